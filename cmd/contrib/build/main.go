@@ -2,8 +2,10 @@ package main
 
 import (
 	"errors"
+	"os"
 
 	"github.com/1Password/shell-plugins/plugins"
+	"github.com/1Password/shell-plugins/sdk/credentialprocess"
 	"github.com/1Password/shell-plugins/sdk/rpc/proto"
 	"github.com/1Password/shell-plugins/sdk/rpc/server"
 	"github.com/1Password/shell-plugins/sdk/schema"
@@ -15,6 +17,11 @@ import (
 var PluginName string
 
 func main() {
+	// Provisioners can point tools at the plugin executable to read provisioned credentials.
+	if credentialprocess.IsInvocation(os.Args) {
+		os.Exit(credentialprocess.Run(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	plugin.Serve(&plugin.ServeConfig{
 		HandshakeConfig: plugin.HandshakeConfig{
 			ProtocolVersion:  proto.Version,
@@ -0,0 +1,234 @@
+// Package docs renders the documentation of a plugin directly from its schema, so that it can't drift from the code.
+package docs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+// The home and temp dirs that provisioners get in the dry run, so that the paths of provisioned files show up
+// as they would in the user's shell.
+const (
+	homeDir = "~"
+	tempDir = "$TMPDIR"
+)
+
+// provisionTimeout limits how long a single dry run may take.
+const provisionTimeout = 5 * time.Second
+
+// Render renders the markdown documentation of the plugin: the credentials it supports with their fields, the
+// environment variables and files that get provisioned for them, and the commands it covers.
+//
+// What gets provisioned is determined by running the provisioners in dry-run mode with example values.
+func Render(plugin schema.Plugin) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", plugin.Platform.Name)
+	fmt.Fprintf(&b, "Shell plugin name: `%s`\n", plugin.Name)
+	if plugin.Platform.Homepage != nil {
+		fmt.Fprintf(&b, "\nHomepage: %s\n", plugin.Platform.Homepage)
+	}
+
+	if len(plugin.Credentials) > 0 {
+		b.WriteString("\n## Credentials\n")
+	}
+	for _, credential := range plugin.Credentials {
+		fmt.Fprintf(&b, "\n### %s\n", credential.Name)
+
+		if credential.DocsURL != nil {
+			fmt.Fprintf(&b, "\nDocumentation: %s\n", credential.DocsURL)
+		}
+		if credential.ManagementURL != nil {
+			fmt.Fprintf(&b, "\nManage: %s\n", credential.ManagementURL)
+		}
+
+		b.WriteString("\n| Field | Description | Secret | Optional |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, field := range credential.Fields {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", field.Name, tableCell(field.MarkdownDescription), yesNo(field.Secret), yesNo(field.Optional))
+		}
+
+		if credential.DefaultProvisioner != nil {
+			writeProvisioned(&b, credential, credential.DefaultProvisioner, nil)
+		}
+	}
+
+	if len(plugin.Executables) > 0 {
+		b.WriteString("\n## Commands\n")
+	}
+	for _, executable := range plugin.Executables {
+		fmt.Fprintf(&b, "\n### %s\n\n", executable.Name)
+		fmt.Fprintf(&b, "Runs: `%s`\n", strings.Join(executable.Runs, " "))
+		if len(executable.Aliases) > 0 {
+			fmt.Fprintf(&b, "\nAliases: %s\n", codeList(executable.Aliases))
+		}
+		if executable.DocsURL != nil {
+			fmt.Fprintf(&b, "\nDocumentation: %s\n", executable.DocsURL)
+		}
+
+		if len(executable.Uses) > 0 {
+			b.WriteString("\nUses:\n\n")
+		}
+		for _, usage := range executable.Uses {
+			fmt.Fprintf(&b, "- %s\n", usageTitle(usage))
+		}
+
+		for _, usage := range executable.Uses {
+			if usage.Provisioner == nil || usage.Plugin != "" {
+				continue
+			}
+			credential := findCredential(plugin, usage.Name)
+			if credential == nil {
+				continue
+			}
+			writeProvisioned(&b, *credential, usage.Provisioner, executable.Runs)
+		}
+	}
+
+	return b.String()
+}
+
+// writeProvisioned writes the environment variables, files and command-line args that the provisioner provisions
+// for the credential, based on a dry run with example values.
+func writeProvisioned(b *strings.Builder, credential schema.CredentialType, provisioner sdk.Provisioner, commandLine []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), provisionTimeout)
+	defer cancel()
+
+	// Try with all fields first to also cover what optional fields provision, and fall back to only the required fields.
+	var report provision.DryRunReport
+	for _, includeOptional := range []bool{true, false} {
+		report = provision.DryRun(ctx, provisioner, sdk.ProvisionInput{
+			HomeDir:    homeDir,
+			TempDir:    tempDir,
+			ItemFields: exampleItemFields(credential, includeOptional),
+		}, commandLine)
+		if len(report.Errors) == 0 {
+			break
+		}
+	}
+
+	heading := "Provisioned as"
+	if commandLine != nil {
+		heading = fmt.Sprintf("%s is provisioned to `%s` as", credential.Name, strings.Join(commandLine, " "))
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Fprintf(b, "\n%s: depends on the local configuration, so could not be determined with example values.\n", heading)
+		return
+	}
+
+	var lines []string
+	for _, name := range sortedKeys(report.Environment) {
+		lines = append(lines, fmt.Sprintf("- Environment variable `%s`", name))
+	}
+	for _, path := range sortedKeys(report.Files) {
+		lines = append(lines, fmt.Sprintf("- File `%s`", path))
+	}
+	if args := addedArgs(commandLine, report.CommandLine); len(args) > 0 {
+		lines = append(lines, fmt.Sprintf("- Command-line arguments `%s`", strings.Join(args, " ")))
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s:\n\n%s\n", heading, strings.Join(lines, "\n"))
+}
+
+// exampleItemFields returns example values for the fields of the credential, based on their value composition.
+func exampleItemFields(credential schema.CredentialType, includeOptional bool) map[sdk.FieldName]string {
+	fields := make(map[sdk.FieldName]string)
+	for _, field := range credential.Fields {
+		if field.Optional && !includeOptional {
+			continue
+		}
+		if field.Composition != nil {
+			fields[field.Name] = plugintest.ExampleSecretFromComposition(*field.Composition)
+		} else {
+			fields[field.Name] = "example"
+		}
+	}
+	return fields
+}
+
+// addedArgs returns the args of the provisioned command line that aren't part of the original command line.
+func addedArgs(original []string, provisioned []string) []string {
+	count := make(map[string]int)
+	for _, arg := range original {
+		count[arg]++
+	}
+
+	var added []string
+	for _, arg := range provisioned {
+		if count[arg] > 0 {
+			count[arg]--
+			continue
+		}
+		added = append(added, arg)
+	}
+	return added
+}
+
+func findCredential(plugin schema.Plugin, name sdk.CredentialName) *schema.CredentialType {
+	for _, credential := range plugin.Credentials {
+		if credential.Name == name {
+			return &credential
+		}
+	}
+	return nil
+}
+
+func usageTitle(usage schema.CredentialUsage) string {
+	var title string
+	switch {
+	case usage.SelectFrom != nil:
+		title = "A credential of your choice"
+	case usage.Plugin != "":
+		title = fmt.Sprintf("%s (from the `%s` plugin)", usage.Name, usage.Plugin)
+	default:
+		title = usage.Name.String()
+	}
+
+	if usage.Optional {
+		title += " (optional)"
+	}
+	if usage.Description != "" {
+		title += ": " + usage.Description
+	}
+	return title
+}
+
+func codeList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "`"+value+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func tableCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "\n", " "), "|", "\\|")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	plugin := schema.Plugin{
+		Name: "acme",
+		Platform: schema.PlatformInfo{
+			Name:     "Acme",
+			Homepage: sdk.URL("https://acme.com"),
+		},
+		Credentials: []schema.CredentialType{
+			{
+				Name:    credname.APIToken,
+				DocsURL: sdk.URL("https://acme.com/docs/tokens"),
+				Fields: []schema.CredentialField{
+					{
+						Name:                fieldname.Token,
+						MarkdownDescription: "Token used to authenticate to Acme.",
+						Secret:              true,
+					},
+					{
+						Name:                fieldname.Host,
+						MarkdownDescription: "The Acme host, e.g. acme.com | acme.eu.",
+						Optional:            true,
+					},
+				},
+				DefaultProvisioner: provision.EnvVars(map[string]sdk.FieldName{
+					"ACME_TOKEN": fieldname.Token,
+					"ACME_HOST":  fieldname.Host,
+				}),
+			},
+		},
+		Executables: []schema.Executable{
+			{
+				Name: "Acme CLI",
+				Runs: []string{"acme"},
+				Uses: []schema.CredentialUsage{
+					{
+						Name: credname.APIToken,
+						Provisioner: provision.TempFile(provision.FieldAsFile(fieldname.Token),
+							provision.Filename("token"),
+							provision.AddArgs("--token-file", "{{ .Path }}"),
+						),
+					},
+				},
+			},
+		},
+	}
+
+	expected := `# Acme
+
+Shell plugin name: ` + "`acme`" + `
+
+Homepage: https://acme.com
+
+## Credentials
+
+### API Token
+
+Documentation: https://acme.com/docs/tokens
+
+| Field | Description | Secret | Optional |
+| --- | --- | --- | --- |
+| Token | Token used to authenticate to Acme. | Yes | No |
+| Host | The Acme host, e.g. acme.com \| acme.eu. | No | Yes |
+
+Provisioned as:
+
+- Environment variable ` + "`ACME_HOST`" + `
+- Environment variable ` + "`ACME_TOKEN`" + `
+
+## Commands
+
+### Acme CLI
+
+Runs: ` + "`acme`" + `
+
+Uses:
+
+- API Token
+
+API Token is provisioned to ` + "`acme`" + ` as:
+
+- File ` + "`$TMPDIR/token`" + `
+- Command-line arguments ` + "`--token-file $TMPDIR/token`" + `
+`
+
+	assert.Equal(t, expected, Render(plugin))
+}
@@ -0,0 +1,139 @@
+// Package fixtures generates fake credentials for a plugin and provisions them to disk, so that contributors can
+// test a plugin manually without using real production secrets.
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+// Credential describes the fake credential that got generated for a credential type.
+type Credential struct {
+	Name sdk.CredentialName
+
+	// Fields contains the generated fake values, using the format: field name -> value.
+	Fields map[sdk.FieldName]string
+
+	// EnvFile is the path to the file with the environment variables that got provisioned, which can be sourced
+	// in a shell. Empty if no environment variables got provisioned.
+	EnvFile string
+
+	// Files contains the paths of the files that got provisioned.
+	Files []string
+
+	// Errors contains the errors that provisioning reported.
+	Errors []string
+}
+
+// Generate generates fake values for the fields of each credential type of the plugin, based on their value
+// compositions, and provisions them using the default provisioners. Provisioned files and an env file for
+// each credential type are written to the specified directory, with "home" and "tmp" subdirectories standing
+// in for the home dir and temp dir.
+func Generate(ctx context.Context, plugin schema.Plugin, dir string) ([]Credential, error) {
+	homeDir := filepath.Join(dir, "home")
+	tempDir := filepath.Join(dir, "tmp")
+	for _, d := range []string{homeDir, tempDir} {
+		err := os.MkdirAll(d, 0700)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var credentials []Credential
+	for _, credentialType := range plugin.Credentials {
+		credential := Credential{
+			Name:   credentialType.Name,
+			Fields: FakeFields(credentialType),
+		}
+
+		if credentialType.DefaultProvisioner != nil {
+			out := sdk.ProvisionOutput{
+				Environment: make(map[string]string),
+				Files:       make(map[string]sdk.OutputFile),
+			}
+			credentialType.DefaultProvisioner.Provision(ctx, sdk.ProvisionInput{
+				HomeDir:    homeDir,
+				TempDir:    tempDir,
+				ItemFields: credential.Fields,
+			}, &out)
+
+			for _, e := range out.Diagnostics.Errors {
+				credential.Errors = append(credential.Errors, e.Message)
+			}
+
+			for _, path := range sortedKeys(out.Files) {
+				if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+					credential.Errors = append(credential.Errors, fmt.Sprintf("skipped writing %s, which is outside of %s", path, dir))
+					continue
+				}
+				err := os.MkdirAll(filepath.Dir(path), 0700)
+				if err != nil {
+					return nil, err
+				}
+				err = os.WriteFile(path, out.Files[path].Contents, 0600)
+				if err != nil {
+					return nil, err
+				}
+				credential.Files = append(credential.Files, path)
+			}
+
+			if len(out.Environment) > 0 {
+				credential.EnvFile = filepath.Join(dir, envFilename(credentialType.Name))
+				err := os.WriteFile(credential.EnvFile, []byte(envFileContents(out.Environment)), 0600)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, nil
+}
+
+// FakeFields generates fake values for the fields of the credential type. Fields with a value composition get a
+// value with the right prefix, length and charset. Required fields without a value
+// composition get a placeholder value and optional fields without a value composition are left empty.
+func FakeFields(credentialType schema.CredentialType) map[sdk.FieldName]string {
+	fields := plugintest.ExampleItemFields(credentialType)
+	for _, field := range credentialType.Fields {
+		if _, ok := fields[field.Name]; !ok && !field.Optional {
+			fields[field.Name] = "example"
+		}
+	}
+	return fields
+}
+
+func envFilename(name sdk.CredentialName) string {
+	return strings.ToLower(strings.ReplaceAll(name.String(), " ", "_")) + ".env"
+}
+
+func envFileContents(environment map[string]string) string {
+	var b strings.Builder
+	for _, name := range sortedKeys(environment) {
+		fmt.Fprintf(&b, "export %s=%s\n", name, shellQuote(environment[name]))
+	}
+	return b.String()
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,75 @@
+package fixtures
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	plugin := schema.Plugin{
+		Name: "acme",
+		Credentials: []schema.CredentialType{
+			{
+				Name: credname.APIToken,
+				Fields: []schema.CredentialField{
+					{
+						Name:   fieldname.Token,
+						Secret: true,
+						Composition: &schema.ValueComposition{
+							Length:  32,
+							Prefix:  "acme_",
+							Charset: schema.Charset{Lowercase: true, Digits: true},
+						},
+					},
+					{
+						Name:     fieldname.Host,
+						Optional: true,
+					},
+				},
+				DefaultProvisioner: provision.EnvVars(map[string]sdk.FieldName{
+					"ACME_TOKEN": fieldname.Token,
+				}),
+			},
+			{
+				Name: credname.DatabaseCredentials,
+				Fields: []schema.CredentialField{
+					{
+						Name:   fieldname.Password,
+						Secret: true,
+					},
+				},
+				DefaultProvisioner: provision.TempFile(provision.FieldAsFile(fieldname.Password), provision.Filename("password")),
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	credentials, err := Generate(context.Background(), plugin, dir)
+	assert.NoError(t, err)
+	assert.Len(t, credentials, 2)
+
+	token := credentials[0].Fields[fieldname.Token]
+	assert.Len(t, token, 32)
+	assert.True(t, strings.HasPrefix(token, "acme_"))
+	assert.NotContains(t, credentials[0].Fields, fieldname.Host)
+
+	env, err := os.ReadFile(credentials[0].EnvFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "export ACME_TOKEN='"+token+"'\n", string(env))
+
+	assert.Equal(t, map[sdk.FieldName]string{fieldname.Password: "example"}, credentials[1].Fields)
+	assert.Equal(t, []string{filepath.Join(dir, "tmp", "password")}, credentials[1].Files)
+	password, err := os.ReadFile(credentials[1].Files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "example", string(password))
+}
@@ -0,0 +1,282 @@
+// Package importdoctor runs the importers of a plugin against the current machine and reports what was found,
+// skipped, or failed, to help debug why credentials don't get imported.
+package importdoctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+// masked is what the values of secret fields get replaced with in the report, matching how sdk.Redactions
+// masks them in errors and logs.
+const masked = "[REDACTED]"
+
+// Status describes the outcome of a single import attempt.
+type Status string
+
+const (
+	// StatusFound means that the attempt found one or more candidates.
+	StatusFound Status = "found"
+
+	// StatusSkipped means that the attempt found nothing, e.g. because the file doesn't exist.
+	StatusSkipped Status = "skipped"
+
+	// StatusFailed means that the attempt reported errors, e.g. because a file failed to parse.
+	StatusFailed Status = "failed"
+)
+
+// Report contains the outcome of running the importers of all credential types of a plugin.
+type Report struct {
+	Plugin      string
+	Credentials []CredentialReport
+}
+
+// CredentialReport contains the outcome of running the importer of a single credential type.
+type CredentialReport struct {
+	Name sdk.CredentialName
+
+	// HasImporter is false if the credential type has no importer, in which case there are no attempts.
+	HasImporter bool
+
+	Attempts []AttemptReport
+}
+
+// AttemptReport contains the redacted outcome of a single import attempt.
+type AttemptReport struct {
+	Status Status
+
+	// Source describes where the attempt looked, e.g. "file ~/.config/gh/hosts.yml".
+	Source string
+
+	// Reason explains why the attempt was skipped.
+	Reason string
+
+	// Candidates contains the candidates that were found, with the values of secret fields masked.
+	Candidates []sdk.ImportCandidate
+
+	Errors []sdk.Error
+	Logs   []sdk.LogEntry
+}
+
+// Diagnose runs the importer of each credential type of the plugin and reports the outcome of every attempt. The
+// values of secret fields are masked in the report, and so are any occurrences of them in errors and logs.
+func Diagnose(ctx context.Context, plugin schema.Plugin, in sdk.ImportInput) Report {
+	report := Report{Plugin: plugin.Name}
+	for _, credential := range plugin.Credentials {
+		credentialReport := CredentialReport{
+			Name:        credential.Name,
+			HasImporter: credential.Importer != nil,
+		}
+		if credential.Importer == nil {
+			report.Credentials = append(report.Credentials, credentialReport)
+			continue
+		}
+
+		out := runImporter(ctx, credential.Importer, in)
+
+		var redactions sdk.Redactions
+		for _, attempt := range out.Attempts {
+			for _, candidate := range attempt.Candidates {
+				for name, value := range candidate.Fields {
+					if isSecret(credential, name) {
+						redactions.Add(value)
+					}
+				}
+			}
+		}
+
+		for _, attempt := range out.Attempts {
+			credentialReport.Attempts = append(credentialReport.Attempts, attemptReport(credential, attempt, in, redactions))
+		}
+		report.Credentials = append(report.Credentials, credentialReport)
+	}
+	return report
+}
+
+// runImporter runs the importer, recording a panic as a failed attempt, so that a single broken importer doesn't
+// prevent the rest of the report.
+func runImporter(ctx context.Context, importer sdk.Importer, in sdk.ImportInput) (out sdk.ImportOutput) {
+	defer func() {
+		if r := recover(); r != nil {
+			attempt := out.NewAttempt(sdk.ImportSource{Other: sdk.CustomSource{Type: "importer"}})
+			attempt.AddError(fmt.Errorf("importer panicked: %v", r))
+		}
+	}()
+
+	importer(ctx, in, &out)
+	return out
+}
+
+func attemptReport(credential schema.CredentialType, attempt *sdk.ImportAttempt, in sdk.ImportInput, redactions sdk.Redactions) AttemptReport {
+	report := AttemptReport{
+		Source: describeSource(attempt.Source),
+	}
+
+	for _, candidate := range attempt.Candidates {
+		redacted := sdk.ImportCandidate{
+			NameHint:  candidate.NameHint,
+			ExpiresAt: candidate.ExpiresAt,
+			Fields:    make(map[sdk.FieldName]string),
+		}
+		for name, value := range candidate.Fields {
+			if isSecret(credential, name) {
+				value = masked
+			}
+			redacted.Fields[name] = value
+		}
+		report.Candidates = append(report.Candidates, redacted)
+	}
+
+	for _, e := range attempt.Diagnostics.Errors {
+		report.Errors = append(report.Errors, sdk.Error{Message: redactions.Redact(e.Message), Code: e.Code})
+	}
+	for _, entry := range attempt.Diagnostics.Logs {
+		entry.Message = redactions.Redact(entry.Message)
+		report.Logs = append(report.Logs, entry)
+	}
+
+	switch {
+	case len(report.Errors) > 0:
+		report.Status = StatusFailed
+	case len(report.Candidates) > 0:
+		report.Status = StatusFound
+	default:
+		report.Status = StatusSkipped
+		report.Reason = skipReason(attempt.Source, in)
+	}
+	return report
+}
+
+// skipReason explains why an attempt without candidates or errors found nothing.
+func skipReason(source sdk.ImportSource, in sdk.ImportInput) string {
+	for _, path := range source.Files {
+		if _, err := os.Stat(resolvePath(path, in)); err == nil {
+			return "file exists, but contains no credential"
+		}
+	}
+	if len(source.Files) > 0 {
+		return "file does not exist"
+	}
+
+	for _, envVar := range source.Env {
+		if os.Getenv(envVar) != "" {
+			return "environment variable is set, but contains no credential"
+		}
+	}
+	if len(source.Env) == 1 {
+		return "environment variable is not set"
+	}
+	if len(source.Env) > 1 {
+		return "none of the environment variables are set"
+	}
+
+	return "nothing found"
+}
+
+// resolvePath resolves the path of a file source in the same way importer.TryFile does.
+func resolvePath(path string, in sdk.ImportInput) string {
+	if strings.HasPrefix(path, "~/") {
+		return in.FromHomeDir(strings.TrimPrefix(path, "~/"))
+	}
+	if strings.HasPrefix(path, "/") {
+		return in.FromRootDir(path)
+	}
+	return path
+}
+
+func describeSource(source sdk.ImportSource) string {
+	var parts []string
+	if len(source.Files) > 0 {
+		parts = append(parts, "file "+strings.Join(source.Files, ", "))
+	}
+	if len(source.Env) > 0 {
+		envVars := append([]string{}, source.Env...)
+		sort.Strings(envVars)
+		parts = append(parts, "env "+strings.Join(envVars, ", "))
+	}
+	if source.Other.Type != "" {
+		other := []string{source.Other.Type}
+		for _, value := range source.Other.Value {
+			if value != "" {
+				other = append(other, value)
+			}
+		}
+		parts = append(parts, strings.Join(other, " "))
+	}
+	if len(parts) == 0 {
+		return "unknown source"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func isSecret(credential schema.CredentialType, name sdk.FieldName) bool {
+	field := credential.Field(name.String())
+	// Treat fields that are not in the schema as secret, to err on the side of caution.
+	return field == nil || field.Secret
+}
+
+// String renders the report in a human-readable format.
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Import report for %s\n", r.Plugin)
+
+	for _, credential := range r.Credentials {
+		fmt.Fprintf(&b, "\n%s:\n", credential.Name)
+		if !credential.HasImporter {
+			b.WriteString("  no importer defined\n")
+			continue
+		}
+		if len(credential.Attempts) == 0 {
+			b.WriteString("  importer made no attempts\n")
+		}
+
+		for _, attempt := range credential.Attempts {
+			fmt.Fprintf(&b, "  [%s] %s", attempt.Status, attempt.Source)
+			if attempt.Reason != "" {
+				fmt.Fprintf(&b, ": %s", attempt.Reason)
+			}
+			b.WriteString("\n")
+
+			for _, candidate := range attempt.Candidates {
+				b.WriteString("    candidate")
+				if candidate.NameHint != "" {
+					fmt.Fprintf(&b, " %q", candidate.NameHint)
+				}
+				b.WriteString(":")
+				for _, name := range sortedFieldNames(candidate.Fields) {
+					fmt.Fprintf(&b, " %s=%s", name, candidate.Fields[name])
+				}
+				b.WriteString("\n")
+			}
+			for _, e := range attempt.Errors {
+				if e.Code != "" {
+					fmt.Fprintf(&b, "    error (%s): %s\n", e.Code, e.Message)
+				} else {
+					fmt.Fprintf(&b, "    error: %s\n", e.Message)
+				}
+			}
+			for _, entry := range attempt.Logs {
+				fmt.Fprintf(&b, "    %s: %s\n", entry.Level, entry.Message)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func sortedFieldNames(fields map[sdk.FieldName]string) []sdk.FieldName {
+	names := make([]sdk.FieldName, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,92 @@
+package importdoctor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnose(t *testing.T) {
+	homeDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(homeDir, ".acme"), []byte("token: acme_secret_value"), 0600)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(homeDir, ".acme-broken"), []byte("broken"), 0600)
+	assert.NoError(t, err)
+
+	plugin := schema.Plugin{
+		Name: "acme",
+		Credentials: []schema.CredentialType{
+			{
+				Name: credname.APIToken,
+				Fields: []schema.CredentialField{
+					{Name: fieldname.Token, Secret: true},
+					{Name: fieldname.Host},
+				},
+				Importer: importer.TryAll(
+					importer.TryEnvVarPair(map[string]sdk.FieldName{"ACME_IMPORT_DOCTOR_TEST_TOKEN": fieldname.Token}),
+					importer.TryFile("~/.acme", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+						out.AddCandidate(sdk.ImportCandidate{
+							NameHint: "work",
+							Fields: map[sdk.FieldName]string{
+								fieldname.Token: "acme_secret_value",
+								fieldname.Host:  "acme.com",
+							},
+						})
+					}),
+					importer.TryFile("~/.acme-broken", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+						out.AddError(errors.New("could not parse token acme_secret_value"))
+					}),
+					importer.TryFile("~/.acme-missing", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+					}),
+				),
+			},
+			{
+				Name: credname.AccessKey,
+			},
+		},
+	}
+
+	report := Diagnose(context.Background(), plugin, sdk.ImportInput{HomeDir: homeDir})
+
+	assert.Equal(t, `Import report for acme
+
+API Token:
+  [skipped] env ACME_IMPORT_DOCTOR_TEST_TOKEN: environment variable is not set
+  [found] file ~/.acme
+    candidate "work": Host=acme.com Token=[REDACTED]
+  [failed] file ~/.acme-broken
+    error: could not parse token [REDACTED]
+  [skipped] file ~/.acme-missing: file does not exist
+
+Access Key:
+  no importer defined
+`, report.String())
+}
+
+func TestDiagnoseRecoversFromPanics(t *testing.T) {
+	plugin := schema.Plugin{
+		Name: "acme",
+		Credentials: []schema.CredentialType{
+			{
+				Name: credname.APIToken,
+				Importer: func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+					panic("oops")
+				},
+			},
+		},
+	}
+
+	report := Diagnose(context.Background(), plugin, sdk.ImportInput{})
+	assert.Len(t, report.Credentials[0].Attempts, 1)
+	assert.Equal(t, StatusFailed, report.Credentials[0].Attempts[0].Status)
+	assert.Equal(t, "importer panicked: oops", report.Credentials[0].Attempts[0].Errors[0].Message)
+}
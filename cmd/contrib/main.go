@@ -2,25 +2,42 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"go/format"
 	"html/template"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"unicode"
 
+	"github.com/1Password/shell-plugins/cmd/contrib/docs"
+	"github.com/1Password/shell-plugins/cmd/contrib/fixtures"
+	"github.com/1Password/shell-plugins/cmd/contrib/importdoctor"
+	"github.com/1Password/shell-plugins/cmd/contrib/manifest"
+	"github.com/1Password/shell-plugins/cmd/contrib/run"
+	"github.com/1Password/shell-plugins/cmd/contrib/upstream"
 	"github.com/1Password/shell-plugins/plugins"
+	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/plugintest"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v2"
 )
 
 const exampleSecretsCommandSuffix = "example-secrets"
 const validateCommandSuffix = "validate"
 const existsCommandSuffix = "exists"
+const docsCommandSuffix = "docs"
+const fakeCredentialsCommandSuffix = "fake-credentials"
 
 func main() {
 	command := os.Args[1]
@@ -45,6 +62,19 @@ func main() {
 		if strings.HasSuffix(pluginCommand, existsCommandSuffix) {
 			return
 		}
+
+		if strings.HasSuffix(pluginCommand, docsCommandSuffix) {
+			fmt.Print(docs.Render(plugin))
+			return
+		}
+
+		if strings.HasSuffix(pluginCommand, fakeCredentialsCommandSuffix) {
+			err := generateFakeCredentials(plugin, os.Args[2:])
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
 	}
 
 	if command == validateCommandSuffix {
@@ -71,7 +101,39 @@ func main() {
 	}
 
 	if command == "new-plugin" {
-		err := newPlugin()
+		err := newPlugin(os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if command == "run" {
+		exitCode, err := runPlugin(os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(exitCode)
+	}
+
+	if command == "import-doctor" {
+		err := importDoctor(os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if command == "manifest.json" {
+		err := generateManifest(os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if command == "upstream-diff" {
+		err := upstreamDiff(os.Args[2:])
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -95,7 +157,173 @@ func isPluginCommand(command string) (isPluginCommand bool, pluginName string, p
 	return true, chunks[0], chunks[1]
 }
 
-func newPlugin() error {
+// configFileFormats lists the config file formats that the new-plugin command can generate an importer for,
+// mapped to a placeholder path of the config file and the name of the generated test fixture.
+var configFileFormats = map[string]struct {
+	Path        string
+	FixtureName string
+}{
+	"ini":   {Path: "~/.%s/credentials", FixtureName: "credentials"},
+	"json":  {Path: "~/.%s/config.json", FixtureName: "config.json"},
+	"yaml":  {Path: "~/.config/%s/config.yml", FixtureName: "config.yml"},
+	"env":   {Path: "~/.%s/.env", FixtureName: "env"},
+	"netrc": {Path: "~/.netrc", FixtureName: "netrc"},
+}
+
+// keyValueFlags is a repeatable command-line flag with values in the format: key=value.
+type keyValueFlags map[string]string
+
+func (f keyValueFlags) String() string {
+	return fmt.Sprint(map[string]string(f))
+}
+
+func (f keyValueFlags) Set(value string) error {
+	i := strings.Index(value, "=")
+	if i <= 0 {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	f[value[:i]] = value[i+1:]
+	return nil
+}
+
+// runPlugin runs a command with the credentials of a plugin provisioned, using example or locally supplied values.
+// Usage: run <plugin> [--field "<field name>=<value>"]... [--setting <name>=<value>]... -- <command> [args...]
+func runPlugin(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, errors.New("usage: run <plugin> [--field \"<field name>=<value>\"]... [--setting <name>=<value>]... -- <command> [args...]")
+	}
+
+	plugin, err := plugins.Get(args[0])
+	if err != nil {
+		return 0, err
+	}
+
+	fields := keyValueFlags{}
+	settings := keyValueFlags{}
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.Var(fields, "field", "value of an item field to provision instead of an example value, e.g. \"Token=abc123\"")
+	flags.Var(settings, "setting", "value of a plugin setting, e.g. \"region=eu\"")
+	err = flags.Parse(args[1:])
+	if err != nil {
+		return 0, err
+	}
+
+	commandLine := flags.Args()
+	if len(commandLine) == 0 {
+		return 0, errors.New("no command specified to run, pass it after --")
+	}
+
+	opts := run.Options{
+		Fields:   make(map[sdk.FieldName]string),
+		Settings: settings,
+	}
+	for name, value := range fields {
+		opts.Fields[sdk.FieldName(name)] = value
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return run.Run(ctx, plugin, commandLine, opts)
+}
+
+// importDoctor runs the importers of a plugin against the current machine and prints a redacted report.
+// Usage: import-doctor <plugin>
+func importDoctor(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: import-doctor <plugin>")
+	}
+
+	plugin, err := plugins.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	report := importdoctor.Diagnose(context.Background(), plugin, sdk.ImportInput{
+		HomeDir: homeDir,
+		RootDir: "/",
+		OS:      runtime.GOOS,
+	})
+	fmt.Print(report.String())
+	return nil
+}
+
+func upstreamDiff(args []string) error {
+	flags := flag.NewFlagSet("upstream-diff", flag.ContinueOnError)
+	upstreamDir := flags.String("upstream", "", "path to a checkout of the upstream repository, instead of cloning it")
+	upstreamURL := flags.String("url", upstream.DefaultURL, "Git URL of the upstream repository to clone")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *upstreamDir == "" {
+		tempDir, err := os.MkdirTemp("", "shell-plugins-upstream-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tempDir)
+
+		*upstreamDir = filepath.Join(tempDir, "shell-plugins")
+		err = upstream.Clone(ctx, *upstreamURL, *upstreamDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	localSchemas, err := upstream.LoadSchemas(ctx, ".")
+	if err != nil {
+		return err
+	}
+	upstreamSchemas, err := upstream.LoadSchemas(ctx, *upstreamDir)
+	if err != nil {
+		return err
+	}
+
+	report, err := upstream.Compare(localSchemas, ".", upstreamSchemas, *upstreamDir)
+	if err != nil {
+		return err
+	}
+	fmt.Print(report.String())
+	return nil
+}
+
+func newPlugin(args []string) error {
+	flags := flag.NewFlagSet("new-plugin", flag.ContinueOnError)
+	configFormat := flags.String("config-format", "", "format of the config file to generate an importer for: ini, json, yaml, env or netrc")
+	specPath := flags.String("spec", "", "path to a YAML or JSON plugin spec to generate the plugin from, instead of asking questions")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if _, ok := configFileFormats[*configFormat]; *configFormat != "" && !ok {
+		return fmt.Errorf("unsupported config format %q, expected one of: ini, json, yaml, env, netrc", *configFormat)
+	}
+
+	if *specPath != "" {
+		if *configFormat != "" {
+			return errors.New("the config format must be set in the spec when generating a plugin from a spec")
+		}
+
+		contents, err := os.ReadFile(*specPath)
+		if err != nil {
+			return err
+		}
+
+		result, err := scaffoldFromSpec(contents)
+		if err != nil {
+			return fmt.Errorf("invalid plugin spec %s: %w", *specPath, err)
+		}
+
+		return renderTemplates(filepath.Join("plugins", result.Name), result.templates(), result)
+	}
+
 	var questionnaire = []*survey.Question{
 		{
 			Name:     "Name",
@@ -134,36 +362,203 @@ func newPlugin() error {
 		},
 	}
 
-	result := struct {
-		Name              string
-		PlatformName      string
-		Executable        string
-		CredentialName    string
-		ExampleCredential string
-
-		// Derived
-		PlatformNameUpperCamelCase   string
-		ValueComposition             schema.ValueComposition
-		FieldName                    string
-		FieldNameUpperCamelCase      string
-		CredentialEnvVarName         string
-		IsNewCredentialName          bool
-		CredentialNameUpperCamelCase string
-		CredentialNameSnakeCase      string
-		TestCredentialExample        string
-		ExecutableSnakeCase          string
-	}{}
-
-	err := survey.Ask(questionnaire, &result)
+	result := pluginScaffold{
+		ConfigFormat: *configFormat,
+	}
+
+	err = survey.Ask(questionnaire, &result)
 	if err != nil {
 		return err
 	}
 
-	if result.ExampleCredential != "" {
-		result.ValueComposition = getValueComposition(result.ExampleCredential)
-		result.TestCredentialExample = plugintest.ExampleSecretFromComposition(result.ValueComposition)
+	result.derive()
+
+	return renderTemplates(filepath.Join("plugins", result.Name), result.templates(), result)
+}
+
+// pluginSpec declaratively describes a simple plugin: one credential that gets provisioned as an env var or a
+// file, and imported from an env var and/or a config file. It can be written in YAML or JSON.
+type pluginSpec struct {
+	Name     string `yaml:"name"`
+	Platform struct {
+		Name     string `yaml:"name"`
+		Homepage string `yaml:"homepage"`
+	} `yaml:"platform"`
+	Credential *struct {
+		Name          string `yaml:"name"`
+		Field         string `yaml:"field"`
+		DocsURL       string `yaml:"docs_url"`
+		ManagementURL string `yaml:"management_url"`
+
+		// EnvVar is the env var that the credential gets provisioned to and imported from.
+		EnvVar      string `yaml:"env_var"`
+		Composition *struct {
+			Length  int      `yaml:"length"`
+			Prefix  string   `yaml:"prefix"`
+			Charset []string `yaml:"charset"`
+		} `yaml:"composition"`
+
+		// File can be set to provision the credential as a file instead of as an env var.
+		File *struct {
+			Name       string `yaml:"name"`
+			PathEnvVar string `yaml:"path_env_var"`
+		} `yaml:"file"`
+
+		ConfigFile *struct {
+			Format string `yaml:"format"`
+			Path   string `yaml:"path"`
+			Key    string `yaml:"key"`
+		} `yaml:"config_file"`
+	} `yaml:"credential"`
+	Executable *struct {
+		Name    string `yaml:"name"`
+		Command string `yaml:"command"`
+		DocsURL string `yaml:"docs_url"`
+	} `yaml:"executable"`
+}
+
+// scaffoldFromSpec parses and validates the YAML or JSON plugin spec and returns the scaffold to render.
+func scaffoldFromSpec(contents []byte) (pluginScaffold, error) {
+	var spec pluginSpec
+	err := yaml.UnmarshalStrict(contents, &spec)
+	if err != nil {
+		return pluginScaffold{}, err
+	}
+
+	if spec.Name == "" || strings.ToLower(spec.Name) != spec.Name || strings.ContainsAny(spec.Name, " -_") {
+		return pluginScaffold{}, errors.New(`name must be set and lowercase without spaces, dashes or underscores, e.g. "aws"`)
+	}
+	if spec.Platform.Name == "" || spec.Platform.Homepage == "" {
+		return pluginScaffold{}, errors.New("platform name and homepage must be set")
+	}
+
+	result := pluginScaffold{
+		FromSpec:     true,
+		Name:         spec.Name,
+		PlatformName: spec.Platform.Name,
+		Homepage:     spec.Platform.Homepage,
+	}
+
+	if executable := spec.Executable; executable != nil {
+		if executable.Command == "" || strings.Contains(executable.Command, " ") {
+			return pluginScaffold{}, errors.New(`executable command must be set to the name of the executable, e.g. "aws"`)
+		}
+		result.Executable = executable.Command
+		result.ExecutableName = executable.Name
+		result.ExecutableDocsURL = executable.DocsURL
+	}
+
+	if credential := spec.Credential; credential != nil {
+		result.CredentialName = transformCredentialName(credential.Name).(string)
+		err := validateCredentialName(any(result.CredentialName))
+		if err != nil {
+			return pluginScaffold{}, err
+		}
+		result.FieldName = credential.Field
+		result.DocsURL = credential.DocsURL
+		result.ManagementURL = credential.ManagementURL
+		result.CredentialEnvVarName = credential.EnvVar
+
+		if credential.File != nil {
+			if credential.File.Name == "" {
+				return pluginScaffold{}, errors.New("credential file name must be set")
+			}
+			result.ProvisionFileName = credential.File.Name
+			result.ProvisionFilePathEnvVar = credential.File.PathEnvVar
+		} else if credential.EnvVar == "" {
+			return pluginScaffold{}, errors.New("credential env var or file must be set")
+		}
+
+		if composition := credential.Composition; composition != nil {
+			if composition.Length == 0 {
+				return pluginScaffold{}, errors.New("credential composition length must be set")
+			}
+			result.ValueComposition = schema.ValueComposition{
+				Length: composition.Length,
+				Prefix: composition.Prefix,
+			}
+			for _, charset := range composition.Charset {
+				switch charset {
+				case "uppercase":
+					result.ValueComposition.Charset.Uppercase = true
+				case "lowercase":
+					result.ValueComposition.Charset.Lowercase = true
+				case "digits":
+					result.ValueComposition.Charset.Digits = true
+				case "symbols":
+					result.ValueComposition.Charset.Symbols = true
+				default:
+					return pluginScaffold{}, fmt.Errorf("unsupported charset %q, expected one of: uppercase, lowercase, digits, symbols", charset)
+				}
+			}
+		}
+
+		if configFile := credential.ConfigFile; configFile != nil {
+			if _, ok := configFileFormats[configFile.Format]; !ok {
+				return pluginScaffold{}, fmt.Errorf("unsupported config format %q, expected one of: ini, json, yaml, env, netrc", configFile.Format)
+			}
+			result.ConfigFormat = configFile.Format
+			result.ConfigFilePath = configFile.Path
+			result.ConfigKey = configFile.Key
+		}
+	}
+
+	result.derive()
+	return result, nil
+}
+
+// pluginScaffold holds the answers to the new-plugin questionnaire, as well as the values derived from them
+// that are used to render the plugin templates.
+type pluginScaffold struct {
+	Name              string
+	PlatformName      string
+	Executable        string
+	CredentialName    string
+	ExampleCredential string
+	ConfigFormat      string
+
+	// Derived
+	PlatformNameUpperCamelCase   string
+	ValueComposition             schema.ValueComposition
+	FieldName                    string
+	FieldNameUpperCamelCase      string
+	CredentialEnvVarName         string
+	IsNewCredentialName          bool
+	CredentialNameUpperCamelCase string
+	CredentialNameSnakeCase      string
+	TestCredentialExample        string
+	ExecutableSnakeCase          string
+	FieldNameSnakeCase           string
+	ConfigFilePath               string
+	ConfigFixtureName            string
+	ConfigKey                    string
+	Homepage                     string
+	DocsURL                      string
+	ManagementURL                string
+	ExecutableName               string
+	ExecutableDocsURL            string
+
+	// FromSpec is set if the scaffold was generated from a plugin spec, which has no placeholder values to
+	// check, so the generated plugin doesn't need TODOs.
+	FromSpec bool
+
+	// ProvisionFileName is set if the credential should be provisioned as a temp file instead of an env var.
+	ProvisionFileName       string
+	ProvisionFilePathEnvVar string
+
+	// HasImporter is set if the credential can be imported from an env var or a config file.
+	HasImporter bool
+}
+
+// derive fills in the derived values of the scaffold based on the answers.
+func (s *pluginScaffold) derive() {
+	if s.ExampleCredential != "" {
+		s.ValueComposition = getValueComposition(s.ExampleCredential)
+	}
+	if s.ValueComposition.Length > 0 {
+		s.TestCredentialExample = plugintest.ExampleSecretFromComposition(s.ValueComposition)
 	} else {
-		result.TestCredentialExample = plugintest.ExampleSecretFromComposition(schema.ValueComposition{
+		s.TestCredentialExample = plugintest.ExampleSecretFromComposition(schema.ValueComposition{
 			Charset: schema.Charset{
 				Uppercase: true,
 				Lowercase: true,
@@ -173,18 +568,18 @@ func newPlugin() error {
 		})
 	}
 
-	result.PlatformNameUpperCamelCase = strings.ReplaceAll(result.PlatformName, " ", "")
+	s.PlatformNameUpperCamelCase = strings.ReplaceAll(s.PlatformName, " ", "")
 
-	credNameSplit := strings.Split(result.CredentialName, " ")
+	credNameSplit := strings.Split(s.CredentialName, " ")
 
-	result.CredentialNameUpperCamelCase = strings.Join(credNameSplit, "")
-	result.CredentialNameSnakeCase = strings.ToLower(strings.Join(credNameSplit, "_"))
-	result.ExecutableSnakeCase = strings.ToLower(strings.ReplaceAll(result.Executable, "-", "_"))
+	s.CredentialNameUpperCamelCase = strings.Join(credNameSplit, "")
+	s.CredentialNameSnakeCase = strings.ToLower(strings.Join(credNameSplit, "_"))
+	s.ExecutableSnakeCase = strings.ToLower(strings.ReplaceAll(s.Executable, "-", "_"))
 
-	result.IsNewCredentialName = true
+	s.IsNewCredentialName = true
 	for _, existing := range credname.ListAll() {
-		if result.CredentialName == existing.String() {
-			result.IsNewCredentialName = false
+		if s.CredentialName == existing.String() {
+			s.IsNewCredentialName = false
 			break
 		}
 	}
@@ -201,25 +596,68 @@ func newPlugin() error {
 	// "Credentials" => "Credentials"
 	lengthCutoff := 7
 	fieldNameSplit := fieldNameSplitFromCredNameSplit(credNameSplit, lengthCutoff)
-	result.FieldName = strings.Join(fieldNameSplit, " ")
-	result.FieldNameUpperCamelCase = strings.Join(fieldNameSplit, "")
-	result.CredentialEnvVarName = strings.ToUpper(strings.Join(append([]string{result.Name}, fieldNameSplit...), "_"))
+	if s.FieldName != "" {
+		fieldNameSplit = strings.Split(s.FieldName, " ")
+	}
+	s.FieldName = strings.Join(fieldNameSplit, " ")
+	s.FieldNameUpperCamelCase = strings.Join(fieldNameSplit, "")
+	s.FieldNameSnakeCase = strings.ToLower(strings.Join(fieldNameSplit, "_"))
+
+	// A plugin spec only contains actual values, so placeholders are only filled in for the questionnaire.
+	if !s.FromSpec {
+		s.CredentialEnvVarName = strings.ToUpper(strings.Join(append([]string{s.Name}, fieldNameSplit...), "_"))
+		s.Homepage = fmt.Sprintf("https://%s.com", s.Name)
+		s.DocsURL = fmt.Sprintf("https://%s.com/docs/%s", s.Name, s.CredentialNameSnakeCase)
+		s.ManagementURL = fmt.Sprintf("https://console.%s.com/user/security/tokens", s.Name)
+		s.ExecutableDocsURL = fmt.Sprintf("https://%s.com/docs/cli", s.Name)
+	}
+	if s.ExecutableName == "" {
+		s.ExecutableName = s.PlatformName + " CLI"
+	}
+	s.HasImporter = !s.FromSpec || s.CredentialEnvVarName != "" || s.ConfigFormat != ""
 
-	relativeDirPath := filepath.Join("plugins", result.Name)
-	err = os.MkdirAll(relativeDirPath, 0777)
-	if err != nil {
-		return err
+	if format, ok := configFileFormats[s.ConfigFormat]; ok {
+		if s.ConfigFilePath == "" {
+			s.ConfigFilePath = format.Path
+			if strings.Contains(format.Path, "%s") {
+				s.ConfigFilePath = fmt.Sprintf(format.Path, s.Name)
+			}
+		}
+		s.ConfigFixtureName = format.FixtureName
+
+		if s.ConfigKey == "" {
+			switch s.ConfigFormat {
+			case "env":
+				s.ConfigKey = strings.ToUpper(strings.Join(append([]string{s.Name}, fieldNameSplit...), "_"))
+			case "netrc":
+				s.ConfigKey = fmt.Sprintf("api.%s.com", s.Name)
+			default:
+				s.ConfigKey = s.FieldNameSnakeCase
+			}
+		}
 	}
+}
 
+// templates returns the templates to render for the scaffold.
+func (s pluginScaffold) templates() []Template {
 	templates := []Template{pluginTemplate}
-	if result.CredentialName != "" {
+	if s.CredentialName != "" {
 		templates = append(templates, credentialTemplate)
 		templates = append(templates, credentialTestTemplate)
+		if s.ConfigFormat != "" {
+			templates = append(templates, configFixtureTemplates[s.ConfigFormat])
+		}
 	}
-	if result.Executable != "" {
+	if s.Executable != "" {
 		templates = append(templates, executableTemplate)
 	}
 
+	return templates
+}
+
+// renderTemplates renders the templates into the given directory, using the data for both the filenames
+// and the contents. Parent directories are created as needed.
+func renderTemplates(dir string, templates []Template, data any) error {
 	for _, tmpl := range templates {
 		filenameTemplate, err := template.New("filename").Parse(tmpl.Filename)
 		if err != nil {
@@ -227,7 +665,7 @@ func newPlugin() error {
 		}
 
 		var filenameBuf bytes.Buffer
-		err = filenameTemplate.Execute(&filenameBuf, result)
+		err = filenameTemplate.Execute(&filenameBuf, data)
 		if err != nil {
 			return err
 		}
@@ -239,13 +677,25 @@ func newPlugin() error {
 		}
 
 		var contentsBuf bytes.Buffer
-		err = contentsTemplate.Execute(&contentsBuf, result)
+		err = contentsTemplate.Execute(&contentsBuf, data)
 		if err != nil {
 			return err
 		}
 		contents := contentsBuf.Bytes()
+		if filepath.Ext(filename) == ".go" {
+			contents, err = format.Source(contents)
+			if err != nil {
+				return fmt.Errorf("formatting %s: %w", filename, err)
+			}
+		}
 
-		err = os.WriteFile(filepath.Join(relativeDirPath, filename), contents, 0666)
+		path := filepath.Join(dir, filename)
+		err = os.MkdirAll(filepath.Dir(path), 0777)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(path, contents, 0666)
 		if err != nil {
 			return err
 		}
@@ -326,7 +776,7 @@ func New() schema.Plugin {
 		Name: "{{ .Name }}",
 		Platform: schema.PlatformInfo{
 			Name:     "{{ .PlatformName }}",
-			Homepage: sdk.URL("https://{{ .Name }}.com"), // TODO: Check if this is correct
+			Homepage: sdk.URL("{{ .Homepage }}"),{{ if not .FromSpec }} // TODO: Check if this is correct{{ end }}
 		},
 		{{- if .CredentialName }}
 		Credentials: []schema.CredentialType{
@@ -348,10 +798,16 @@ var credentialTemplate = Template{
 	Contents: `package {{ .Name }}
 
 import (
+	{{- if or .ConfigFormat (not .FromSpec) }}
 	"context"
+	{{- end }}
 
+	{{- if or .DocsURL .ManagementURL .CredentialEnvVarName .ConfigFormat (not .FromSpec) }}
 	"github.com/1Password/shell-plugins/sdk"
+	{{- end }}
+	{{- if .HasImporter }}
 	"github.com/1Password/shell-plugins/sdk/importer"
+	{{- end }}
 	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
@@ -361,8 +817,12 @@ import (
 func {{ .CredentialNameUpperCamelCase }}() schema.CredentialType {
 	return schema.CredentialType{
 		Name:          credname.{{ .CredentialNameUpperCamelCase }},{{ if .IsNewCredentialName }} // TODO: Register name in project://sdk/schema/credname/names.go{{ end }}
-		DocsURL:       sdk.URL("https://{{ .Name }}.com/docs/{{ .CredentialNameSnakeCase }}"), // TODO: Replace with actual URL
-		ManagementURL: sdk.URL("https://console.{{ .Name }}.com/user/security/tokens"), // TODO: Replace with actual URL
+		{{- if .DocsURL }}
+		DocsURL:       sdk.URL("{{ .DocsURL }}"),{{ if not .FromSpec }} // TODO: Replace with actual URL{{ end }}
+		{{- end }}
+		{{- if .ManagementURL }}
+		ManagementURL: sdk.URL("{{ .ManagementURL }}"),{{ if not .FromSpec }} // TODO: Replace with actual URL{{ end }}
+		{{- end }}
 		Fields: []schema.CredentialField{
 			{
 				Name:                fieldname.{{ .FieldNameUpperCamelCase }},
@@ -374,7 +834,7 @@ func {{ .CredentialNameUpperCamelCase }}() schema.CredentialType {
 					Length: {{ .ValueComposition.Length }},
 					{{- end }}
 					{{- if .ValueComposition.Prefix }}
-					Prefix: "{{ .ValueComposition.Prefix }}", // TODO: Check if this is correct
+					Prefix: "{{ .ValueComposition.Prefix }}",{{ if not .FromSpec }} // TODO: Check if this is correct{{ end }}
 					{{- end }}
 					Charset: schema.Charset{
 						{{- if .ValueComposition.Charset.Uppercase }}
@@ -394,17 +854,126 @@ func {{ .CredentialNameUpperCamelCase }}() schema.CredentialType {
 				{{- end }}
 			},
 		},
+		{{- if .ProvisionFileName }}
+		DefaultProvisioner: provision.TempFile(
+			provision.FieldAsFile(fieldname.{{ .FieldNameUpperCamelCase }}),
+			provision.Filename("{{ .ProvisionFileName }}"),
+			{{- if .ProvisionFilePathEnvVar }}
+			provision.SetPathAsEnvVar("{{ .ProvisionFilePathEnvVar }}"),
+			{{- end }}
+		),
+		{{- else }}
 		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		{{- end }}
+		{{- if .HasImporter }}
 		Importer: importer.TryAll(
+			{{- if .CredentialEnvVarName }}
 			importer.TryEnvVarPair(defaultEnvVarMapping),
+			{{- end }}
+			{{- if or .ConfigFormat (not .FromSpec) }}
 			Try{{ .PlatformNameUpperCamelCase }}ConfigFile(),
-		)}
+			{{- end }}
+		),
+		{{- end }}
+	}
 }
+{{- if .CredentialEnvVarName }}
 
 var defaultEnvVarMapping = map[string]sdk.FieldName{
-	"{{ .CredentialEnvVarName }}": fieldname.{{ .FieldNameUpperCamelCase }}, // TODO: Check if this is correct
+	"{{ .CredentialEnvVarName }}": fieldname.{{ .FieldNameUpperCamelCase }},{{ if not .FromSpec }} // TODO: Check if this is correct{{ end }}
 }
+{{- end }}
+
+{{- if eq .ConfigFormat "ini" }}
+{{ if .FromSpec }}// Try{{ .PlatformNameUpperCamelCase }}ConfigFile tries to find the {{ .CredentialName }} in {{ .ConfigFilePath }}.{{ else }}// TODO: Check if this is where and how the platform stores the {{ .CredentialName }}.{{ end }}
+func Try{{ .PlatformNameUpperCamelCase }}ConfigFile() sdk.Importer {
+	return importer.TryFile("{{ .ConfigFilePath }}", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		credentialsFile, err := contents.ToINI()
+		if err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, section := range credentialsFile.Sections() {
+			value := section.Key("{{ .ConfigKey }}").Value()
+			if value == "" {
+				continue
+			}
 
+			out.AddCandidate(sdk.ImportCandidate{
+				NameHint: importer.SanitizeNameHint(section.Name()),
+				Fields: map[sdk.FieldName]string{
+					fieldname.{{ .FieldNameUpperCamelCase }}: value,
+				},
+			})
+		}
+	})
+}
+{{- else if or (eq .ConfigFormat "json") (eq .ConfigFormat "yaml") }}
+{{ if .FromSpec }}// Try{{ .PlatformNameUpperCamelCase }}ConfigFile tries to find the {{ .CredentialName }} in {{ .ConfigFilePath }}.{{ else }}// TODO: Check if this is where and how the platform stores the {{ .CredentialName }}.{{ end }}
+func Try{{ .PlatformNameUpperCamelCase }}ConfigFile() sdk.Importer {
+	return importer.TryFile("{{ .ConfigFilePath }}", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config Config
+		{{- if eq .ConfigFormat "json" }}
+		if err := contents.ToJSON(&config); err != nil {
+		{{- else }}
+		if err := contents.ToYAML(&config); err != nil {
+		{{- end }}
+			out.AddError(err)
+			return
+		}
+
+		if config.{{ .FieldNameUpperCamelCase }} == "" {
+			return
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.{{ .FieldNameUpperCamelCase }}: config.{{ .FieldNameUpperCamelCase }},
+			},
+		})
+	})
+}
+
+{{ if .FromSpec }}// Config is the part of the config file that contains the {{ .CredentialName }}.{{ else }}// TODO: Complete the config file schema{{ end }}
+type Config struct {
+	{{ .FieldNameUpperCamelCase }} string ` + "`{{ .ConfigFormat }}:\"{{ .ConfigKey }}\"`" + `
+}
+{{- else if eq .ConfigFormat "env" }}
+{{ if .FromSpec }}// Try{{ .PlatformNameUpperCamelCase }}ConfigFile tries to find the {{ .CredentialName }} in {{ .ConfigFilePath }}.{{ else }}// TODO: Check if this is where the platform stores the {{ .CredentialName }}.{{ end }}
+func Try{{ .PlatformNameUpperCamelCase }}ConfigFile() sdk.Importer {
+	return importer.TryFile("{{ .ConfigFilePath }}", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		value := contents.ToEnv()["{{ .ConfigKey }}"]
+		if value == "" {
+			return
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.{{ .FieldNameUpperCamelCase }}: value,
+			},
+		})
+	})
+}
+{{- else if eq .ConfigFormat "netrc" }}
+{{ if .FromSpec }}// Try{{ .PlatformNameUpperCamelCase }}ConfigFile tries to find the {{ .CredentialName }} in {{ .ConfigFilePath }}.{{ else }}// TODO: Check if this is the machine name the platform uses in the netrc file.{{ end }}
+func Try{{ .PlatformNameUpperCamelCase }}ConfigFile() sdk.Importer {
+	return importer.TryFile("{{ .ConfigFilePath }}", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		for _, entry := range contents.ToNetrc() {
+			if entry.Machine != "{{ .ConfigKey }}" || entry.Password == "" {
+				continue
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				NameHint: importer.SanitizeNameHint(entry.Login),
+				Fields: map[sdk.FieldName]string{
+					fieldname.{{ .FieldNameUpperCamelCase }}: entry.Password,
+				},
+			})
+		}
+	})
+}
+{{- else if not .FromSpec }}
 // TODO: Check if the platform stores the {{ .CredentialName }} in a local config file, and if so,
 // implement the function below to add support for importing it.
 func Try{{ .PlatformNameUpperCamelCase }}ConfigFile() sdk.Importer {
@@ -431,6 +1000,7 @@ func Try{{ .PlatformNameUpperCamelCase }}ConfigFile() sdk.Importer {
 // type Config struct {
 //	{{ .FieldNameUpperCamelCase }} string
 // }
+{{- end }}
 `,
 }
 
@@ -449,22 +1019,36 @@ import (
 func Test{{ .CredentialNameUpperCamelCase }}Provisioner(t *testing.T) {
 	plugintest.TestProvisioner(t, {{ .CredentialNameUpperCamelCase }}().DefaultProvisioner, map[string]plugintest.ProvisionCase{
 		"default": {
-			ItemFields: map[sdk.FieldName]string{ // TODO: Check if this is correct
+			ItemFields: map[sdk.FieldName]string{ {{- if not .FromSpec }} // TODO: Check if this is correct{{ end }}
 				fieldname.{{ .FieldNameUpperCamelCase }}: "{{ .TestCredentialExample }}",
 			},
 			ExpectedOutput: sdk.ProvisionOutput{
+				{{- if .ProvisionFileName }}
+				{{- if .ProvisionFilePathEnvVar }}
+				Environment: map[string]string{
+					"{{ .ProvisionFilePathEnvVar }}": "/tmp/{{ .ProvisionFileName }}",
+				},
+				{{- end }}
+				Files: map[string]sdk.OutputFile{
+					"/tmp/{{ .ProvisionFileName }}": {Contents: []byte("{{ .TestCredentialExample }}")},
+				},
+				{{- else }}
 				Environment: map[string]string{
 					"{{ .CredentialEnvVarName }}": "{{ .TestCredentialExample }}",
 				},
+				{{- end }}
 			},
 		},
 	})
 }
 
+{{- if .HasImporter }}
+
 func Test{{ .CredentialNameUpperCamelCase }}Importer(t *testing.T) {
 	plugintest.TestImporter(t, {{ .CredentialNameUpperCamelCase }}().Importer, map[string]plugintest.ImportCase{
+		{{- if .CredentialEnvVarName }}
 		"environment": {
-			Environment: map[string]string{ // TODO: Check if this is correct
+			Environment: map[string]string{ {{- if not .FromSpec }} // TODO: Check if this is correct{{ end }}
 				"{{ .CredentialEnvVarName }}": "{{ .TestCredentialExample }}",
 			},
 			ExpectedCandidates: []sdk.ImportCandidate{
@@ -475,6 +1059,24 @@ func Test{{ .CredentialNameUpperCamelCase }}Importer(t *testing.T) {
 				},
 			},
 		},
+		{{- end }}
+		{{- if .ConfigFormat }}
+		"config file": {
+			Files: map[string]string{
+				"{{ .ConfigFilePath }}": plugintest.LoadFixture(t, "{{ .ConfigFixtureName }}"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					{{- if eq .ConfigFormat "netrc" }}
+					NameHint: "wendy@example.com",
+					{{- end }}
+					Fields: map[sdk.FieldName]string{
+						fieldname.{{ .FieldNameUpperCamelCase }}: "{{ .TestCredentialExample }}",
+					},
+				},
+			},
+		},
+		{{- else if not .FromSpec }}
 		// TODO: If you implemented a config file importer, add a test file example in {{ .Name }}/test-fixtures
 		// and fill the necessary details in the test template below.
 		"config file": {
@@ -489,17 +1091,55 @@ func Test{{ .CredentialNameUpperCamelCase }}Importer(t *testing.T) {
 			// 	},
 			},
 		},
+		{{- end }}
 	})
 }
+{{- end }}
 `,
 }
 
+// configFixtureTemplates contains the test fixture to generate for each of the configFileFormats.
+var configFixtureTemplates = map[string]Template{
+	"ini": {
+		Filename: "test-fixtures/{{ .ConfigFixtureName }}",
+		Contents: `[default]
+{{ .ConfigKey }} = {{ .TestCredentialExample }}
+`,
+	},
+	"json": {
+		Filename: "test-fixtures/{{ .ConfigFixtureName }}",
+		Contents: `{
+  "{{ .ConfigKey }}": "{{ .TestCredentialExample }}"
+}
+`,
+	},
+	"yaml": {
+		Filename: "test-fixtures/{{ .ConfigFixtureName }}",
+		Contents: `{{ .ConfigKey }}: {{ .TestCredentialExample }}
+`,
+	},
+	"env": {
+		Filename: "test-fixtures/{{ .ConfigFixtureName }}",
+		Contents: `{{ .ConfigKey }}={{ .TestCredentialExample }}
+`,
+	},
+	"netrc": {
+		Filename: "test-fixtures/{{ .ConfigFixtureName }}",
+		Contents: `machine {{ .ConfigKey }}
+  login wendy@example.com
+  password {{ .TestCredentialExample }}
+`,
+	},
+}
+
 var executableTemplate = Template{
 	Filename: "{{ .ExecutableSnakeCase }}.go",
 	Contents: `package {{ .Name }}
 
 import (
+	{{- if .ExecutableDocsURL }}
 	"github.com/1Password/shell-plugins/sdk"
+	{{- end }}
 	"github.com/1Password/shell-plugins/sdk/needsauth"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
@@ -507,9 +1147,11 @@ import (
 
 func {{ .PlatformNameUpperCamelCase }}CLI() schema.Executable {
 	return schema.Executable{
-		Name:      "{{ .PlatformName }} CLI", // TODO: Check if this is correct
+		Name:      "{{ .ExecutableName }}",{{ if not .FromSpec }} // TODO: Check if this is correct{{ end }}
 		Runs:      []string{"{{ .Executable }}"},
-		DocsURL:   sdk.URL("https://{{ .Name }}.com/docs/cli"), // TODO: Replace with actual URL
+		{{- if .ExecutableDocsURL }}
+		DocsURL:   sdk.URL("{{ .ExecutableDocsURL }}"),{{ if not .FromSpec }} // TODO: Replace with actual URL{{ end }}
+		{{- end }}
 		NeedsAuth: needsauth.IfAll(
 			needsauth.NotForHelpOrVersion(),
 			needsauth.NotWithoutArgs(),
@@ -542,6 +1184,64 @@ func generateSecretsExample(plugin schema.Plugin) string {
 	return example
 }
 
+// generateFakeCredentials generates fake credentials for the plugin and writes them to env files and
+// provisioned files, so they can be used for manual testing.
+func generateFakeCredentials(plugin schema.Plugin, args []string) error {
+	flags := flag.NewFlagSet(fakeCredentialsCommandSuffix, flag.ContinueOnError)
+	outDir := flags.String("out", "", "directory to write the fake credentials to, defaults to a new temporary directory")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	dir := *outDir
+	if dir == "" {
+		dir, err = os.MkdirTemp("", plugin.Name+"-fake-credentials-")
+		if err != nil {
+			return err
+		}
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := fixtures.Generate(context.Background(), plugin, dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated fake credentials in %s\n", dir)
+	for _, credential := range credentials {
+		fmt.Printf("\n%s:\n", credential.Name)
+		for _, field := range sortedFieldNames(credential.Fields) {
+			fmt.Printf("  %s: %s\n", field, credential.Fields[field])
+		}
+		if credential.EnvFile != "" {
+			fmt.Printf("  Environment: source %s\n", credential.EnvFile)
+		}
+		for _, path := range credential.Files {
+			fmt.Printf("  File: %s\n", path)
+		}
+		for _, e := range credential.Errors {
+			fmt.Printf("  Error: %s\n", e)
+		}
+	}
+
+	return nil
+}
+
+func sortedFieldNames(fields map[sdk.FieldName]string) []sdk.FieldName {
+	names := make([]sdk.FieldName, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
 func generatePluginRegistry() error {
 	var plugins []string
 
@@ -634,6 +1334,33 @@ func generateRegistryJSON() error {
 	return os.WriteFile(filepath.Join("plugins", "registry.json"), b, 0600)
 }
 
+func generateManifest(args []string) error {
+	flags := flag.NewFlagSet("manifest.json", flag.ContinueOnError)
+	out := flags.String("out", filepath.Join("plugins", "manifest.json"), `path to write the manifest to, or "-" to print it`)
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	workingDir, err := os.MkdirTemp("", "shell-plugins-manifest-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workingDir)
+
+	b, err := json.MarshalIndent(manifest.Generate(plugins.List(), workingDir), "", "\t")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if *out == "-" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(*out, b, 0600)
+}
+
 // fieldNameSplitFromCredNameSplit takes in a credential name split array and returns a field name split array.
 //
 // As a placeholder, assume the field name is the short version (max 7 chars, including space between words) of the credential name, starting from the last word.
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -66,3 +70,115 @@ func TestValidateFieldNameSplitFromCredNameSplit(t *testing.T) {
 		})
 	}
 }
+
+func TestScaffoldConfigFormats(t *testing.T) {
+	for format, expected := range configFileFormats {
+		t.Run(format, func(t *testing.T) {
+			scaffold := pluginScaffold{
+				Name:           "acme",
+				PlatformName:   "Acme",
+				Executable:     "acme",
+				CredentialName: "API Key",
+				ConfigFormat:   format,
+			}
+			scaffold.derive()
+
+			dir := t.TempDir()
+			err := renderTemplates(dir, scaffold.templates(), scaffold)
+			assert.NoError(t, err)
+
+			fixture, err := os.ReadFile(filepath.Join(dir, "test-fixtures", expected.FixtureName))
+			assert.NoError(t, err)
+			assert.Contains(t, string(fixture), scaffold.TestCredentialExample)
+
+			for _, filename := range []string{"plugin.go", "api_key.go", "api_key_test.go", "acme.go"} {
+				_, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, filename), nil, parser.AllErrors)
+				assert.NoError(t, err, filename)
+			}
+
+			credential, err := os.ReadFile(filepath.Join(dir, "api_key.go"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(credential), `importer.TryFile("`+scaffold.ConfigFilePath+`"`)
+		})
+	}
+}
+
+func TestScaffoldFromSpec(t *testing.T) {
+	cases := map[string]string{
+		"env var and config file": `
+name: acme
+platform:
+  name: Acme
+  homepage: https://acme.com
+credential:
+  name: api token
+  docs_url: https://acme.com/docs/tokens
+  env_var: ACME_TOKEN
+  composition:
+    length: 40
+    prefix: acme_
+    charset: [lowercase, digits]
+  config_file:
+    format: yaml
+    path: ~/.config/acme/config.yml
+    key: auth_token
+executable:
+  name: Acme CLI
+  command: acme
+  docs_url: https://acme.com/docs/cli
+`,
+		"file provisioner in JSON": `{
+  "name": "acme",
+  "platform": {"name": "Acme", "homepage": "https://acme.com"},
+  "credential": {
+    "name": "API Token",
+    "file": {"name": "token", "path_env_var": "ACME_TOKEN_FILE"}
+  },
+  "executable": {"command": "acme"}
+}`,
+	}
+
+	for description, spec := range cases {
+		t.Run(description, func(t *testing.T) {
+			scaffold, err := scaffoldFromSpec([]byte(spec))
+			assert.NoError(t, err)
+			assert.Equal(t, "API Token", scaffold.CredentialName)
+
+			dir := t.TempDir()
+			err = renderTemplates(dir, scaffold.templates(), scaffold)
+			assert.NoError(t, err)
+
+			for _, filename := range []string{"plugin.go", "api_token.go", "api_token_test.go", "acme.go"} {
+				contents, err := os.ReadFile(filepath.Join(dir, filename))
+				assert.NoError(t, err)
+				assert.NotContains(t, string(contents), "TODO", filename)
+			}
+		})
+	}
+}
+
+func TestScaffoldFromSpecReturnsError(t *testing.T) {
+	cases := map[string]string{
+		"when name is missing":              `platform: {name: Acme, homepage: https://acme.com}`,
+		"when platform homepage is missing": `{name: acme, platform: {name: Acme}}`,
+		"when credential has no env var or file": `
+name: acme
+platform: {name: Acme, homepage: https://acme.com}
+credential: {name: API Token}`,
+		"when config format is unsupported": `
+name: acme
+platform: {name: Acme, homepage: https://acme.com}
+credential: {name: API Token, env_var: ACME_TOKEN, config_file: {format: xml}}`,
+		"when spec contains unknown keys": `
+name: acme
+platform: {name: Acme, homepage: https://acme.com}
+homepage: https://acme.com`,
+	}
+
+	for description, spec := range cases {
+		t.Run(description, func(t *testing.T) {
+			_, err := scaffoldFromSpec([]byte(spec))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Package manifest generates a machine-readable manifest of all plugins, for consumption by package managers,
+// docs sites, and shell completion tooling.
+package manifest
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+// Version is the version of the manifest format. It gets bumped on changes that aren't backwards compatible.
+const Version = 1
+
+// Manifest describes all plugins of the repository.
+type Manifest struct {
+	Version int      `json:"version"`
+	Plugins []Plugin `json:"plugins"`
+}
+
+type Plugin struct {
+	Name                 string       `json:"name"`
+	Platform             Platform     `json:"platform"`
+	Credentials          []Credential `json:"credentials"`
+	Executables          []Executable `json:"executables"`
+	Settings             []Setting    `json:"settings,omitempty"`
+	RequiredCapabilities []string     `json:"required_capabilities,omitempty"`
+}
+
+type Platform struct {
+	Name     string `json:"name"`
+	Homepage string `json:"homepage,omitempty"`
+}
+
+type Credential struct {
+	Name          string  `json:"name"`
+	ID            string  `json:"id"`
+	DocsURL       string  `json:"docs_url,omitempty"`
+	ManagementURL string  `json:"management_url,omitempty"`
+	Fields        []Field `json:"fields"`
+	HasImporter   bool    `json:"has_importer"`
+}
+
+type Field struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Secret      bool   `json:"secret"`
+	Optional    bool   `json:"optional"`
+}
+
+type Executable struct {
+	Name string `json:"name"`
+
+	// Commands contains the command of the executable followed by the commands of its aliases, e.g. "aws".
+	Commands []string `json:"commands"`
+
+	DocsURL   string            `json:"docs_url,omitempty"`
+	Uses      []CredentialUsage `json:"uses"`
+	NeedsAuth NeedsAuthCoverage `json:"needs_auth"`
+}
+
+type CredentialUsage struct {
+	Name string `json:"name"`
+
+	// Plugin is the name of the plugin that defines the credential, if it's defined by another plugin.
+	Plugin string `json:"plugin,omitempty"`
+
+	Description  string `json:"description,omitempty"`
+	Optional     bool   `json:"optional,omitempty"`
+	HasNeedsAuth bool   `json:"has_needs_auth,omitempty"`
+}
+
+// NeedsAuthCoverage describes for which invocations of an executable authentication gets skipped.
+type NeedsAuthCoverage struct {
+	// Defined is set if the executable has NeedsAuth rules. Without rules, every invocation gets authenticated.
+	Defined bool `json:"defined"`
+
+	// SkippedWithoutArgs is set if authentication gets skipped when running the executable without arguments.
+	SkippedWithoutArgs bool `json:"skipped_without_args"`
+
+	// SkippedFor contains the common invocations, e.g. "--help", for which authentication gets skipped.
+	SkippedFor []string `json:"skipped_for"`
+}
+
+type Setting struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// commonInvocations are the arguments that executables get probed with to determine the NeedsAuth coverage.
+var commonInvocations = [][]string{
+	{"--help"},
+	{"-h"},
+	{"help"},
+	{"--version"},
+	{"-v"},
+	{"version"},
+}
+
+// Generate returns the manifest of the plugins. The NeedsAuth rules of the executables get evaluated from the
+// working directory, which should be an empty directory so that rules that look at local files are deterministic.
+func Generate(plugins []schema.Plugin, workingDir string) Manifest {
+	manifest := Manifest{Version: Version, Plugins: []Plugin{}}
+	for _, p := range plugins {
+		manifest.Plugins = append(manifest.Plugins, plugin(p, workingDir))
+	}
+	return manifest
+}
+
+func plugin(p schema.Plugin, workingDir string) Plugin {
+	result := Plugin{
+		Name: p.Name,
+		Platform: Platform{
+			Name:     p.Platform.Name,
+			Homepage: urlString(p.Platform.Homepage),
+		},
+		Credentials: []Credential{},
+		Executables: []Executable{},
+	}
+
+	for _, c := range p.Credentials {
+		credential := Credential{
+			Name:          c.Name.String(),
+			ID:            c.Name.ID().String(),
+			DocsURL:       urlString(c.DocsURL),
+			ManagementURL: urlString(c.ManagementURL),
+			Fields:        []Field{},
+			HasImporter:   c.Importer != nil,
+		}
+		for _, f := range c.Fields {
+			credential.Fields = append(credential.Fields, Field{
+				Name:        f.Name.String(),
+				Description: f.MarkdownDescription,
+				Secret:      f.Secret,
+				Optional:    f.Optional,
+			})
+		}
+		result.Credentials = append(result.Credentials, credential)
+	}
+
+	for _, e := range p.Executables {
+		executable := Executable{
+			Name:      e.Name,
+			Commands:  e.Commands(),
+			DocsURL:   urlString(e.DocsURL),
+			Uses:      []CredentialUsage{},
+			NeedsAuth: needsAuthCoverage(e.NeedsAuth, workingDir),
+		}
+		for _, usage := range e.Uses {
+			executable.Uses = append(executable.Uses, CredentialUsage{
+				Name:         usage.Name.String(),
+				Plugin:       usage.Plugin,
+				Description:  usage.Description,
+				Optional:     usage.Optional,
+				HasNeedsAuth: usage.NeedsAuth != nil,
+			})
+		}
+		result.Executables = append(result.Executables, executable)
+	}
+
+	for _, s := range p.Settings {
+		result.Settings = append(result.Settings, Setting{
+			Name:        s.Name,
+			Description: s.Description,
+			Default:     s.Default,
+			Options:     s.Options,
+		})
+	}
+
+	for _, capability := range p.RequiredCapabilities {
+		result.RequiredCapabilities = append(result.RequiredCapabilities, string(capability))
+	}
+
+	return result
+}
+
+func needsAuthCoverage(needsAuth sdk.NeedsAuthentication, workingDir string) NeedsAuthCoverage {
+	coverage := NeedsAuthCoverage{SkippedFor: []string{}}
+	if needsAuth == nil {
+		return coverage
+	}
+
+	skips := func(args []string) bool {
+		return !needsAuth(sdk.NeedsAuthenticationInput{CommandArgs: args, WorkingDir: workingDir})
+	}
+
+	coverage.Defined = true
+	coverage.SkippedWithoutArgs = skips([]string{})
+	for _, args := range commonInvocations {
+		if skips(args) {
+			coverage.SkippedFor = append(coverage.SkippedFor, strings.Join(args, " "))
+		}
+	}
+	return coverage
+}
+
+func urlString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
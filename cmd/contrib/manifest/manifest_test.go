@@ -0,0 +1,97 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	plugin := schema.Plugin{
+		Name: "example",
+		Platform: schema.PlatformInfo{
+			Name:     "Example",
+			Homepage: sdk.URL("https://example.com"),
+		},
+		Credentials: []schema.CredentialType{
+			{
+				Name:          credname.APIKey,
+				ManagementURL: sdk.URL("https://example.com/keys"),
+				Fields: []schema.CredentialField{
+					{Name: fieldname.APIKey, MarkdownDescription: "API Key used to authenticate.", Secret: true},
+					{Name: fieldname.Region, Optional: true},
+				},
+			},
+		},
+		Executables: []schema.Executable{
+			{
+				Name:    "Example CLI",
+				Runs:    []string{"example"},
+				Aliases: []string{"ex"},
+				NeedsAuth: needsauth.IfAll(
+					needsauth.NotForHelpOrVersion(),
+					needsauth.NotWithoutArgs(),
+				),
+				Uses: []schema.CredentialUsage{{Name: credname.APIKey}},
+			},
+			{
+				Name: "Example Admin CLI",
+				Runs: []string{"example-admin"},
+				Uses: []schema.CredentialUsage{{Name: credname.APIKey, Optional: true}},
+			},
+		},
+		Settings: []schema.Setting{
+			{Name: "default-region", Description: "The region to use.", Default: "eu"},
+		},
+	}
+
+	manifest := Generate([]schema.Plugin{plugin}, t.TempDir())
+
+	expected := `{
+		"version": 1,
+		"plugins": [{
+			"name": "example",
+			"platform": {"name": "Example", "homepage": "https://example.com"},
+			"credentials": [{
+				"name": "API Key",
+				"id": "api_key",
+				"management_url": "https://example.com/keys",
+				"fields": [
+					{"name": "API Key", "description": "API Key used to authenticate.", "secret": true, "optional": false},
+					{"name": "Region", "secret": false, "optional": true}
+				],
+				"has_importer": false
+			}],
+			"executables": [
+				{
+					"name": "Example CLI",
+					"commands": ["example", "ex"],
+					"uses": [{"name": "API Key"}],
+					"needs_auth": {
+						"defined": true,
+						"skipped_without_args": true,
+						"skipped_for": ["--help", "-h", "help", "--version", "-v", "version"]
+					}
+				},
+				{
+					"name": "Example Admin CLI",
+					"commands": ["example-admin"],
+					"uses": [{"name": "API Key", "optional": true}],
+					"needs_auth": {"defined": false, "skipped_without_args": false, "skipped_for": []}
+				}
+			],
+			"settings": [{"name": "default-region", "description": "The region to use.", "default": "eu"}]
+		}]
+	}`
+
+	actual, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+}
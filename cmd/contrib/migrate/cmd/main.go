@@ -0,0 +1,59 @@
+// Command migrate rewrites the plugins when SDK interfaces change. It doesn't depend on the plugin registry, so
+// that it also works when the plugins don't compile against the SDK anymore.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/1Password/shell-plugins/cmd/contrib/migrate"
+)
+
+func main() {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only print the files that would be rewritten")
+	only := flags.String("only", "", "ID of the single migration to apply, instead of all of them")
+	list := flags.Bool("list", false, "list the available migrations")
+	_ = flags.Parse(os.Args[1:])
+
+	if *list {
+		for _, migration := range migrate.Migrations {
+			fmt.Printf("%s: %s\n", migration.ID, migration.Description)
+		}
+		return
+	}
+
+	migrations := migrate.Migrations
+	if *only != "" {
+		migration, ok := migrate.Find(*only)
+		if !ok {
+			log.Fatalf("unknown migration %q, run with --list to see the available migrations", *only)
+		}
+		migrations = []migrate.Migration{migration}
+	}
+
+	dirs := flags.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"plugins"}
+	}
+
+	var count int
+	for _, dir := range dirs {
+		changes, err := migrate.Run(dir, migrations, *dryRun)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, change := range changes {
+			fmt.Printf("%s: %s\n", change.Migration, change.Path)
+		}
+		count += len(changes)
+	}
+
+	if count == 0 {
+		fmt.Println("No files need to be migrated.")
+	} else if *dryRun {
+		fmt.Printf("%d changes would be made.\n", count)
+	}
+}
@@ -0,0 +1,329 @@
+// Package migrate rewrites plugin source code when SDK interfaces change, such as renamed options or new
+// required methods, so that breaking SDK improvements don't require hand-editing every plugin package.
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is a rewrite that brings existing plugins in line with a change in the SDK.
+type Migration struct {
+	// ID identifies the migration, e.g. "keyed-sdk-error-literals".
+	ID string
+
+	// Description describes the SDK change that the migration handles.
+	Description string
+
+	// Rewrite rewrites the package in place and returns the files it changed.
+	Rewrite func(pkg *Package) []*File
+}
+
+// Package is a parsed Go package that migrations can rewrite.
+type Package struct {
+	Dir   string
+	Fset  *token.FileSet
+	Files []*File
+}
+
+// File is a parsed Go file of a package.
+type File struct {
+	Path string
+	AST  *ast.File
+
+	// appended contains declarations to add to the end of the file, as source code.
+	appended []string
+}
+
+// Change describes a file that got rewritten by a migration.
+type Change struct {
+	Migration string
+	Path      string
+}
+
+// Run applies the migrations to all packages in the directory and its subdirectories, and returns the changes.
+// The migrated files are written back to disk, unless dryRun is set.
+func Run(dir string, migrations []Migration, dryRun bool) ([]Change, error) {
+	var changes []Change
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if name := d.Name(); path != dir && (name == "testdata" || name == "test-fixtures" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+
+		pkgChanges, err := migratePackage(path, migrations, dryRun)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, pkgChanges...)
+		return nil
+	})
+	return changes, err
+}
+
+func migratePackage(dir string, migrations []Migration, dryRun bool) ([]Change, error) {
+	pkg, err := ParseDir(dir)
+	if err != nil || len(pkg.Files) == 0 {
+		return nil, err
+	}
+
+	var changes []Change
+	changed := make(map[*File]bool)
+	for _, migration := range migrations {
+		for _, file := range migration.Rewrite(pkg) {
+			changes = append(changes, Change{Migration: migration.ID, Path: file.Path})
+			changed[file] = true
+		}
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	for _, file := range pkg.Files {
+		if !changed[file] {
+			continue
+		}
+		src, err := file.Source(pkg.Fset)
+		if err != nil {
+			return nil, err
+		}
+		err = os.WriteFile(file.Path, src, 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// ParseDir parses the Go files in the directory, not including subdirectories.
+func ParseDir(dir string) (*Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &Package{Dir: dir, Fset: token.NewFileSet()}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		f, err := parser.ParseFile(pkg.Fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Files = append(pkg.Files, &File{Path: path, AST: f})
+	}
+	return pkg, nil
+}
+
+// Source returns the formatted source code of the file, including the declarations that got appended.
+func (f *File) Source(fset *token.FileSet) ([]byte, error) {
+	var buf bytes.Buffer
+	err := format.Node(&buf, fset, f.AST)
+	if err != nil {
+		return nil, err
+	}
+	for _, decl := range f.appended {
+		buf.WriteString("\n" + decl + "\n")
+	}
+	return format.Source(buf.Bytes())
+}
+
+// importName returns the name under which the file imports the package with the specified path, or an empty
+// string if the file doesn't import it.
+func importName(f *ast.File, pkgPath string) string {
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != pkgPath {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return filepath.Base(path)
+	}
+	return ""
+}
+
+// isQualified returns whether the expression refers to the exported name of the package imported as pkgName.
+func isQualified(expr ast.Expr, pkgName string, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name == pkgName && sel.Sel.Name == name
+}
+
+// RenameIdent returns a rewrite that renames references to an exported identifier of a package, e.g. an option
+// function that got renamed from provision.Filename to provision.WithFilename.
+func RenameIdent(pkgPath string, oldName string, newName string) func(pkg *Package) []*File {
+	return func(pkg *Package) []*File {
+		var changed []*File
+		for _, file := range pkg.Files {
+			pkgName := importName(file.AST, pkgPath)
+			if pkgName == "" {
+				continue
+			}
+
+			var fileChanged bool
+			ast.Inspect(file.AST, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok && isQualified(sel, pkgName, oldName) {
+					sel.Sel.Name = newName
+					fileChanged = true
+				}
+				return true
+			})
+			if fileChanged {
+				changed = append(changed, file)
+			}
+		}
+		return changed
+	}
+}
+
+// KeyStructLiterals returns a rewrite that adds the field names to unkeyed composite literals of a struct type
+// of a package, so that they keep compiling when fields get added to the struct. The fields have to be specified
+// in the order in which they are declared.
+func KeyStructLiterals(pkgPath string, typeName string, fields ...string) func(pkg *Package) []*File {
+	return func(pkg *Package) []*File {
+		var changed []*File
+		for _, file := range pkg.Files {
+			pkgName := importName(file.AST, pkgPath)
+			if pkgName == "" {
+				continue
+			}
+
+			var fileChanged bool
+			ast.Inspect(file.AST, func(n ast.Node) bool {
+				lit, ok := n.(*ast.CompositeLit)
+				if !ok || !isQualified(lit.Type, pkgName, typeName) || len(lit.Elts) == 0 || len(lit.Elts) > len(fields) {
+					return true
+				}
+				if _, keyed := lit.Elts[0].(*ast.KeyValueExpr); keyed {
+					return true
+				}
+
+				for i, elt := range lit.Elts {
+					lit.Elts[i] = &ast.KeyValueExpr{
+						Key:   &ast.Ident{Name: fields[i], NamePos: elt.Pos()},
+						Value: elt,
+					}
+				}
+				fileChanged = true
+				return true
+			})
+			if fileChanged {
+				changed = append(changed, file)
+			}
+		}
+		return changed
+	}
+}
+
+// AddMissingMethod returns a rewrite that adds a method to every type of the package that has all of the
+// specified methods but not the new one, e.g. to add a new method to all provisioners when it gets added to
+// the sdk.Provisioner interface. The stub is the source code of the method without a receiver, e.g.
+// `Description() string { return "" }`. The receiver matches the existing methods of the type.
+func AddMissingMethod(requiredMethods []string, stub string) func(pkg *Package) []*File {
+	return func(pkg *Package) []*File {
+		name := stub
+		if i := strings.IndexAny(stub, "[("); i >= 0 {
+			name = strings.TrimSpace(stub[:i])
+		}
+
+		methods := make(map[string]map[string]*ast.FieldList)
+		declaredIn := make(map[string]*File)
+		for _, file := range pkg.Files {
+			for _, decl := range file.AST.Decls {
+				switch decl := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+							declaredIn[typeSpec.Name.Name] = file
+						}
+					}
+				case *ast.FuncDecl:
+					if decl.Recv == nil || len(decl.Recv.List) == 0 {
+						continue
+					}
+					typeName := receiverTypeName(decl.Recv.List[0].Type)
+					if methods[typeName] == nil {
+						methods[typeName] = make(map[string]*ast.FieldList)
+					}
+					methods[typeName][decl.Name.Name] = decl.Recv
+				}
+			}
+		}
+
+		var typeNames []string
+		for typeName := range methods {
+			typeNames = append(typeNames, typeName)
+		}
+		sort.Strings(typeNames)
+
+		var changed []*File
+		for _, typeName := range typeNames {
+			file, ok := declaredIn[typeName]
+			if !ok || methods[typeName][name] != nil || !hasAll(methods[typeName], requiredMethods) {
+				continue
+			}
+
+			recv := methods[typeName][requiredMethods[0]]
+			file.appended = append(file.appended, fmt.Sprintf("func (%s) %s", receiverSource(pkg.Fset, recv), stub))
+			changed = append(changed, file)
+		}
+		return changed
+	}
+}
+
+func hasAll(methods map[string]*ast.FieldList, names []string) bool {
+	if len(names) == 0 {
+		return false
+	}
+	for _, name := range names {
+		if methods[name] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func receiverTypeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	case *ast.IndexExpr:
+		return receiverTypeName(expr.X)
+	}
+	return ""
+}
+
+func receiverSource(fset *token.FileSet, recv *ast.FieldList) string {
+	field := recv.List[0]
+	var buf bytes.Buffer
+	_ = format.Node(&buf, fset, field.Type)
+	if len(field.Names) == 0 {
+		return buf.String()
+	}
+	return field.Names[0].Name + " " + buf.String()
+}
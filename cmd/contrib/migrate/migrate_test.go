@@ -0,0 +1,146 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePackage(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, contents := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+	}
+	return dir
+}
+
+func readFile(t *testing.T, path string) string {
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(contents)
+}
+
+func TestRenameIdent(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"plugin.go": `package plugin
+
+import (
+	prov "github.com/1Password/shell-plugins/sdk/provision"
+)
+
+var Filename = "config"
+
+var provisioner = prov.TempFile(nil, prov.Filename(Filename))
+`,
+	})
+
+	changes, err := Run(dir, []Migration{{
+		ID:      "rename",
+		Rewrite: RenameIdent("github.com/1Password/shell-plugins/sdk/provision", "Filename", "WithFilename"),
+	}}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Change{{Migration: "rename", Path: filepath.Join(dir, "plugin.go")}}, changes)
+	assert.Equal(t, `package plugin
+
+import (
+	prov "github.com/1Password/shell-plugins/sdk/provision"
+)
+
+var Filename = "config"
+
+var provisioner = prov.TempFile(nil, prov.WithFilename(Filename))
+`, readFile(t, filepath.Join(dir, "plugin.go")))
+}
+
+func TestKeyStructLiterals(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"importer.go": `package plugin
+
+import "github.com/1Password/shell-plugins/sdk"
+
+var errs = []sdk.Error{
+	{Message: "already keyed"},
+	sdk.Error{"not keyed"},
+}
+
+var err = sdk.Error{"not keyed"}
+`,
+	})
+
+	changes, err := Run(dir, Migrations, false)
+	require.NoError(t, err)
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, `package plugin
+
+import "github.com/1Password/shell-plugins/sdk"
+
+var errs = []sdk.Error{
+	{Message: "already keyed"},
+	sdk.Error{Message: "not keyed"},
+}
+
+var err = sdk.Error{Message: "not keyed"}
+`, readFile(t, filepath.Join(dir, "importer.go")))
+}
+
+func TestAddMissingMethod(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"provisioner.go": `package plugin
+
+type provisioner struct{}
+
+type otherType struct{}
+`,
+		"methods.go": `package plugin
+
+func (p *provisioner) Provision() {}
+
+func (p *provisioner) Deprovision() {}
+
+func (o otherType) Provision() {}
+`,
+	})
+
+	migration := Migration{
+		ID:      "add-description",
+		Rewrite: AddMissingMethod([]string{"Provision", "Deprovision"}, `Description() string { return "" }`),
+	}
+	changes, err := Run(dir, []Migration{migration}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Change{{Migration: "add-description", Path: filepath.Join(dir, "provisioner.go")}}, changes)
+	assert.Equal(t, `package plugin
+
+type provisioner struct{}
+
+type otherType struct{}
+
+func (p *provisioner) Description() string { return "" }
+`, readFile(t, filepath.Join(dir, "provisioner.go")))
+
+	// Running the migration again should be a no-op.
+	changes, err = Run(dir, []Migration{migration}, false)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestRunDryRun(t *testing.T) {
+	source := `package plugin
+
+import "github.com/1Password/shell-plugins/sdk"
+
+var err = sdk.Error{"not keyed"}
+`
+	dir := writePackage(t, map[string]string{"importer.go": source})
+
+	changes, err := Run(dir, Migrations, true)
+	require.NoError(t, err)
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, source, readFile(t, filepath.Join(dir, "importer.go")))
+}
@@ -0,0 +1,23 @@
+package migrate
+
+const sdkPkgPath = "github.com/1Password/shell-plugins/sdk"
+
+// Migrations contains all migrations, in the order in which they should be applied. When making a breaking
+// change to the SDK, add a migration to the end of the list, using the rewrites of this package where possible.
+var Migrations = []Migration{
+	{
+		ID:          "keyed-sdk-error-literals",
+		Description: "Adds field names to unkeyed sdk.Error literals, since sdk.Error got a Code field.",
+		Rewrite:     KeyStructLiterals(sdkPkgPath, "Error", "Message", "Code"),
+	},
+}
+
+// Find returns the migration with the specified ID.
+func Find(id string) (Migration, bool) {
+	for _, migration := range Migrations {
+		if migration.ID == id {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
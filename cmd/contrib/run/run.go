@@ -0,0 +1,383 @@
+// Package run exercises a plugin locally the way the 1Password CLI would: it checks the needs-auth rules,
+// provisions the credentials, runs the executable and deprovisions again, all without requiring a full
+// 1Password CLI setup while iterating on a plugin.
+package run
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+// Options configure a local run.
+type Options struct {
+	// Fields overrides the example values that get provisioned, using the format: field name -> value.
+	// Fields that are not set default to example values generated from the value composition of the field.
+	Fields map[sdk.FieldName]string
+
+	// Settings contains the plugin settings to run with, on top of the defaults.
+	Settings map[string]string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+
+	// Stderr receives the output of the executable and the progress of the run.
+	Stderr io.Writer
+}
+
+// provisioned is a credential usage that got provisioned, so it has to be deprovisioned after the run.
+type provisioned struct {
+	credential  schema.CredentialType
+	provisioner sdk.Provisioner
+	itemFields  map[sdk.FieldName]string
+	environment sdk.Environment
+}
+
+// Run runs the command line with the credentials of the plugin provisioned and returns the exit code of the
+// executable. Provisioned files are written to a temporary directory or to their fixed path, but existing files
+// are never overwritten. All provisioned files are deleted after the run.
+func Run(ctx context.Context, plugin schema.Plugin, commandLine []string, opts Options) (int, error) {
+	if opts.Stdin == nil {
+		opts.Stdin = os.Stdin
+	}
+	if opts.Stdout == nil {
+		opts.Stdout = os.Stdout
+	}
+	if opts.Stderr == nil {
+		opts.Stderr = os.Stderr
+	}
+	logf := func(format string, a ...any) {
+		fmt.Fprintf(opts.Stderr, "[contrib run] "+format+"\n", a...)
+	}
+
+	executable, entrypoint := findExecutable(plugin, commandLine)
+	if executable == nil {
+		return 0, fmt.Errorf("plugin %s has no executable for %q", plugin.Name, strings.Join(commandLine, " "))
+	}
+	args := commandLine[len(entrypoint):]
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return 0, err
+	}
+	tempDir, err := os.MkdirTemp("", "contrib-run-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	workingDir, _ := os.Getwd()
+	env := environ()
+	needsAuthIn := sdk.NeedsAuthenticationInput{
+		CommandArgs: args,
+		WorkingDir:  workingDir,
+		StdinIsTTY:  isTerminal(os.Stdin),
+		StdoutIsTTY: isTerminal(os.Stdout),
+		Environment: env,
+	}
+
+	out := sdk.ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]sdk.OutputFile),
+		CommandLine: append([]string{}, commandLine...),
+	}
+
+	var profile string
+	if executable.ProfileHint != nil {
+		profile = executable.ProfileHint.Select(args, env)
+	}
+
+	needsAuth := executable.NeedsAuth == nil || executable.NeedsAuth(needsAuthIn)
+
+	// Executables with AuthOnFailure first run without credentials, and only get credentials provisioned if that
+	// run fails with an authentication error. The output of the first run is passed on as is, and so is stdin,
+	// which means that input piped to the first run isn't available to the retry anymore.
+	if needsAuth && executable.AuthOnFailure != nil {
+		logf("running %s without credentials first", executable.Name)
+		var stderr bytes.Buffer
+		exitCode, err := runCommand(ctx, commandLine, nil, opts.Stdin, opts.Stdout, io.MultiWriter(opts.Stderr, &stderr))
+		if err != nil {
+			return 0, err
+		}
+		if ctx.Err() != nil {
+			return exitCode, ctx.Err()
+		}
+		if !executable.AuthOnFailure.ShouldRetry(exitCode, stderr.Bytes()) {
+			return exitCode, nil
+		}
+		logf("%s failed with an authentication error, retrying with credentials provisioned", executable.Name)
+	}
+
+	var usages []provisioned
+	if !needsAuth {
+		logf("%s does not need authentication for these args, skipping provisioning", executable.Name)
+	} else {
+		for _, usage := range executable.Uses {
+			credential, provisioner := plugin.CredentialForUsage(usage)
+			if provisioner == nil {
+				logf("skipping credential usage %q, which is not defined in this plugin", usageName(usage))
+				continue
+			}
+
+			needsAuthIn.CredentialType = credential.Name.String()
+			if usage.NeedsAuth != nil && !usage.NeedsAuth(needsAuthIn) {
+				logf("%s is not needed for these args, skipping it", credential.Name)
+				continue
+			}
+
+			itemFields := plugintest.ExampleItemFields(*credential)
+			for _, field := range credential.Fields {
+				if value, ok := opts.Fields[field.Name]; ok {
+					itemFields[field.Name] = value
+				}
+			}
+
+			in := sdk.ProvisionInput{
+				HomeDir:    homeDir,
+				TempDir:    tempDir,
+				ItemFields: itemFields,
+				Settings:   schema.ResolveSettings(plugin.Settings, opts.Settings),
+				Profile:    profile,
+				// Prompts need a round trip through the 1Password CLI, so local runs fall back to the prompt defaults.
+				Interactive:      false,
+				HostCapabilities: sdk.SupportedCapabilities,
+			}
+			if credential.Environments != nil {
+				selected, err := credential.Environments.Select(args, env)
+				if err != nil {
+					out.AddError(err)
+					break
+				}
+				in.Environment = selected
+			}
+
+			out.AddRedaction(credential.SecretValues(itemFields)...)
+			logf("provisioning %s using: %s", credential.Name, provisioner.Description())
+			usages = append(usages, provisioned{credential: *credential, provisioner: provisioner, itemFields: itemFields, environment: in.Environment})
+			provisioner.Provision(ctx, in, &out)
+			if len(out.Diagnostics.Errors) > 0 || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+
+	reason := sdk.DeprovisionReasonExited
+	defer func() {
+		deprovision(homeDir, tempDir, profile, reason, usages, logf)
+	}()
+
+	if ctx.Err() != nil {
+		reason = sdk.DeprovisionReasonInterrupted
+		return 0, ctx.Err()
+	}
+	if len(out.Diagnostics.Errors) > 0 {
+		reason = sdk.DeprovisionReasonProvisionFailed
+		for _, e := range out.Diagnostics.Errors {
+			logf("provisioning error: %s", e.Message)
+		}
+		return 0, errors.New("provisioning failed")
+	}
+	written, err := writeFiles(out.Files, tempDir)
+	defer func() {
+		for _, path := range written {
+			os.Remove(path)
+		}
+	}()
+	if err != nil {
+		reason = sdk.DeprovisionReasonProvisionFailed
+		return 0, err
+	}
+
+	for _, name := range sortedKeys(out.Environment) {
+		logf("set environment variable %s=%s", name, out.Redactions.Redact(out.Environment[name]))
+	}
+	for _, path := range written {
+		logf("wrote file %s", path)
+	}
+	logf("running: %s", out.Redactions.Redact(strings.Join(out.CommandLine, " ")))
+
+	for _, usage := range usages {
+		hook, ok := usage.provisioner.(sdk.PreExecHook)
+		if !ok {
+			continue
+		}
+		var preExecOut sdk.PreExecOutput
+		hook.PreExec(ctx, sdk.PreExecInput{HomeDir: homeDir, TempDir: tempDir, Environment: usage.environment, ItemFields: usage.itemFields, CommandLine: out.CommandLine}, &preExecOut)
+		if len(preExecOut.Diagnostics.Errors) > 0 {
+			reason = sdk.DeprovisionReasonProvisionFailed
+			for _, e := range preExecOut.Diagnostics.Errors {
+				logf("pre-exec error: %s", e.Message)
+			}
+			return 0, errors.New("pre-exec hook failed")
+		}
+	}
+
+	start := time.Now()
+	exitCode, err := runCommand(ctx, out.CommandLine, out.Environment, opts.Stdin, opts.Stdout, opts.Stderr)
+	duration := time.Since(start)
+	if err != nil {
+		reason = sdk.DeprovisionReasonProvisionFailed
+		return 0, err
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		reason = sdk.DeprovisionReasonInterrupted
+	case exitCode == -1:
+		reason = sdk.DeprovisionReasonKilled
+	}
+
+	for _, usage := range usages {
+		hook, ok := usage.provisioner.(sdk.PostExecHook)
+		if !ok {
+			continue
+		}
+		var postExecOut sdk.PostExecOutput
+		hook.PostExec(context.Background(), sdk.PostExecInput{HomeDir: homeDir, TempDir: tempDir, Environment: usage.environment, ItemFields: usage.itemFields, CommandLine: out.CommandLine, ExitCode: exitCode, Duration: duration}, &postExecOut)
+		for _, e := range postExecOut.Diagnostics.Errors {
+			logf("post-exec error: %s", e.Message)
+		}
+	}
+
+	return exitCode, nil
+}
+
+// deprovision deprovisions the usages in reverse order, each with a fresh context that is canceled after
+// sdk.DeprovisionTimeout, just like the 1Password CLI does.
+func deprovision(homeDir string, tempDir string, profile string, reason sdk.DeprovisionReason, usages []provisioned, logf func(format string, a ...any)) {
+	for i := len(usages) - 1; i >= 0; i-- {
+		usage := usages[i]
+
+		ctx, cancel := context.WithTimeout(context.Background(), sdk.DeprovisionTimeout)
+		var out sdk.DeprovisionOutput
+		usage.provisioner.Deprovision(ctx, sdk.DeprovisionInput{
+			HomeDir:     homeDir,
+			TempDir:     tempDir,
+			Environment: usage.environment,
+			Profile:     profile,
+			Reason:      reason,
+		}, &out)
+		if ctx.Err() != nil {
+			logf("deprovisioning %s did not finish within %s", usage.credential.Name, sdk.DeprovisionTimeout)
+		}
+		cancel()
+
+		for _, e := range out.Diagnostics.Errors {
+			logf("deprovisioning error: %s", e.Message)
+		}
+	}
+}
+
+// runCommand runs the command line with the specified environment variables added to the current environment, and
+// returns its exit code. Exiting with a non-zero exit code is not considered an error.
+func runCommand(ctx context.Context, commandLine []string, env map[string]string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
+	cmd := exec.CommandContext(ctx, commandLine[0], commandLine[1:]...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = os.Environ()
+	for _, name := range sortedKeys(env) {
+		cmd.Env = append(cmd.Env, name+"="+env[name])
+	}
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// writeFiles writes the provisioned files to disk and returns the paths of the files that got written. Files
+// outside of the temp dir are only written if they don't exist yet, so that local config never gets overwritten.
+func writeFiles(files map[string]sdk.OutputFile, tempDir string) ([]string, error) {
+	var written []string
+	for _, path := range sortedKeys(files) {
+		if !strings.HasPrefix(path, tempDir+string(filepath.Separator)) {
+			if _, err := os.Stat(path); err == nil {
+				return written, fmt.Errorf("refusing to overwrite existing file %s", path)
+			}
+		}
+
+		err := os.MkdirAll(filepath.Dir(path), 0700)
+		if err != nil {
+			return written, err
+		}
+
+		err = os.WriteFile(path, files[path].Contents, 0600)
+		if err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// findExecutable returns the executable of the plugin that the command line runs, along with the entrypoint that
+// matched. Longer entrypoints take precedence, e.g. `gcloud alpha` over `gcloud`.
+func findExecutable(plugin schema.Plugin, commandLine []string) (*schema.Executable, []string) {
+	var match *schema.Executable
+	var matchedEntrypoint []string
+	for i, executable := range plugin.Executables {
+		for _, entrypoint := range executable.Entrypoints() {
+			if len(entrypoint) == 0 || len(entrypoint) > len(commandLine) || len(entrypoint) <= len(matchedEntrypoint) {
+				continue
+			}
+			if strings.Join(entrypoint, " ") == strings.Join(commandLine[:len(entrypoint)], " ") {
+				match = &plugin.Executables[i]
+				matchedEntrypoint = entrypoint
+			}
+		}
+	}
+	return match, matchedEntrypoint
+}
+
+func usageName(usage schema.CredentialUsage) string {
+	if usage.SelectFrom != nil {
+		return usage.SelectFrom.ID
+	}
+	if usage.Plugin != "" {
+		return usage.Plugin + "/" + usage.Name.String()
+	}
+	return usage.Name.String()
+}
+
+// environ returns the environment of the current process as a map.
+func environ() map[string]string {
+	environment := make(map[string]string)
+	for _, envVar := range os.Environ() {
+		name, value, _ := strings.Cut(envVar, "=")
+		environment[name] = value
+	}
+	return environment
+}
+
+func isTerminal(f *os.File) *bool {
+	info, err := f.Stat()
+	if err != nil {
+		return nil
+	}
+	isTerminal := info.Mode()&os.ModeCharDevice != 0
+	return &isTerminal
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
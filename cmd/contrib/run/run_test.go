@@ -0,0 +1,221 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+)
+
+// reasonRecorder wraps a provisioner to record the reason and environment it got deprovisioned with.
+type reasonRecorder struct {
+	sdk.Provisioner
+	reason      sdk.DeprovisionReason
+	environment sdk.Environment
+}
+
+func (r *reasonRecorder) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	r.reason = in.Reason
+	r.environment = in.Environment
+	r.Provisioner.Deprovision(ctx, in, out)
+}
+
+func testPlugin(provisioner sdk.Provisioner) schema.Plugin {
+	return schema.Plugin{
+		Name: "acme",
+		Credentials: []schema.CredentialType{
+			{
+				Name: credname.APIToken,
+				Fields: []schema.CredentialField{
+					{
+						Name:   fieldname.Token,
+						Secret: true,
+						Composition: &schema.ValueComposition{
+							Length:  20,
+							Charset: schema.Charset{Lowercase: true},
+						},
+					},
+				},
+				DefaultProvisioner: provisioner,
+			},
+		},
+		Executables: []schema.Executable{
+			{
+				Name:      "Acme CLI",
+				Runs:      []string{"sh"},
+				NeedsAuth: needsauth.NotWhenContainsArgs("--version"),
+				Uses: []schema.CredentialUsage{
+					{
+						Name: credname.APIToken,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh as the executable")
+	}
+
+	recorder := &reasonRecorder{Provisioner: provision.TempFile(provision.FieldAsFile(fieldname.Token),
+		provision.Filename("token"),
+		provision.SetPathAsEnvVar("ACME_TOKEN_FILE"),
+	)}
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := Run(context.Background(), testPlugin(recorder), []string{"sh", "-c", `cat "$ACME_TOKEN_FILE"; echo "$ACME_TOKEN_FILE" >&2; exit 3`}, Options{
+		Fields: map[sdk.FieldName]string{
+			fieldname.Token: "abcdefghijklmnopqrst",
+		},
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+	assert.Equal(t, "abcdefghijklmnopqrst", stdout.String())
+	assert.Equal(t, sdk.DeprovisionReasonExited, recorder.reason)
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	tokenFile := lines[len(lines)-1]
+	_, err = os.Stat(tokenFile)
+	assert.True(t, os.IsNotExist(err), "provisioned file should be deleted after the run")
+}
+
+func TestRunSkipsProvisioningWhenNotNeeded(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh as the executable")
+	}
+
+	recorder := &reasonRecorder{Provisioner: provision.EnvVars(map[string]sdk.FieldName{"ACME_TOKEN": fieldname.Token})}
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := Run(context.Background(), testPlugin(recorder), []string{"sh", "-c", `echo "token=$ACME_TOKEN"`, "--version"}, Options{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, "token=\n", stdout.String())
+	assert.Contains(t, stderr.String(), "does not need authentication")
+	assert.Empty(t, recorder.reason, "should not deprovision what never got provisioned")
+}
+
+func TestRunDeprovisionsWhenProvisioningFails(t *testing.T) {
+	recorder := &reasonRecorder{Provisioner: provision.TempFile(provision.FieldAsFile("Missing Field"))}
+
+	var stderr bytes.Buffer
+	_, err := Run(context.Background(), testPlugin(recorder), []string{"sh", "-c", "exit 0"}, Options{
+		Stderr: &stderr,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, sdk.DeprovisionReasonProvisionFailed, recorder.reason)
+	assert.Contains(t, stderr.String(), "provisioning error")
+}
+
+func testPluginWithEnvironments(provisioner sdk.Provisioner) schema.Plugin {
+	plugin := testPlugin(provisioner)
+	plugin.Credentials[0].Environments = &schema.EnvironmentSelection{
+		Supported: []sdk.Environment{sdk.EnvironmentProduction, sdk.EnvironmentDevelopment},
+		Flag:      "--env",
+	}
+	return plugin
+}
+
+func TestRunDeprovisionsSelectedEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh as the executable")
+	}
+
+	recorder := &reasonRecorder{Provisioner: provision.PerEnvironment(map[sdk.Environment]sdk.Provisioner{
+		sdk.EnvironmentProduction:  provision.EnvVars(map[string]sdk.FieldName{"ACME_LIVE_TOKEN": fieldname.Token}),
+		sdk.EnvironmentDevelopment: provision.EnvVars(map[string]sdk.FieldName{"ACME_TEST_TOKEN": fieldname.Token}),
+	})}
+
+	var stdout bytes.Buffer
+	exitCode, err := Run(context.Background(), testPluginWithEnvironments(recorder), []string{"sh", "-c", `echo "live=$ACME_LIVE_TOKEN test=$ACME_TEST_TOKEN"`, "--env", "development"}, Options{
+		Fields: map[sdk.FieldName]string{
+			fieldname.Token: "abcdefghijklmnopqrst",
+		},
+		Stdout: &stdout,
+		Stderr: &bytes.Buffer{},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, "live= test=abcdefghijklmnopqrst\n", stdout.String())
+	assert.Equal(t, sdk.EnvironmentDevelopment, recorder.environment)
+}
+
+func TestRunFailsForUnsupportedEnvironment(t *testing.T) {
+	recorder := &reasonRecorder{Provisioner: provision.EnvVars(map[string]sdk.FieldName{"ACME_TOKEN": fieldname.Token})}
+
+	var stderr bytes.Buffer
+	_, err := Run(context.Background(), testPluginWithEnvironments(recorder), []string{"sh", "-c", "exit 0", "--env", "staging"}, Options{
+		Stderr: &stderr,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, stderr.String(), `environment "staging" is not supported`)
+	assert.Empty(t, recorder.reason, "should not deprovision what never got provisioned")
+}
+
+func TestRunUnknownExecutable(t *testing.T) {
+	_, err := Run(context.Background(), testPlugin(provision.NoOp()), []string{"acme"}, Options{})
+	assert.Error(t, err)
+}
+
+func TestRunRetriesWithCredentialsOnAuthFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh as the executable")
+	}
+
+	plugin := testPlugin(provision.EnvVars(map[string]sdk.FieldName{"ACME_TOKEN": fieldname.Token}))
+	plugin.Executables[0].AuthOnFailure = &schema.AuthOnFailure{
+		ExitCodes:      []int{4},
+		StderrPatterns: []string{"unauthorized"},
+	}
+	script := `if [ -z "$ACME_TOKEN" ]; then echo unauthorized >&2; exit 4; fi; echo "token=$ACME_TOKEN"`
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := Run(context.Background(), plugin, []string{"sh", "-c", script}, Options{
+		Fields: map[sdk.FieldName]string{
+			fieldname.Token: "abcdefghijklmnopqrst",
+		},
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, "token=abcdefghijklmnopqrst\n", stdout.String())
+	assert.Contains(t, stderr.String(), "retrying with credentials provisioned")
+}
+
+func TestRunSkipsCredentialsWhenFirstRunSucceeds(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh as the executable")
+	}
+
+	recorder := &reasonRecorder{Provisioner: provision.EnvVars(map[string]sdk.FieldName{"ACME_TOKEN": fieldname.Token})}
+	plugin := testPlugin(recorder)
+	plugin.Executables[0].AuthOnFailure = &schema.AuthOnFailure{}
+
+	var stdout bytes.Buffer
+	exitCode, err := Run(context.Background(), plugin, []string{"sh", "-c", `echo "token=$ACME_TOKEN"`}, Options{
+		Stdout: &stdout,
+		Stderr: &bytes.Buffer{},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, "token=\n", stdout.String())
+	assert.Empty(t, recorder.reason, "should not provision when the first run succeeds")
+}
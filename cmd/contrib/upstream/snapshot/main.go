@@ -0,0 +1,144 @@
+// Command snapshot prints the schemas of all plugins of the repository as JSON, so that they can be compared
+// against the schemas of another checkout, e.g. the upstream repository.
+//
+// This file gets copied into the checkout that is compared against, so it should only depend on parts of the
+// SDK that are unlikely to change.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+
+	"github.com/1Password/shell-plugins/plugins"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+type pluginSnapshot struct {
+	Name        string               `json:"name"`
+	Platform    platformSnapshot     `json:"platform"`
+	Credentials []credentialSnapshot `json:"credentials,omitempty"`
+	Executables []executableSnapshot `json:"executables,omitempty"`
+}
+
+type platformSnapshot struct {
+	Name     string `json:"name"`
+	Homepage string `json:"homepage,omitempty"`
+}
+
+type credentialSnapshot struct {
+	Name                  string          `json:"name"`
+	DocsURL               string          `json:"docs_url,omitempty"`
+	ManagementURL         string          `json:"management_url,omitempty"`
+	Fields                []fieldSnapshot `json:"fields,omitempty"`
+	HasImporter           bool            `json:"has_importer"`
+	HasDefaultProvisioner bool            `json:"has_default_provisioner"`
+}
+
+type fieldSnapshot struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description,omitempty"`
+	Secret      bool                 `json:"secret"`
+	Optional    bool                 `json:"optional"`
+	Composition *compositionSnapshot `json:"composition,omitempty"`
+}
+
+type compositionSnapshot struct {
+	Length    int    `json:"length,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Uppercase bool   `json:"uppercase"`
+	Lowercase bool   `json:"lowercase"`
+	Digits    bool   `json:"digits"`
+	Symbols   bool   `json:"symbols"`
+	Specific  string `json:"specific,omitempty"`
+}
+
+type executableSnapshot struct {
+	Name         string   `json:"name"`
+	Runs         []string `json:"runs"`
+	DocsURL      string   `json:"docs_url,omitempty"`
+	HasNeedsAuth bool     `json:"has_needs_auth"`
+	Uses         []string `json:"uses,omitempty"`
+}
+
+func main() {
+	var snapshots []pluginSnapshot
+	for _, p := range plugins.List() {
+		snapshots = append(snapshots, snapshot(p))
+	}
+
+	out, err := json.MarshalIndent(snapshots, "", "\t")
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, _ = os.Stdout.Write(append(out, '\n'))
+}
+
+func snapshot(p schema.Plugin) pluginSnapshot {
+	result := pluginSnapshot{
+		Name: p.Name,
+		Platform: platformSnapshot{
+			Name:     p.Platform.Name,
+			Homepage: urlString(p.Platform.Homepage),
+		},
+	}
+
+	for _, c := range p.Credentials {
+		credential := credentialSnapshot{
+			Name:                  fmt.Sprint(c.Name),
+			DocsURL:               urlString(c.DocsURL),
+			ManagementURL:         urlString(c.ManagementURL),
+			HasImporter:           c.Importer != nil,
+			HasDefaultProvisioner: c.DefaultProvisioner != nil,
+		}
+		for _, f := range c.Fields {
+			field := fieldSnapshot{
+				Name:        fmt.Sprint(f.Name),
+				Description: f.MarkdownDescription,
+				Secret:      f.Secret,
+				Optional:    f.Optional,
+			}
+			if f.Composition != nil {
+				field.Composition = &compositionSnapshot{
+					Length:    f.Composition.Length,
+					Prefix:    f.Composition.Prefix,
+					Uppercase: f.Composition.Charset.Uppercase,
+					Lowercase: f.Composition.Charset.Lowercase,
+					Digits:    f.Composition.Charset.Digits,
+					Symbols:   f.Composition.Charset.Symbols,
+					Specific:  string(f.Composition.Charset.Specific),
+				}
+			}
+			credential.Fields = append(credential.Fields, field)
+		}
+		result.Credentials = append(result.Credentials, credential)
+	}
+
+	for _, e := range p.Executables {
+		executable := executableSnapshot{
+			Name:         e.Name,
+			Runs:         e.Runs,
+			DocsURL:      urlString(e.DocsURL),
+			HasNeedsAuth: e.NeedsAuth != nil,
+		}
+		for _, usage := range e.Uses {
+			name := fmt.Sprint(usage.Name)
+			if usage.Plugin != "" {
+				name = usage.Plugin + "/" + name
+			}
+			executable.Uses = append(executable.Uses, name)
+		}
+		result.Executables = append(result.Executables, executable)
+	}
+
+	return result
+}
+
+func urlString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,363 @@
+// Package upstream compares the plugins of this repository against the upstream 1Password/shell-plugins
+// repository, to keep forks maintainable.
+package upstream
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DefaultURL is the Git URL of the upstream repository.
+const DefaultURL = "https://github.com/1Password/shell-plugins.git"
+
+//go:embed snapshot/main.go
+var snapshotSource []byte
+
+var snapshotPath = filepath.Join("cmd", "contrib", "upstream", "snapshot", "main.go")
+
+// Schemas contains the JSON schema snapshots of the plugins of a checkout, by plugin name.
+type Schemas map[string]any
+
+// FileStatus describes how a file of a plugin differs between the local checkout and upstream.
+type FileStatus string
+
+const (
+	FileLocalOnly    FileStatus = "local only"
+	FileUpstreamOnly FileStatus = "upstream only"
+	FileModified     FileStatus = "modified"
+)
+
+// FileChange is a file of a plugin that differs between the local checkout and upstream.
+type FileChange struct {
+	Path   string
+	Status FileStatus
+}
+
+// Report describes how the plugins of the local checkout diverge from upstream.
+type Report struct {
+	// MissingPlugins contains the plugins that exist upstream, but not locally.
+	MissingPlugins []string
+
+	// LocalOnlyPlugins contains the plugins that exist locally, but not upstream.
+	LocalOnlyPlugins []string
+
+	// SchemaDiffs contains the differences in the schema of plugins that exist in both, by plugin name.
+	SchemaDiffs map[string][]string
+
+	// FileChanges contains the source files that differ for plugins that exist in both, by plugin name.
+	FileChanges map[string][]FileChange
+}
+
+// Clone makes a shallow clone of the Git repository at the URL into the directory.
+func Clone(ctx context.Context, url string, dir string) error {
+	cmd := exec.CommandContext(ctx, "git", "clone", "--quiet", "--depth", "1", url, dir)
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("cloning %s: %w", url, err)
+	}
+	return nil
+}
+
+// LoadSchemas generates the plugin registry of the checkout in the directory, and returns the schema snapshots
+// of its plugins. If the checkout doesn't contain the snapshot command yet, it gets added temporarily.
+func LoadSchemas(ctx context.Context, repoDir string) (Schemas, error) {
+	path := filepath.Join(repoDir, snapshotPath)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		created := filepath.Dir(path)
+		for parent := filepath.Dir(created); !exists(parent); parent = filepath.Dir(created) {
+			created = parent
+		}
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(created)
+
+		err = os.WriteFile(path, snapshotSource, 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := goRun(ctx, repoDir, filepath.Join("cmd", "contrib", "main.go"), "registry")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := goRun(ctx, repoDir, "./"+filepath.ToSlash(filepath.Dir(snapshotPath)))
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []map[string]any
+	err = json.Unmarshal(out, &snapshots)
+	if err != nil {
+		return nil, fmt.Errorf("parsing plugin schemas of %s: %w", repoDir, err)
+	}
+
+	schemas := make(Schemas)
+	for _, snapshot := range snapshots {
+		if name, ok := snapshot["name"].(string); ok {
+			schemas[name] = snapshot
+		}
+	}
+	return schemas, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func goRun(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", append([]string{"run"}, args...)...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running go run %s in %s: %w\n%s", strings.Join(args, " "), dir, err, stderr.String())
+	}
+	return out, nil
+}
+
+// Compare compares the local plugins against the upstream ones. The source files of plugins that exist in both
+// get compared as well, by reading them from the plugins directory of both checkouts.
+func Compare(local Schemas, localDir string, upstream Schemas, upstreamDir string) (Report, error) {
+	report := Report{
+		SchemaDiffs: make(map[string][]string),
+		FileChanges: make(map[string][]FileChange),
+	}
+
+	for _, name := range sortedKeys(upstream) {
+		if _, ok := local[name]; !ok {
+			report.MissingPlugins = append(report.MissingPlugins, name)
+		}
+	}
+
+	for _, name := range sortedKeys(local) {
+		if _, ok := upstream[name]; !ok {
+			report.LocalOnlyPlugins = append(report.LocalOnlyPlugins, name)
+			continue
+		}
+
+		var diffs []string
+		diffValues("", local[name], upstream[name], &diffs)
+		if len(diffs) > 0 {
+			report.SchemaDiffs[name] = diffs
+		}
+
+		changes, err := compareFiles(filepath.Join(localDir, "plugins", name), filepath.Join(upstreamDir, "plugins", name))
+		if err != nil {
+			return Report{}, err
+		}
+		if len(changes) > 0 {
+			report.FileChanges[name] = changes
+		}
+	}
+
+	return report, nil
+}
+
+// diffValues appends a description of every difference between the local and upstream JSON values to diffs.
+// Lists of named objects, such as credentials and fields, are compared by name instead of by position.
+func diffValues(path string, local any, upstream any, diffs *[]string) {
+	localMap, localIsMap := local.(map[string]any)
+	upstreamMap, upstreamIsMap := upstream.(map[string]any)
+	if localIsMap && upstreamIsMap {
+		for _, key := range unionKeys(localMap, upstreamMap) {
+			subPath := key
+			if path != "" {
+				subPath = path + "." + key
+			}
+			diffEntry(subPath, localMap, upstreamMap, key, diffs)
+		}
+		return
+	}
+
+	localNamed, localOK := namedElements(local)
+	upstreamNamed, upstreamOK := namedElements(upstream)
+	if localOK && upstreamOK {
+		for _, name := range unionKeys(localNamed, upstreamNamed) {
+			diffEntry(fmt.Sprintf("%s[%q]", path, name), localNamed, upstreamNamed, name, diffs)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(local, upstream) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: upstream %s, local %s", path, compactJSON(upstream), compactJSON(local)))
+	}
+}
+
+func diffEntry(path string, local map[string]any, upstream map[string]any, key string, diffs *[]string) {
+	localValue, inLocal := local[key]
+	upstreamValue, inUpstream := upstream[key]
+	switch {
+	case !inLocal:
+		*diffs = append(*diffs, fmt.Sprintf("%s: upstream only", path))
+	case !inUpstream:
+		*diffs = append(*diffs, fmt.Sprintf("%s: local only", path))
+	default:
+		diffValues(path, localValue, upstreamValue, diffs)
+	}
+}
+
+// namedElements returns the elements of a JSON list by name, if all of them are objects with a unique name.
+func namedElements(value any) (map[string]any, bool) {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+
+	result := make(map[string]any)
+	for _, element := range list {
+		object, ok := element.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if _, duplicate := result[name]; !ok || duplicate {
+			return nil, false
+		}
+		result[name] = element
+	}
+	return result, true
+}
+
+func compactJSON(value any) string {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(out)
+}
+
+// compareFiles returns the files that differ between the two plugin directories.
+func compareFiles(localDir string, upstreamDir string) ([]FileChange, error) {
+	localFiles, err := readFiles(localDir)
+	if err != nil {
+		return nil, err
+	}
+	upstreamFiles, err := readFiles(upstreamDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FileChange
+	for _, path := range unionKeys(localFiles, upstreamFiles) {
+		localContents, inLocal := localFiles[path]
+		upstreamContents, inUpstream := upstreamFiles[path]
+		switch {
+		case !inUpstream:
+			changes = append(changes, FileChange{Path: path, Status: FileLocalOnly})
+		case !inLocal:
+			changes = append(changes, FileChange{Path: path, Status: FileUpstreamOnly})
+		case !bytes.Equal(localContents, upstreamContents):
+			changes = append(changes, FileChange{Path: path, Status: FileModified})
+		}
+	}
+	return changes, nil
+}
+
+// readFiles returns the contents of all files in the directory and its subdirectories, by slash-separated path
+// relative to the directory. A directory that doesn't exist has no files.
+func readFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == dir {
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = contents
+		return nil
+	})
+	return files, err
+}
+
+// String renders the report as human-readable text.
+func (r Report) String() string {
+	if len(r.MissingPlugins) == 0 && len(r.LocalOnlyPlugins) == 0 && len(r.SchemaDiffs) == 0 && len(r.FileChanges) == 0 {
+		return "The plugins are in sync with upstream.\n"
+	}
+
+	var b strings.Builder
+	writeList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "%s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&b, "  - %s\n", item)
+		}
+		b.WriteString("\n")
+	}
+
+	writeList("Missing plugins (upstream only)", r.MissingPlugins)
+	writeList("Local-only plugins", r.LocalOnlyPlugins)
+
+	if len(r.SchemaDiffs) > 0 {
+		b.WriteString("Diverging schemas:\n")
+		for _, name := range sortedKeys(r.SchemaDiffs) {
+			fmt.Fprintf(&b, "  %s:\n", name)
+			for _, diff := range r.SchemaDiffs[name] {
+				fmt.Fprintf(&b, "    - %s\n", diff)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.FileChanges) > 0 {
+		b.WriteString("Changed files:\n")
+		for _, name := range sortedKeys(r.FileChanges) {
+			fmt.Fprintf(&b, "  %s:\n", name)
+			for _, change := range r.FileChanges[name] {
+				fmt.Fprintf(&b, "    - %s (%s)\n", change.Path, change.Status)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionKeys[V any](a map[string]V, b map[string]V) []string {
+	keys := sortedKeys(a)
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
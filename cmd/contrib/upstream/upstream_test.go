@@ -0,0 +1,117 @@
+package upstream
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseSchemas(t *testing.T, contents string) Schemas {
+	var snapshots []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(contents), &snapshots))
+
+	schemas := make(Schemas)
+	for _, snapshot := range snapshots {
+		schemas[snapshot["name"].(string)] = snapshot
+	}
+	return schemas
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for path, contents := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	}
+}
+
+func TestCompare(t *testing.T) {
+	local := parseSchemas(t, `[
+		{"name": "forked", "platform": {"name": "Forked"}},
+		{"name": "shared", "platform": {"name": "Shared"}, "credentials": [
+			{"name": "API Key", "fields": [
+				{"name": "Key", "secret": true, "optional": false},
+				{"name": "Region", "secret": false, "optional": true}
+			]}
+		], "executables": [{"name": "Shared CLI", "runs": ["shared", "sh"]}]}
+	]`)
+	upstream := parseSchemas(t, `[
+		{"name": "new", "platform": {"name": "New"}},
+		{"name": "shared", "platform": {"name": "Shared"}, "credentials": [
+			{"name": "API Key", "fields": [
+				{"name": "Key", "secret": true, "optional": false},
+				{"name": "Host", "secret": false, "optional": true}
+			]}
+		], "executables": [{"name": "Shared CLI", "runs": ["shared"]}]}
+	]`)
+
+	localDir := t.TempDir()
+	upstreamDir := t.TempDir()
+	writeFiles(t, localDir, map[string]string{
+		"plugins/shared/plugin.go":  "package shared // local",
+		"plugins/shared/api_key.go": "package shared",
+		"plugins/shared/region.go":  "package shared",
+	})
+	writeFiles(t, upstreamDir, map[string]string{
+		"plugins/shared/plugin.go":  "package shared",
+		"plugins/shared/api_key.go": "package shared",
+		"plugins/shared/host.go":    "package shared",
+	})
+
+	report, err := Compare(local, localDir, upstream, upstreamDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, Report{
+		MissingPlugins:   []string{"new"},
+		LocalOnlyPlugins: []string{"forked"},
+		SchemaDiffs: map[string][]string{
+			"shared": {
+				`credentials["API Key"].fields["Host"]: upstream only`,
+				`credentials["API Key"].fields["Region"]: local only`,
+				`executables["Shared CLI"].runs: upstream ["shared"], local ["shared","sh"]`,
+			},
+		},
+		FileChanges: map[string][]FileChange{
+			"shared": {
+				{Path: "host.go", Status: FileUpstreamOnly},
+				{Path: "plugin.go", Status: FileModified},
+				{Path: "region.go", Status: FileLocalOnly},
+			},
+		},
+	}, report)
+
+	assert.Equal(t, `Missing plugins (upstream only):
+  - new
+
+Local-only plugins:
+  - forked
+
+Diverging schemas:
+  shared:
+    - credentials["API Key"].fields["Host"]: upstream only
+    - credentials["API Key"].fields["Region"]: local only
+    - executables["Shared CLI"].runs: upstream ["shared"], local ["shared","sh"]
+
+Changed files:
+  shared:
+    - host.go (upstream only)
+    - plugin.go (modified)
+    - region.go (local only)
+
+`, report.String())
+}
+
+func TestCompareInSync(t *testing.T) {
+	schemas := parseSchemas(t, `[{"name": "shared", "platform": {"name": "Shared"}}]`)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"plugins/shared/plugin.go": "package shared"})
+
+	report, err := Compare(schemas, dir, schemas, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The plugins are in sync with upstream.\n", report.String())
+}
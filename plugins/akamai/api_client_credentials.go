@@ -70,6 +70,12 @@ func APIClientCredentials() schema.CredentialType {
 					},
 				},
 			},
+			{
+				Name:                fieldname.AccountKey,
+				MarkdownDescription: "Account Switch Key used to manage another account, e.g. when using partner or reseller API clients.",
+				Secret:              false,
+				Optional:            true,
+			},
 		},
 		DefaultProvisioner: provision.TempFile(configFile,
 			provision.Filename(".edgerc"),
@@ -103,6 +109,10 @@ func configFile(in sdk.ProvisionInput) ([]byte, error) {
 		contents += "client_token = " + clienttoken + "\n"
 	}
 
+	if accountkey, ok := in.ItemFields[fieldname.AccountKey]; ok {
+		contents += "account_key = " + accountkey + "\n"
+	}
+
 	return []byte(contents), nil
 }
 
@@ -132,6 +142,9 @@ func TryAkamaiConfigFile() sdk.Importer {
 			if section.HasKey("client_token") && section.Key("client_token").Value() != "" {
 				fields[fieldname.ClientToken] = section.Key("client_token").Value()
 			}
+			if section.HasKey("account_key") && section.Key("account_key").Value() != "" {
+				fields[fieldname.AccountKey] = section.Key("account_key").Value()
+			}
 
 			// add candidates that contain all required credential fields
 			if fields[fieldname.ClientSecret] != "" && fields[fieldname.Host] != "" && fields[fieldname.AccessToken] != "" && fields[fieldname.ClientToken] != "" {
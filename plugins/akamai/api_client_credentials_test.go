@@ -27,6 +27,24 @@ func TestAPIClientCredentialsProvisioner(t *testing.T) {
 				},
 			},
 		},
+		"with account switch key": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.ClientSecret: "abcdE23FNkBxy456z25qx9Yp5CPUxlEfQeTDkfh4QA=I",
+				fieldname.Host:         "akab-lmn789n2k53w7qrs-nfkxaa4lfk3kd6ym.luna.akamaiapis.net",
+				fieldname.AccessToken:  "akab-zyx987xa6osbli4k-e7jf5ikib5jknes3",
+				fieldname.ClientToken:  "akab-nomoflavjuc4422e-fa2xznerxrm3teg7",
+				fieldname.AccountKey:   "1-5C0YLB:1-8BYUX",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				CommandLine: []string{"--edgerc", "/tmp/.edgerc", "--section", "default"},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/.edgerc": {Contents: []byte(plugintest.LoadFixture(t, ".edgerc-account-key"))},
+				},
+				Environment: map[string]string{
+					"EDGERC": "/tmp/.edgerc",
+				},
+			},
+		},
 	})
 }
 
@@ -73,5 +91,22 @@ func TestAPIClientCredentialsImporter(t *testing.T) {
 				},
 			},
 		},
+		"config file with account switch key": {
+			Files: map[string]string{
+				"~/.edgerc": plugintest.LoadFixture(t, ".edgerc-account-key"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					NameHint: "",
+					Fields: map[sdk.FieldName]string{
+						fieldname.ClientSecret: "abcdE23FNkBxy456z25qx9Yp5CPUxlEfQeTDkfh4QA=I",
+						fieldname.Host:         "akab-lmn789n2k53w7qrs-nfkxaa4lfk3kd6ym.luna.akamaiapis.net",
+						fieldname.AccessToken:  "akab-zyx987xa6osbli4k-e7jf5ikib5jknes3",
+						fieldname.ClientToken:  "akab-nomoflavjuc4422e-fa2xznerxrm3teg7",
+						fieldname.AccountKey:   "1-5C0YLB:1-8BYUX",
+					},
+				},
+			},
+		},
 	})
 }
@@ -0,0 +1,117 @@
+package aliyun
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func AccessKey() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.AccessKey,
+		DocsURL:       sdk.URL("https://www.alibabacloud.com/help/en/ram/user-guide/create-an-accesskey-pair"),
+		ManagementURL: sdk.URL("https://ram.console.aliyun.com/manage/ak"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.AccessKeyID,
+				MarkdownDescription: "The AccessKey ID used to authenticate to Alibaba Cloud.",
+				Composition: &schema.ValueComposition{
+					Length: 24,
+					Prefix: "LTAI",
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+					},
+				},
+			},
+			{
+				Name:                fieldname.AccessKeySecret,
+				MarkdownDescription: "The AccessKey secret used to authenticate to Alibaba Cloud.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 30,
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+					},
+				},
+			},
+			{
+				Name:                fieldname.Region,
+				MarkdownDescription: "The region to use by default, e.g. 'cn-hangzhou'.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			importer.TryEnvVarPair(map[string]sdk.FieldName{
+				"ALIBABACLOUD_ACCESS_KEY_ID":     fieldname.AccessKeyID,
+				"ALIBABACLOUD_ACCESS_KEY_SECRET": fieldname.AccessKeySecret,
+				"ALIBABACLOUD_REGION_ID":         fieldname.Region,
+			}),
+			importer.TryEnvVarPair(map[string]sdk.FieldName{
+				"ALICLOUD_ACCESS_KEY_ID":     fieldname.AccessKeyID,
+				"ALICLOUD_ACCESS_KEY_SECRET": fieldname.AccessKeySecret,
+				"ALICLOUD_REGION_ID":         fieldname.Region,
+			}),
+			TryAliyunConfigFile(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"ALIBABA_CLOUD_ACCESS_KEY_ID":     fieldname.AccessKeyID,
+	"ALIBABA_CLOUD_ACCESS_KEY_SECRET": fieldname.AccessKeySecret,
+	"ALIBABA_CLOUD_REGION_ID":         fieldname.Region,
+}
+
+// TryAliyunConfigFile imports the profiles that use AccessKey authentication from the Alibaba Cloud CLI config.
+func TryAliyunConfigFile() sdk.Importer {
+	return importer.TryFile("~/.aliyun/config.json", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config Config
+		if err := contents.ToJSON(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, profile := range config.Profiles {
+			if profile.Mode != "AK" || profile.AccessKeyID == "" || profile.AccessKeySecret == "" {
+				continue
+			}
+
+			fields := map[sdk.FieldName]string{
+				fieldname.AccessKeyID:     profile.AccessKeyID,
+				fieldname.AccessKeySecret: profile.AccessKeySecret,
+			}
+			if profile.RegionID != "" {
+				fields[fieldname.Region] = profile.RegionID
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields:   fields,
+				NameHint: importer.SanitizeNameHint(profile.Name),
+			})
+		}
+	})
+}
+
+type Config struct {
+	Current  string          `json:"current"`
+	Profiles []ConfigProfile `json:"profiles"`
+}
+
+type ConfigProfile struct {
+	Name            string `json:"name"`
+	Mode            string `json:"mode"`
+	AccessKeyID     string `json:"access_key_id"`
+	AccessKeySecret string `json:"access_key_secret"`
+	RegionID        string `json:"region_id"`
+}
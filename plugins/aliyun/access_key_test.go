@@ -0,0 +1,61 @@
+package aliyun
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAccessKeyProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, AccessKey().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.AccessKeyID:     "LTAI5tQm8ZxR2vN4kEXAMPLE",
+				fieldname.AccessKeySecret: "jH3kL9mN2pQ5rS8tV1wX4yZEXAMPLE",
+				fieldname.Region:          "cn-hangzhou",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"ALIBABA_CLOUD_ACCESS_KEY_ID":     "LTAI5tQm8ZxR2vN4kEXAMPLE",
+					"ALIBABA_CLOUD_ACCESS_KEY_SECRET": "jH3kL9mN2pQ5rS8tV1wX4yZEXAMPLE",
+					"ALIBABA_CLOUD_REGION_ID":         "cn-hangzhou",
+				},
+			},
+		},
+	})
+}
+
+func TestAccessKeyImporter(t *testing.T) {
+	plugintest.TestImporter(t, AccessKey().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"ALIBABA_CLOUD_ACCESS_KEY_ID":     "LTAI5tQm8ZxR2vN4kEXAMPLE",
+				"ALIBABA_CLOUD_ACCESS_KEY_SECRET": "jH3kL9mN2pQ5rS8tV1wX4yZEXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.AccessKeyID:     "LTAI5tQm8ZxR2vN4kEXAMPLE",
+						fieldname.AccessKeySecret: "jH3kL9mN2pQ5rS8tV1wX4yZEXAMPLE",
+					},
+				},
+			},
+		},
+		"config file": {
+			Files: map[string]string{
+				"~/.aliyun/config.json": plugintest.LoadFixture(t, "config.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.AccessKeyID:     "LTAI5tQm8ZxR2vN4kEXAMPLE",
+						fieldname.AccessKeySecret: "jH3kL9mN2pQ5rS8tV1wX4yZEXAMPLE",
+						fieldname.Region:          "cn-hangzhou",
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package aliyun
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func AlibabaCloudCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Alibaba Cloud CLI",
+		Runs:    []string{"aliyun"},
+		DocsURL: sdk.URL("https://www.alibabacloud.com/help/en/alibaba-cloud-cli"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("configure"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.AccessKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package aliyun
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "aliyun",
+		Platform: schema.PlatformInfo{
+			Name:     "Alibaba Cloud",
+			Homepage: sdk.URL("https://www.alibabacloud.com"),
+		},
+		Credentials: []schema.CredentialType{
+			AccessKey(),
+		},
+		Executables: []schema.Executable{
+			AlibabaCloudCLI(),
+		},
+	}
+}
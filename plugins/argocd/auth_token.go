@@ -5,7 +5,6 @@ import (
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
-	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
@@ -19,8 +18,9 @@ func AuthToken() schema.CredentialType {
 		Fields: []schema.CredentialField{
 			{
 				Name:                fieldname.AuthToken,
-				MarkdownDescription: "Auth Token used to authenticate to Argo CD.",
+				MarkdownDescription: "Auth Token used to authenticate to Argo CD. Not needed when a username and password are set.",
 				Secret:              true,
+				Optional:            true,
 				Composition: &schema.ValueComposition{
 					Charset: schema.Charset{
 						Uppercase: true,
@@ -35,8 +35,19 @@ func AuthToken() schema.CredentialType {
 				MarkdownDescription: "Address of the ArgoCD server without https:// prefix.",
 				Optional:            true,
 			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "Username of a local Argo CD account, used to create a session token instead of storing an auth token.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Password,
+				MarkdownDescription: "Password of the local Argo CD account.",
+				Secret:              true,
+				Optional:            true,
+			},
 		},
-		DefaultProvisioner: provision.EnvVars(envVarMapping),
+		DefaultProvisioner: argocdProvisioner{},
 		Importer: importer.TryAll(
 			importer.TryEnvVarPair(envVarMapping),
 			TryArgocdConfigFile(),
@@ -72,6 +83,11 @@ func TryArgocdConfigFile() sdk.Importer {
 				}
 			}
 
+			// skip contexts of which the session was removed with 'argocd logout'
+			if fields[fieldname.AuthToken] == "" {
+				continue
+			}
+
 			out.AddCandidate(sdk.ImportCandidate{
 				Fields:   fields,
 				NameHint: importer.SanitizeNameHint(nameHint),
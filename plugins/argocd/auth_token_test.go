@@ -1,11 +1,19 @@
 package argocd
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/plugintest"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthTokenProvisioner(t *testing.T) {
@@ -20,7 +28,86 @@ func TestAuthTokenProvisioner(t *testing.T) {
 				},
 			},
 		},
+		"username without password": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Address:  "argocd.test.domain",
+				fieldname.Username: "admin",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"ARGOCD_SERVER": "argocd.test.domain",
+				},
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "either an auth token, or a username and password are required to authenticate to Argo CD"}},
+				},
+			},
+		},
+	})
+}
+
+func TestAuthTokenProvisionerSession(t *testing.T) {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"argocd","sub":"admin:login","exp":1680436800}`))
+	sessionToken := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." + claims + ".c2lnbmF0dXJlEXAMPLE"
+
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/v1/session", r.URL.Path)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "admin", body["username"])
+		assert.Equal(t, "Zp4Qm9Xv2Rk7Tn1EXAMPLE", body["password"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "` + sessionToken + `"}`))
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "https://")
+	itemFields := map[sdk.FieldName]string{
+		fieldname.Address:  address,
+		fieldname.Username: "admin",
+		fieldname.Password: "Zp4Qm9Xv2Rk7Tn1EXAMPLE",
+	}
+
+	expiresAt := time.Unix(1680436800, 0).UTC().Add(-tokenExpiryMargin)
+	in := sdk.ProvisionInput{ItemFields: itemFields}
+	cacheKey := "argocd|" + in.ItemFingerprint(fieldname.Address, fieldname.Username, fieldname.Password) + "|" + sessionTokenCacheKey
+	expectedCache := sdk.CacheOperations{Puts: map[string]sdk.CacheEntry{}}
+	require.NoError(t, expectedCache.Put(cacheKey, cachedSessionToken{Token: sessionToken, ExpiresAt: expiresAt}, expiresAt))
+
+	environment := map[string]string{
+		"ARGOCD_SERVER":     address,
+		"ARGOCD_AUTH_TOKEN": sessionToken,
+	}
+
+	plugintest.TestProvisioner(t, argocdProvisioner{client: server.Client()}, map[string]plugintest.ProvisionCase{
+		"login": {
+			ItemFields: itemFields,
+			Now:        now,
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: environment,
+				Cache:       expectedCache,
+			},
+		},
 	})
+	assert.Equal(t, 1, requests)
+
+	plugintest.TestProvisioner(t, argocdProvisioner{client: server.Client()}, map[string]plugintest.ProvisionCase{
+		"cached": {
+			ItemFields: itemFields,
+			Now:        now.Add(time.Hour),
+			Cache: sdk.CacheState{
+				cacheKey: expectedCache.Puts[cacheKey],
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: environment,
+			},
+		},
+	})
+	assert.Equal(t, 1, requests, "the cached session token should be used")
 }
 
 func TestAuthTokenImporter(t *testing.T) {
@@ -51,5 +138,11 @@ func TestAuthTokenImporter(t *testing.T) {
 				},
 			},
 		},
+		"config file without session": {
+			Files: map[string]string{
+				"~/.config/argocd/config": plugintest.LoadFixture(t, "config-logged-out"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{},
+		},
 	})
 }
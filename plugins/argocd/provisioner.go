@@ -0,0 +1,54 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// argocdProvisioner provisions the auth token stored in the item, or, if the item holds the username and password
+// of a local account instead, creates a session with these and provisions the session token.
+type argocdProvisioner struct {
+	// client is the HTTP client used to create the session. Defaults to http.DefaultClient.
+	client *http.Client
+}
+
+func (p argocdProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	if address, ok := in.ItemFields[fieldname.Address]; ok {
+		out.AddEnvVar("ARGOCD_SERVER", address)
+	}
+
+	if token, ok := in.ItemFields[fieldname.AuthToken]; ok {
+		out.AddEnvVar("ARGOCD_AUTH_TOKEN", token)
+		return
+	}
+
+	_, hasUsername := in.ItemFields[fieldname.Username]
+	_, hasPassword := in.ItemFields[fieldname.Password]
+	if !hasUsername || !hasPassword {
+		out.AddError(errors.New("either an auth token, or a username and password are required to authenticate to Argo CD"))
+		return
+	}
+	if in.ItemFields[fieldname.Address] == "" {
+		out.AddError(errors.New("the address of the Argo CD server is required to log in with a username and password"))
+		return
+	}
+
+	token, err := p.sessionToken(ctx, in, out)
+	if err != nil {
+		out.AddError(err)
+		return
+	}
+	out.AddEnvVar("ARGOCD_AUTH_TOKEN", token)
+}
+
+func (p argocdProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p argocdProvisioner) Description() string {
+	return "Provision environment variables ARGOCD_AUTH_TOKEN and ARGOCD_SERVER, creating a session if a username and password are set"
+}
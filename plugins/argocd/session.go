@@ -0,0 +1,119 @@
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const (
+	// tokenExpiryMargin makes sure a cached session token doesn't expire while the executable is using it.
+	tokenExpiryMargin = 5 * time.Minute
+
+	sessionTokenCacheKey = "session-token"
+
+	// dryRunSessionToken is provisioned instead of a real session token in dry runs, to skip creating a session.
+	dryRunSessionToken = "<session token>"
+)
+
+type cachedSessionToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// sessionToken returns a cached session token for the account, or creates a new session with the username and
+// password, like 'argocd login' does.
+func (p argocdProvisioner) sessionToken(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) (string, error) {
+	if in.DryRun {
+		return dryRunSessionToken, nil
+	}
+
+	cache := in.NamespacedCache(out, "argocd", in.ItemFingerprint(fieldname.Address, fieldname.Username, fieldname.Password))
+	var cached cachedSessionToken
+	if cache.Get(sessionTokenCacheKey, &cached) && cached.Token != "" {
+		return cached.Token, nil
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"username": in.ItemFields[fieldname.Username],
+		"password": in.ItemFields[fieldname.Password],
+	})
+	if err != nil {
+		return "", err
+	}
+
+	address := in.ItemFields[fieldname.Address]
+	if !strings.HasPrefix(address, "https://") && !strings.HasPrefix(address, "http://") {
+		address = "https://" + address
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(address, "/")+"/api/v1/session", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := p.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("creating Argo CD session: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var session struct {
+		Token   string `json:"token"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("decoding Argo CD session response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || session.Token == "" {
+		if session.Message != "" {
+			return "", fmt.Errorf("creating Argo CD session: %s (%s)", session.Message, resp.Status)
+		}
+		return "", fmt.Errorf("creating Argo CD session: unexpected response: %s", resp.Status)
+	}
+
+	// Only cache the token if it's known when it expires.
+	if expiresAt, ok := tokenExpiry(session.Token); ok {
+		expiresAt = expiresAt.Add(-tokenExpiryMargin)
+		if expiresAt.After(in.Now()) {
+			err = cache.PutUntil(sessionTokenCacheKey, cachedSessionToken{Token: session.Token, ExpiresAt: expiresAt}, expiresAt)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return session.Token, nil
+}
+
+// tokenExpiry reads the expiry time from the claims of the session token, which is a JWT.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.ExpiresAt, 0).UTC(), true
+}
@@ -54,6 +54,31 @@ func AccessKey() schema.CredentialType {
 				MarkdownDescription: "ARN of the MFA serial number to use to generate temporary STS credentials if the item contains a TOTP setup.",
 				Optional:            true,
 			},
+			{
+				Name:                fieldname.RoleARN,
+				MarkdownDescription: "ARN of the IAM role to assume with the access key, if the AWS profile doesn't configure a role. If the item contains a TOTP setup and an MFA serial, the one-time code is used for MFA.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.StartURL,
+				MarkdownDescription: "The URL of the AWS access portal to sign in to, to use temporary credentials of an IAM Identity Center (SSO) session instead of the access key.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.SSORegion,
+				MarkdownDescription: "The region that contains the AWS access portal.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.AccountID,
+				MarkdownDescription: "The ID of the AWS account to sign in to through the AWS access portal.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.RoleName,
+				MarkdownDescription: "The name of the IAM Identity Center permission set to assume in the account.",
+				Optional:            true,
+			},
 		},
 		DefaultProvisioner: NewSTSProvisioner(""),
 		Importer: importer.TryAll(
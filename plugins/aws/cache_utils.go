@@ -2,14 +2,18 @@ package aws
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
 )
 
 const (
 	mfaCacheKeyID        = "sts-mfa"
 	assumeRoleCacheKeyID = "sts-assume-role"
+	ssoRoleCacheKeyID    = "sso-role"
+	ssoTokenCacheKeyID   = "sso-token"
 )
 
 // stsCacheWriter writes aws temp credentials to cache using the awsCacheKey
@@ -36,3 +40,67 @@ func getRoleCacheKey(roleArn string, accessKeyID string) string {
 func getMfaCacheKey(accessKeyID string) string {
 	return fmt.Sprintf("%s|%s", mfaCacheKeyID, accessKeyID)
 }
+
+func getSSORoleCacheKey(startURL string, accountID string, roleName string) string {
+	return fmt.Sprintf("%s|%s|%s|%s", ssoRoleCacheKeyID, startURL, accountID, roleName)
+}
+
+func getSSOTokenCacheKey(startURL string) string {
+	return fmt.Sprintf("%s|%s", ssoTokenCacheKeyID, startURL)
+}
+
+// cachedOIDCToken is the SSO access token as stored in the cache.
+type cachedOIDCToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// oidcTokenCache stores the SSO access tokens in the plugin encrypted cache, so that users don't have to sign in
+// to the AWS access portal on every run. It implements the OIDCTokenCacher interface of AWS Vault.
+type oidcTokenCache struct {
+	in      sdk.CacheState
+	out     sdk.CacheOperations
+	now     func() time.Time
+	removed map[string]bool
+}
+
+// newOIDCTokenCache returns a token cache that uses now to check whether tokens have expired, or time.Now if it's nil.
+func newOIDCTokenCache(in sdk.CacheState, out sdk.CacheOperations, now func() time.Time) *oidcTokenCache {
+	if now == nil {
+		now = time.Now
+	}
+	return &oidcTokenCache{
+		in:      in,
+		out:     out,
+		now:     now,
+		removed: make(map[string]bool),
+	}
+}
+
+func (c *oidcTokenCache) Get(startURL string) (*ssooidc.CreateTokenOutput, error) {
+	key := getSSOTokenCacheKey(startURL)
+	var cached cachedOIDCToken
+	now := c.now()
+	if c.removed[key] || !c.in.Get(key, &cached) || !now.Before(cached.ExpiresAt) {
+		return nil, nil
+	}
+
+	return &ssooidc.CreateTokenOutput{
+		AccessToken: aws.String(cached.AccessToken),
+		ExpiresIn:   int32(cached.ExpiresAt.Sub(now).Seconds()),
+	}, nil
+}
+
+func (c *oidcTokenCache) Set(startURL string, token *ssooidc.CreateTokenOutput) error {
+	expiresAt := c.now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return c.out.Put(getSSOTokenCacheKey(startURL), cachedOIDCToken{
+		AccessToken: aws.ToString(token.AccessToken),
+		ExpiresAt:   expiresAt,
+	}, expiresAt)
+}
+
+// Remove makes sure the token isn't used anymore in this run. The token will be overwritten when the user signs in again.
+func (c *oidcTokenCache) Remove(startURL string) error {
+	c.removed[getSSOTokenCacheKey(startURL)] = true
+	return nil
+}
@@ -0,0 +1,76 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/credentialprocess"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const credentialsModeSetting = "credentials-mode"
+
+const (
+	environmentCredentialsMode       = "environment"
+	credentialProcessCredentialsMode = "credential-process"
+)
+
+func CredentialsMode() schema.Setting {
+	return schema.Setting{
+		Name:        credentialsModeSetting,
+		Description: "How to provide the credentials: as environment variables, or through a temporary AWS config file that uses credential_process, for tools that only read the AWS config file.",
+		Default:     environmentCredentialsMode,
+		Options:     []string{environmentCredentialsMode, credentialProcessCredentialsMode},
+	}
+}
+
+// provisionCredentialProcess provisions a temporary AWS config file with a profile that gets the credentials from
+// the plugin executable through credential_process, so that tools that read the AWS config file directly also get
+// the credentials, even when they don't inherit the environment.
+func provisionCredentialProcess(in sdk.ProvisionInput, out *sdk.ProvisionOutput, profile string, credentials aws.Credentials, region string) error {
+	processCredentials := credentialprocess.Credentials{
+		Version:         1,
+		AccessKeyID:     credentials.AccessKeyID,
+		SecretAccessKey: credentials.SecretAccessKey,
+		SessionToken:    credentials.SessionToken,
+	}
+	if credentials.CanExpire && !credentials.Expires.IsZero() {
+		processCredentials.Expiration = &credentials.Expires
+	}
+
+	contents, err := json.Marshal(processCredentials)
+	if err != nil {
+		return err
+	}
+	credentialsPath := in.FromTempDir("credentials.json")
+	out.AddSecretFile(credentialsPath, contents)
+
+	command, err := credentialprocess.Command(credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	section := fmt.Sprintf("profile %s", profile)
+	if profile == defaultProfileName {
+		section = defaultProfileName
+	}
+	config := fmt.Sprintf("[%s]\ncredential_process = %s\n", section, command)
+	if region != "" {
+		config += fmt.Sprintf("region = %s\n", region)
+	}
+	configPath := in.FromTempDir("config")
+	out.AddNonSecretFile(configPath, []byte(config))
+
+	// An empty shared credentials file makes sure that no long-lived keys of the same profile take precedence.
+	sharedCredentialsPath := in.FromTempDir("credentials")
+	out.AddNonSecretFile(sharedCredentialsPath, []byte{})
+
+	out.AddEnvVar("AWS_CONFIG_FILE", configPath)
+	out.AddEnvVar("AWS_SHARED_CREDENTIALS_FILE", sharedCredentialsPath)
+	out.AddEnvVar("AWS_PROFILE", profile)
+	// Makes tools built with v1 of the AWS SDK for Go read the config file.
+	out.AddEnvVar("AWS_SDK_LOAD_CONFIG", "1")
+	return nil
+}
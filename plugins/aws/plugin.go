@@ -19,5 +19,8 @@ func New() schema.Plugin {
 			AWSCLI(),
 			AWSCDKToolkit(),
 		},
+		Settings: []schema.Setting{
+			CredentialsMode(),
+		},
 	}
 }
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"context"
+
+	confighelpers "github.com/99designs/aws-vault/v7/vault"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sso"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+)
+
+// NewSSORoleProvider returns a provider for temporary credentials of an IAM Identity Center (SSO) role. If there is no
+// valid SSO access token in the cache, the user gets prompted to sign in to the AWS access portal in the browser.
+func (m CacheProviderFactory) NewSSORoleProvider(awsConfig *confighelpers.Config) aws.CredentialsProvider {
+	roleCacheKey := getSSORoleCacheKey(awsConfig.SSOStartURL, awsConfig.SSOAccountID, awsConfig.SSORoleName)
+	if m.InCache.Has(roleCacheKey) {
+		return NewStsCacheProvider(roleCacheKey, m.InCache)
+	}
+
+	clientConfig := aws.Config{Region: awsConfig.SSORegion}
+	return &ssoRoleProvider{
+		SSORoleCredentialsProvider: confighelpers.SSORoleCredentialsProvider{
+			OIDCClient:     ssooidc.NewFromConfig(clientConfig),
+			OIDCTokenCache: newOIDCTokenCache(m.InCache, m.OutCache, m.Now),
+			StartURL:       awsConfig.SSOStartURL,
+			SSOClient:      sso.NewFromConfig(clientConfig),
+			AccountID:      awsConfig.SSOAccountID,
+			RoleName:       awsConfig.SSORoleName,
+		},
+		stsCacheWriter: NewSTSCacheWriter(roleCacheKey, m.OutCache),
+	}
+}
+
+// ssoRoleProvider retrieves temporary credentials for an SSO role, using the plugin encrypted cache as caching layer.
+type ssoRoleProvider struct {
+	confighelpers.SSORoleCredentialsProvider
+	stsCacheWriter
+}
+
+func (p *ssoRoleProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	credentials, err := p.SSORoleCredentialsProvider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	err = p.stsCacheWriter.Put(credentials)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	return credentials, nil
+}
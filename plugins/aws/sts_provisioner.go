@@ -20,17 +20,18 @@ const defaultSessionDuration = 15 * time.Minute
 
 type STSProvisioner struct {
 	profileName        string
-	newProviderFactory func(cacheState sdk.CacheState, cacheOps sdk.CacheOperations, fields map[sdk.FieldName]string) STSProviderFactory
+	newProviderFactory func(cacheState sdk.CacheState, cacheOps sdk.CacheOperations, fields map[sdk.FieldName]string, now func() time.Time) STSProviderFactory
 }
 
 func NewSTSProvisioner(profileName string) STSProvisioner {
 	return STSProvisioner{
 		profileName: profileName,
-		newProviderFactory: func(cacheState sdk.CacheState, cacheOps sdk.CacheOperations, fields map[sdk.FieldName]string) STSProviderFactory {
+		newProviderFactory: func(cacheState sdk.CacheState, cacheOps sdk.CacheOperations, fields map[sdk.FieldName]string, now func() time.Time) STSProviderFactory {
 			return &CacheProviderFactory{
 				InCache:    cacheState,
 				OutCache:   cacheOps,
 				ItemFields: fields,
+				Now:        now,
 			}
 		},
 	}
@@ -62,7 +63,15 @@ func (p STSProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, ou
 		return
 	}
 
-	cacheProviderFactory := p.newProviderFactory(in.Cache, out.Cache, in.ItemFields)
+	if roleARN, hasRoleARN := in.ItemFields[fieldname.RoleARN]; hasRoleARN {
+		if awsConfig.HasRole() && awsConfig.RoleARN != roleARN {
+			out.AddError(fmt.Errorf("your local AWS configuration (config file or environment variable) has a different role ARN than the one specified in 1Password"))
+			return
+		}
+		awsConfig.RoleARN = roleARN
+	}
+
+	cacheProviderFactory := p.newProviderFactory(in.Cache, out.Cache, in.ItemFields, in.Now)
 	tempCredentialsProvider, err := GetTemporaryCredentialsProviderForProfile(awsConfig, cacheProviderFactory, in.ItemFields)
 	if err != nil {
 		out.AddError(err)
@@ -75,6 +84,14 @@ func (p STSProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, ou
 		return
 	}
 
+	if in.Settings.Get(credentialsModeSetting) == credentialProcessCredentialsMode {
+		err := provisionCredentialProcess(in, out, profile, tempCredentials, awsConfig.Region)
+		if err != nil {
+			out.AddError(err)
+		}
+		return
+	}
+
 	out.AddEnvVar("AWS_ACCESS_KEY_ID", tempCredentials.AccessKeyID)
 	out.AddEnvVar("AWS_SECRET_ACCESS_KEY", tempCredentials.SecretAccessKey)
 	if tempCredentials.SessionToken != "" {
@@ -102,7 +119,10 @@ func GetTemporaryCredentialsProviderForProfile(awsConfig *confighelpers.Config,
 
 	unsupportedMessage := "%s is not yet supported by the AWS Shell Plugin. If you would like for this feature to be supported, upvote or take on its issue: %s"
 	if awsConfig.HasSSOStartURL() || awsConfig.HasSSOSession() {
-		return nil, fmt.Errorf(unsupportedMessage, "SSO Authentication", "https://github.com/1Password/shell-plugins/issues/210")
+		if awsConfig.SSOStartURL == "" || awsConfig.SSORegion == "" || awsConfig.SSOAccountID == "" || awsConfig.SSORoleName == "" {
+			return nil, fmt.Errorf("SSO authentication requires a start URL, SSO region, account ID and role name to be set in your AWS config file or in the associated item")
+		}
+		return providerFactory.NewSSORoleProvider(awsConfig), nil
 	}
 
 	if awsConfig.HasWebIdentity() {
@@ -120,6 +140,12 @@ func GetTemporaryCredentialsProviderForProfile(awsConfig *confighelpers.Config,
 			return nil, err
 		}
 		sourceCredentialsProvider = sourceProfileProvider
+
+		// When chaining roles, the one-time code was already used to retrieve the credentials of the source
+		// profile. A code can only be used once, and session tokens can't be requested with role credentials.
+		if providesTemporaryCredentials(awsConfig.SourceProfile) {
+			awsConfig.MfaToken = ""
+		}
 	} else {
 		sourceCredentialsProvider = providerFactory.NewAccessKeysProvider()
 	}
@@ -135,10 +161,17 @@ func GetTemporaryCredentialsProviderForProfile(awsConfig *confighelpers.Config,
 	return sourceCredentialsProvider, nil
 }
 
+// providesTemporaryCredentials returns whether the provider for the resolved configuration returns temporary STS
+// credentials, instead of the long-lived access key.
+func providesTemporaryCredentials(awsConfig *confighelpers.Config) bool {
+	return awsConfig.HasRole() || awsConfig.HasSSOStartURL() || awsConfig.HasSSOSession() || (awsConfig.HasMfaSerial() && awsConfig.MfaToken != "")
+}
+
 type STSProviderFactory interface {
 	NewAssumeRoleProvider(awsConfig *confighelpers.Config, sourcedCredentialsProvider aws.CredentialsProvider) aws.CredentialsProvider
 	NewMFASessionTokenProvider(awsConfig *confighelpers.Config, sourcedCredentialsProvider aws.CredentialsProvider) aws.CredentialsProvider
 	NewAccessKeysProvider() aws.CredentialsProvider
+	NewSSORoleProvider(awsConfig *confighelpers.Config) aws.CredentialsProvider
 }
 
 // CacheProviderFactory 's functions return AWS providers that are also handling reading and writing from shell plugin's encrypted cache
@@ -146,6 +179,9 @@ type CacheProviderFactory struct {
 	InCache    sdk.CacheState
 	OutCache   sdk.CacheOperations
 	ItemFields map[sdk.FieldName]string
+
+	// Now returns the current time, to check whether cached tokens have expired. Defaults to time.Now.
+	Now func() time.Time
 }
 
 func (m CacheProviderFactory) NewAssumeRoleProvider(awsConfig *confighelpers.Config, sourcedCredentialsProvider aws.CredentialsProvider) aws.CredentialsProvider {
@@ -250,6 +286,24 @@ Learn how to add an OTP field to your item:
 https://developer.1password.com/docs/cli/shell-plugins/aws/#optional-set-up-multi-factor-authentication`, awsConfig.MfaSerial)
 	}
 
+	for _, ssoSetting := range []struct {
+		field  sdk.FieldName
+		value  *string
+		option string
+	}{
+		{field: fieldname.StartURL, value: &awsConfig.SSOStartURL, option: "SSO start URL"},
+		{field: fieldname.SSORegion, value: &awsConfig.SSORegion, option: "SSO region"},
+		{field: fieldname.AccountID, value: &awsConfig.SSOAccountID, option: "SSO account ID"},
+		{field: fieldname.RoleName, value: &awsConfig.SSORoleName, option: "SSO role name"},
+	} {
+		itemValue, hasItemValue := itemFields[ssoSetting.field]
+		if hasItemValue && *ssoSetting.value != "" && *ssoSetting.value != itemValue {
+			return fmt.Errorf("your local AWS configuration (config file or environment variable) has a different %s than the one specified in 1Password", ssoSetting.option)
+		} else if *ssoSetting.value == "" {
+			*ssoSetting.value = itemValue
+		}
+	}
+
 	if hasRegion && awsConfig.Region != "" && region != awsConfig.Region {
 		return fmt.Errorf("your local AWS configuration (config file or environment variable) has a different default region than the one specified in 1Password")
 	} else if awsConfig.Region == "" {
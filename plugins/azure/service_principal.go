@@ -0,0 +1,259 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func ServicePrincipal() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.ServicePrincipal,
+		DocsURL:       sdk.URL("https://learn.microsoft.com/cli/azure/azure-cli-sp-tutorial-1"),
+		ManagementURL: sdk.URL("https://portal.azure.com/#view/Microsoft_AAD_RegisteredApps/ApplicationsListBlade"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.TenantID,
+				MarkdownDescription: "The ID of the Microsoft Entra tenant the service principal belongs to.",
+				Composition:         uuidComposition,
+			},
+			{
+				Name:                fieldname.ClientID,
+				MarkdownDescription: "The application (client) ID of the service principal.",
+				Composition:         uuidComposition,
+			},
+			{
+				Name:                fieldname.ClientSecret,
+				MarkdownDescription: "The client secret used to authenticate as the service principal. Either this or a certificate is required.",
+				Secret:              true,
+				Optional:            true,
+				Composition: &schema.ValueComposition{
+					Length: 40,
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+						Symbols:   true,
+					},
+				},
+			},
+			{
+				Name:                fieldname.Certificate,
+				MarkdownDescription: "The PEM-encoded certificate and private key used to authenticate as the service principal, as an alternative to a client secret.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.SubscriptionID,
+				MarkdownDescription: "The ID of the subscription to use by default.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: servicePrincipalProvisioner{},
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryServicePrincipalEntries(),
+			TryAzureProfile(),
+			TryMSALTokenCache(),
+		),
+	}
+}
+
+var uuidComposition = &schema.ValueComposition{
+	Length: 36,
+	Charset: schema.Charset{
+		Lowercase: true,
+		Digits:    true,
+		Specific:  []rune{'-'},
+	},
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"AZURE_TENANT_ID":       fieldname.TenantID,
+	"AZURE_CLIENT_ID":       fieldname.ClientID,
+	"AZURE_CLIENT_SECRET":   fieldname.ClientSecret,
+	"AZURE_SUBSCRIPTION_ID": fieldname.SubscriptionID,
+}
+
+// azureCLIClientID is the ID of the Azure CLI's own public client application, which is used for interactive user
+// logins. Tokens for that client don't belong to a service principal.
+const azureCLIClientID = "04b07795-8ddb-461a-bbee-02f9e1bf7b46"
+
+// servicePrincipalProvisioner provisions the service principal as the AZURE_* environment variables used by the
+// Azure SDKs' environment credential, writing the certificate to a temp file if no client secret is set.
+type servicePrincipalProvisioner struct{}
+
+func (p servicePrincipalProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	out.AddEnvVar("AZURE_TENANT_ID", in.ItemFields[fieldname.TenantID])
+	out.AddEnvVar("AZURE_CLIENT_ID", in.ItemFields[fieldname.ClientID])
+
+	if secret, ok := in.ItemFields[fieldname.ClientSecret]; ok && secret != "" {
+		out.AddEnvVar("AZURE_CLIENT_SECRET", secret)
+	} else if certificate, ok := in.ItemFields[fieldname.Certificate]; ok && certificate != "" {
+		path := in.FromTempDir("certificate.pem")
+		out.AddSecretFile(path, []byte(certificate))
+		out.AddEnvVar("AZURE_CLIENT_CERTIFICATE_PATH", path)
+	} else {
+		out.AddError(errors.New("a client secret or certificate is required to authenticate as a service principal"))
+		return
+	}
+
+	if subscriptionID, ok := in.ItemFields[fieldname.SubscriptionID]; ok && subscriptionID != "" {
+		out.AddEnvVar("AZURE_SUBSCRIPTION_ID", subscriptionID)
+	}
+}
+
+func (p servicePrincipalProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p servicePrincipalProvisioner) Description() string {
+	return "Provision environment variables: AZURE_TENANT_ID, AZURE_CLIENT_ID, and AZURE_CLIENT_SECRET or AZURE_CLIENT_CERTIFICATE_PATH"
+}
+
+// TryServicePrincipalEntries imports the service principals that were logged in with 'az login --service-principal'.
+func TryServicePrincipalEntries() sdk.Importer {
+	return importer.TryFile("~/.azure/service_principal_entries.json", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var entries []ServicePrincipalEntry
+		if err := contents.ToJSON(&entries); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, entry := range entries {
+			if entry.ClientID == "" || entry.Tenant == "" {
+				continue
+			}
+
+			fields := map[sdk.FieldName]string{
+				fieldname.ClientID: entry.ClientID,
+				fieldname.TenantID: entry.Tenant,
+			}
+			if entry.ClientSecret != "" {
+				fields[fieldname.ClientSecret] = entry.ClientSecret
+			} else if entry.Certificate != "" {
+				certificate, err := os.ReadFile(resolvePath(in, entry.Certificate))
+				if err != nil {
+					out.AddError(err)
+					continue
+				}
+				fields[fieldname.Certificate] = string(certificate)
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: fields,
+			})
+		}
+	})
+}
+
+// resolvePath resolves the certificate paths in the service principal entries, which are absolute or relative to the
+// home dir.
+func resolvePath(in sdk.ImportInput, path string) string {
+	if strings.HasPrefix(path, "~/") {
+		return in.FromHomeDir(strings.TrimPrefix(path, "~/"))
+	}
+	if strings.HasPrefix(path, "/") {
+		return in.FromRootDir(path)
+	}
+	return in.FromHomeDir(path)
+}
+
+type ServicePrincipalEntry struct {
+	ClientID     string `json:"client_id"`
+	Tenant       string `json:"tenant"`
+	ClientSecret string `json:"client_secret"`
+	Certificate  string `json:"certificate"`
+}
+
+// TryAzureProfile imports the tenant, client and subscription IDs of the service principal subscriptions in the
+// Azure CLI profile. The secret has to be added manually, because the profile doesn't contain it.
+func TryAzureProfile() sdk.Importer {
+	return importer.TryFile("~/.azure/azureProfile.json", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var profile AzureProfile
+		// The Azure CLI writes the profile with a UTF-8 byte order mark.
+		if err := importer.FileContents(bytes.TrimPrefix(contents, []byte("\xef\xbb\xbf"))).ToJSON(&profile); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, subscription := range profile.Subscriptions {
+			if subscription.User.Type != "servicePrincipal" {
+				continue
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.ClientID:       subscription.User.Name,
+					fieldname.TenantID:       subscription.TenantID,
+					fieldname.SubscriptionID: subscription.ID,
+				},
+				NameHint: importer.SanitizeNameHint(subscription.Name),
+			})
+		}
+	})
+}
+
+type AzureProfile struct {
+	Subscriptions []AzureSubscription `json:"subscriptions"`
+}
+
+type AzureSubscription struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	TenantID string `json:"tenantId"`
+	User     struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"user"`
+}
+
+// TryMSALTokenCache imports the tenant and client IDs of the service principals that have cached tokens in the
+// MSAL token cache of the Azure CLI.
+func TryMSALTokenCache() sdk.Importer {
+	return importer.TryFile("~/.azure/msal_token_cache.json", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var cache MSALTokenCache
+		if err := contents.ToJSON(&cache); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		seen := map[string]bool{}
+		for _, token := range cache.AccessToken {
+			// Service principal tokens are acquired without a user account.
+			if token.HomeAccountID != "" || token.ClientID == "" || token.ClientID == azureCLIClientID || token.Realm == "" {
+				continue
+			}
+			key := token.Realm + "/" + token.ClientID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.ClientID: token.ClientID,
+					fieldname.TenantID: token.Realm,
+				},
+			})
+		}
+	})
+}
+
+type MSALTokenCache struct {
+	AccessToken map[string]MSALAccessToken `json:"AccessToken"`
+}
+
+type MSALAccessToken struct {
+	HomeAccountID string `json:"home_account_id"`
+	ClientID      string `json:"client_id"`
+	Realm         string `json:"realm"`
+}
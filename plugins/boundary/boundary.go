@@ -0,0 +1,29 @@
+package boundary
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func BoundaryCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Boundary CLI",
+		Runs:    []string{"boundary"},
+		DocsURL: sdk.URL("https://developer.hashicorp.com/boundary/docs/commands"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("dev"),
+			needsauth.NotWhenContainsArgs("server"),
+			needsauth.NotWhenContainsArgs("config"),
+			needsauth.NotWhenContainsArgs("database"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.Credentials,
+			},
+		},
+	}
+}
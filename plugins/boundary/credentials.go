@@ -0,0 +1,69 @@
+package boundary
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func Credentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://developer.hashicorp.com/boundary/docs/concepts/domain-model/auth-methods"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Address,
+				MarkdownDescription: "The address of the Boundary controller, e.g. 'https://boundary.acme.com:9200'. Defaults to 'http://127.0.0.1:9200'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "An auth token, e.g. one obtained through 'boundary authenticate oidc'.",
+				Secret:              true,
+				Optional:            true,
+				Composition: &schema.ValueComposition{
+					Prefix: "at_",
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'_'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.AuthMethodID,
+				MarkdownDescription: "The ID of the auth method to authenticate with, e.g. 'ampw_1234567890'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The login name to use with 'boundary authenticate password'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Password,
+				MarkdownDescription: "The password to use with 'boundary authenticate password'.",
+				Secret:              true,
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryBoundaryKeyring(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"BOUNDARY_ADDR":                             fieldname.Address,
+	"BOUNDARY_TOKEN":                            fieldname.Token,
+	"BOUNDARY_AUTH_METHOD_ID":                   fieldname.AuthMethodID,
+	"BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME": fieldname.Username,
+	"BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD":   fieldname.Password,
+}
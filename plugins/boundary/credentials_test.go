@@ -0,0 +1,97 @@
+package boundary
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/99designs/keyring"
+)
+
+func TestCredentialsProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, Credentials().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"auth token": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Address: "https://boundary.acme.com:9200",
+				fieldname.Token:   "at_u4Xq9RcKpM_s12wXaBcDeFgHiJkLmNoPqRsTuVwXyZ3EXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"BOUNDARY_ADDR":  "https://boundary.acme.com:9200",
+					"BOUNDARY_TOKEN": "at_u4Xq9RcKpM_s12wXaBcDeFgHiJkLmNoPqRsTuVwXyZ3EXAMPLE",
+				},
+			},
+		},
+		"password auth method": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Address:      "https://boundary.acme.com:9200",
+				fieldname.AuthMethodID: "ampw_1234567890",
+				fieldname.Username:     "wendy",
+				fieldname.Password:     "Xv2Rk7Tn1Wb6Yc3Jd8Hf5EXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"BOUNDARY_ADDR":                             "https://boundary.acme.com:9200",
+					"BOUNDARY_AUTH_METHOD_ID":                   "ampw_1234567890",
+					"BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME": "wendy",
+					"BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD":   "Xv2Rk7Tn1Wb6Yc3Jd8Hf5EXAMPLE",
+				},
+			},
+		},
+	})
+}
+
+func TestCredentialsImporter(t *testing.T) {
+	storedToken := base64.RawStdEncoding.EncodeToString([]byte(`{"id": "at_u4Xq9RcKpM", "token": "at_u4Xq9RcKpM_s12wXaBcDeFgHiJkLmNoPqRsTuVwXyZ3EXAMPLE", "auth_method_id": "amoidc_9876543210", "user_id": "u_1234567890"}`))
+	kr := keyring.NewArrayKeyring([]keyring.Item{
+		{Key: "default", Data: []byte(storedToken)},
+		{Key: "staging", Data: []byte("not a stored auth token")},
+	})
+
+	defer func(backends func() []keyring.BackendType, open func(keyring.Config) (keyring.Keyring, error)) {
+		availableBackends = backends
+		openKeyring = open
+	}(availableBackends, openKeyring)
+	availableBackends = func() []keyring.BackendType {
+		return []keyring.BackendType{keyring.KeychainBackend}
+	}
+	openKeyring = func(config keyring.Config) (keyring.Keyring, error) {
+		return kr, nil
+	}
+
+	plugintest.TestImporter(t, Credentials().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"BOUNDARY_ADDR":  "https://boundary.acme.com:9200",
+				"BOUNDARY_TOKEN": "at_u4Xq9RcKpM_s12wXaBcDeFgHiJkLmNoPqRsTuVwXyZ3EXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Address: "https://boundary.acme.com:9200",
+						fieldname.Token:   "at_u4Xq9RcKpM_s12wXaBcDeFgHiJkLmNoPqRsTuVwXyZ3EXAMPLE",
+					},
+				},
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Address:      "https://boundary.acme.com:9200",
+						fieldname.Token:        "at_u4Xq9RcKpM_s12wXaBcDeFgHiJkLmNoPqRsTuVwXyZ3EXAMPLE",
+						fieldname.AuthMethodID: "amoidc_9876543210",
+					},
+				},
+			},
+		},
+		"keyring": {
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token:        "at_u4Xq9RcKpM_s12wXaBcDeFgHiJkLmNoPqRsTuVwXyZ3EXAMPLE",
+						fieldname.AuthMethodID: "amoidc_9876543210",
+					},
+				},
+			},
+		},
+	})
+}
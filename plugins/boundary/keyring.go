@@ -0,0 +1,119 @@
+package boundary
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/99designs/keyring"
+)
+
+// Name of the keyring collection in which the Boundary CLI stores the auth tokens obtained with 'boundary authenticate'.
+// Details can be found at https://github.com/hashicorp/boundary/blob/main/internal/cmd/base/command.go
+const storeCollection = "HashiCorp Boundary Auth Token"
+
+// Backend types that the Boundary CLI stores auth tokens in, with their user-friendly display names.
+var backendNames = map[keyring.BackendType]string{
+	keyring.KeychainBackend:      "macOS Keychain",
+	keyring.WinCredBackend:       "Windows Credential Manager",
+	keyring.SecretServiceBackend: "Secret Service: GNOME Keyring, KWallet",
+	keyring.PassBackend:          "Pass",
+}
+
+// Keyring config values matching the ones used by the Boundary CLI for each backend.
+var keyringConfig = keyring.Config{
+	ServiceName:             storeCollection,
+	WinCredPrefix:           storeCollection,
+	LibSecretCollectionName: "login",
+	PassPrefix:              "HashiCorp_Boundary",
+}
+
+// Overridden in tests.
+var (
+	availableBackends = keyring.AvailableBackends
+	openKeyring       = keyring.Open
+)
+
+// storedAuthToken holds the fields of the auth token the Boundary CLI stores in the keyring.
+type storedAuthToken struct {
+	Token        string `json:"token"`
+	AuthMethodID string `json:"auth_method_id"`
+}
+
+// TryBoundaryKeyring imports the auth tokens that the Boundary CLI stored in the system keyring after
+// 'boundary authenticate', one candidate per token name.
+func TryBoundaryKeyring() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		for _, backendType := range availableBackends() {
+			displayName, ok := backendNames[backendType]
+			if !ok {
+				continue
+			}
+			attempt := out.NewAttempt(importer.SourceOther(displayName, ""))
+
+			config := keyringConfig
+			config.AllowedBackends = []keyring.BackendType{backendType}
+			kr, err := openKeyring(config)
+			if err != nil {
+				attempt.AddError(err)
+				continue
+			}
+
+			tokenNames, err := kr.Keys()
+			if err != nil {
+				attempt.AddError(err)
+				continue
+			}
+
+			for _, tokenName := range tokenNames {
+				item, err := kr.Get(tokenName)
+				if err != nil {
+					attempt.AddError(err)
+					continue
+				}
+
+				authToken, ok := parseStoredAuthToken(item.Data)
+				if !ok {
+					continue
+				}
+
+				fields := map[sdk.FieldName]string{
+					fieldname.Token: authToken.Token,
+				}
+				if authToken.AuthMethodID != "" {
+					fields[fieldname.AuthMethodID] = authToken.AuthMethodID
+				}
+				// The address is not stored alongside the token.
+				if address := os.Getenv("BOUNDARY_ADDR"); address != "" {
+					fields[fieldname.Address] = address
+				}
+
+				attempt.AddCandidate(sdk.ImportCandidate{
+					Fields:   fields,
+					NameHint: importer.SanitizeNameHint(tokenName),
+				})
+			}
+		}
+	}
+}
+
+// parseStoredAuthToken decodes the base64-encoded JSON the Boundary CLI stores in the keyring.
+func parseStoredAuthToken(data []byte) (storedAuthToken, bool) {
+	decoded, err := base64.RawStdEncoding.DecodeString(string(data))
+	if err != nil {
+		decoded, err = base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return storedAuthToken{}, false
+		}
+	}
+
+	var authToken storedAuthToken
+	if err := json.Unmarshal(decoded, &authToken); err != nil || authToken.Token == "" {
+		return storedAuthToken{}, false
+	}
+	return authToken, true
+}
@@ -0,0 +1,22 @@
+package boundary
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "boundary",
+		Platform: schema.PlatformInfo{
+			Name:     "HashiCorp Boundary",
+			Homepage: sdk.URL("https://www.boundaryproject.io"),
+		},
+		Credentials: []schema.CredentialType{
+			Credentials(),
+		},
+		Executables: []schema.Executable{
+			BoundaryCLI(),
+		},
+	}
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
-	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
@@ -19,11 +18,16 @@ func APIToken() schema.CredentialType {
 		Fields: []schema.CredentialField{
 			{
 				Name:                fieldname.Token,
-				MarkdownDescription: "Token used to authenticate to crates.io.",
+				MarkdownDescription: "Token used to authenticate to crates.io or an alternate registry.",
 				Secret:              true,
 			},
+			{
+				Name:                fieldname.Registry,
+				MarkdownDescription: "The name of the alternate registry the token is for, as configured in the `[registries]` table of the Cargo config. Leave empty for crates.io.",
+				Optional:            true,
+			},
 		},
-		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		DefaultProvisioner: cargoProvisioner{},
 		Importer: importer.TryAll(
 			importer.TryEnvVarPair(defaultEnvVarMapping),
 			TryCargoConfigFile(),
@@ -42,17 +46,32 @@ func TryCargoConfigFile() sdk.Importer {
 			return
 		}
 
-		out.AddCandidate(sdk.ImportCandidate{
-			Fields: map[sdk.FieldName]string{
-				fieldname.Token: config.Registry.Token,
-			},
-		})
+		if config.Registry.Token != "" {
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.Token: config.Registry.Token,
+				},
+			})
+		}
 
+		for name, registry := range config.Registries {
+			if registry.Token == "" {
+				continue
+			}
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.Token:    registry.Token,
+					fieldname.Registry: name,
+				},
+				NameHint: importer.SanitizeNameHint(name),
+			})
+		}
 	})
 }
 
 type Config struct {
-	Registry ConfigRegistry `toml:"registry"`
+	Registry   ConfigRegistry            `toml:"registry"`
+	Registries map[string]ConfigRegistry `toml:"registries"`
 }
 
 type ConfigRegistry struct {
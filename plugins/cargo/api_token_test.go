@@ -20,6 +20,17 @@ func TestAPITokenProvisioner(t *testing.T) {
 				},
 			},
 		},
+		"alternate registry": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Token:    "9xAQsMIO2UubpsgD2eUOKqXEXAMPLE3",
+				fieldname.Registry: "acme-internal",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"CARGO_REGISTRIES_ACME_INTERNAL_TOKEN": "9xAQsMIO2UubpsgD2eUOKqXEXAMPLE3",
+				},
+			},
+		},
 	})
 }
 
@@ -47,6 +58,20 @@ func TestAPITokenImporter(t *testing.T) {
 						fieldname.Token: "9xAQsMIO2UubpsgD2eUOKqXEXAMPLE",
 					},
 				},
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token:    "9xAQsMIO2UubpsgD2eUOKqXEXAMPLE2",
+						fieldname.Registry: "reg1",
+					},
+					NameHint: "reg1",
+				},
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token:    "9xAQsMIO2UubpsgD2eUOKqXEXAMPLE3",
+						fieldname.Registry: "acme-internal",
+					},
+					NameHint: "acme-internal",
+				},
 			},
 		},
 	})
@@ -0,0 +1,35 @@
+package cargo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// cargoProvisioner provisions the token as CARGO_REGISTRY_TOKEN for crates.io, or as
+// CARGO_REGISTRIES_<NAME>_TOKEN for an alternate registry.
+type cargoProvisioner struct {
+}
+
+func (p cargoProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	out.AddEnvVar(tokenEnvVarName(in.ItemFields[fieldname.Registry]), in.ItemFields[fieldname.Token])
+}
+
+func (p cargoProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p cargoProvisioner) Description() string {
+	return "Provision environment variable CARGO_REGISTRY_TOKEN, or CARGO_REGISTRIES_<NAME>_TOKEN for alternate registries"
+}
+
+// tokenEnvVarName returns the environment variable Cargo reads the token for the registry from. Cargo expects
+// the registry name in uppercase, with dashes replaced by underscores.
+func tokenEnvVarName(registry string) string {
+	if registry == "" || registry == "crates-io" {
+		return "CARGO_REGISTRY_TOKEN"
+	}
+	return "CARGO_REGISTRIES_" + strings.ToUpper(strings.ReplaceAll(registry, "-", "_")) + "_TOKEN"
+}
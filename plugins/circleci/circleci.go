@@ -1,6 +1,8 @@
 package circleci
 
 import (
+	"regexp"
+
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/needsauth"
 	"github.com/1Password/shell-plugins/sdk/schema"
@@ -16,6 +18,16 @@ func CircleCICLI() schema.Executable {
 			needsauth.NotForHelpOrVersion(),
 			needsauth.NotWithoutArgs(),
 			needsauth.NotForExactArgs("config"),
+			// These commands only work on local files or manage the CLI itself.
+			needsauth.Not(needsauth.IfAll(
+				needsauth.ForCommand("config", "validate"),
+				needsauth.IfArgsMatch(regexp.MustCompile(`^--local$`)),
+			)),
+			needsauth.Not(needsauth.ForCommand("config", "pack")),
+			needsauth.Not(needsauth.ForCommand("orb", "pack")),
+			needsauth.Not(needsauth.ForCommand("setup")),
+			needsauth.Not(needsauth.ForCommand("completion")),
+			needsauth.Not(needsauth.ForCommand("update")),
 		),
 		Uses: []schema.CredentialUsage{
 			{
@@ -0,0 +1,48 @@
+package circleci
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestCircleCICLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, CircleCICLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"no for --help": {
+			Args:              []string{"--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for local config validation": {
+			Args:              []string{"config", "validate", ".circleci/config.yml", "--local"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for config pack": {
+			Args:              []string{"config", "pack", "src"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for orb pack": {
+			Args:              []string{"orb", "pack", "src"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for setup": {
+			Args:              []string{"setup"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for update": {
+			Args:              []string{"update", "check"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for config validation": {
+			Args:              []string{"config", "validate"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for orb publish": {
+			Args:              []string{"orb", "publish", "orb.yml", "acme/deploy@1.0.0"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for context list": {
+			Args:              []string{"context", "list", "github", "acme"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// githubHost is the host of which the GitHub OAuth token is used.
+const githubHost = "github.com"
+
+// Auth holds the parts of Composer's auth.json, or the COMPOSER_AUTH env var, that this plugin supports.
+// The full format is described at https://getcomposer.org/doc/articles/authentication-for-private-packages.md
+type Auth struct {
+	GitHubOAuth map[string]string    `json:"github-oauth,omitempty"`
+	HTTPBasic   map[string]BasicAuth `json:"http-basic,omitempty"`
+}
+
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// composerProvisioner provisions the GitHub token and HTTP basic auth credentials as a COMPOSER_AUTH JSON object.
+type composerProvisioner struct {
+}
+
+func (p composerProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	var auth Auth
+	if token, ok := in.ItemFields[fieldname.Token]; ok {
+		auth.GitHubOAuth = map[string]string{githubHost: token}
+	}
+
+	host, hasHost := in.ItemFields[fieldname.Host]
+	username, hasUsername := in.ItemFields[fieldname.Username]
+	password, hasPassword := in.ItemFields[fieldname.Password]
+	if hasHost && hasUsername && hasPassword {
+		auth.HTTPBasic = map[string]BasicAuth{host: {Username: username, Password: password}}
+	}
+
+	if auth.GitHubOAuth == nil && auth.HTTPBasic == nil {
+		out.AddError(errors.New("either a GitHub token, or a host, username and password are required to authenticate Composer"))
+		return
+	}
+
+	contents, err := json.Marshal(auth)
+	if err != nil {
+		out.AddError(err)
+		return
+	}
+	out.AddEnvVar("COMPOSER_AUTH", string(contents))
+}
+
+func (p composerProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p composerProvisioner) Description() string {
+	return "Provision environment variable COMPOSER_AUTH with the GitHub token and HTTP basic auth credentials"
+}
+
+// TryComposerAuthEnvVar imports the credentials from the COMPOSER_AUTH env var.
+func TryComposerAuthEnvVar() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		value := os.Getenv("COMPOSER_AUTH")
+		if value == "" {
+			return
+		}
+		addAuthCandidates(out.NewAttempt(importer.SourceEnvVars("COMPOSER_AUTH")), []byte(value))
+	}
+}
+
+// TryComposerAuthFile imports the credentials from the global auth.json at path.
+func TryComposerAuthFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		addAuthCandidates(out, contents)
+	})
+}
+
+// addAuthCandidates adds a candidate for every host with HTTP basic auth credentials, along with the GitHub
+// token if there is one. If there are no HTTP basic auth credentials, a candidate with just the GitHub token is added.
+func addAuthCandidates(out *sdk.ImportAttempt, contents []byte) {
+	var auth Auth
+	if err := json.Unmarshal(contents, &auth); err != nil {
+		out.AddError(err)
+		return
+	}
+
+	token := auth.GitHubOAuth[githubHost]
+	hasBasicAuth := false
+	for host, basicAuth := range auth.HTTPBasic {
+		if basicAuth.Username == "" || basicAuth.Password == "" {
+			continue
+		}
+		hasBasicAuth = true
+
+		fields := map[sdk.FieldName]string{
+			fieldname.Host:     host,
+			fieldname.Username: basicAuth.Username,
+			fieldname.Password: basicAuth.Password,
+		}
+		if token != "" {
+			fields[fieldname.Token] = token
+		}
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields:   fields,
+			NameHint: importer.SanitizeNameHint(host),
+		})
+	}
+
+	if !hasBasicAuth && token != "" {
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.Token: token,
+			},
+		})
+	}
+}
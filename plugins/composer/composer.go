@@ -0,0 +1,35 @@
+package composer
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func ComposerCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Composer",
+		Runs:    []string{"composer"},
+		DocsURL: sdk.URL("https://getcomposer.org/doc/03-cli.md"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.IfAny(
+				needsauth.ForCommand("install"),
+				needsauth.ForCommand("i"),
+				needsauth.ForCommand("update"),
+				needsauth.ForCommand("u"),
+				needsauth.ForCommand("upgrade"),
+				needsauth.ForCommand("require"),
+				needsauth.ForCommand("create-project"),
+				needsauth.ForCommand("outdated"),
+				needsauth.ForCommand("reinstall"),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.Credentials,
+			},
+		},
+	}
+}
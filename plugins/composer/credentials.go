@@ -0,0 +1,47 @@
+package composer
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func Credentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://getcomposer.org/doc/articles/authentication-for-private-packages.md"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "GitHub OAuth token or personal access token used to download packages from GitHub.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Host,
+				MarkdownDescription: "The hostname of the private repository that uses HTTP basic auth, e.g. 'repo.packagist.com'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The username used to authenticate to the private repository.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Password,
+				MarkdownDescription: "The password or token used to authenticate to the private repository.",
+				Secret:              true,
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: composerProvisioner{},
+		Importer: importer.TryAll(
+			TryComposerAuthEnvVar(),
+			TryComposerAuthFile("~/.composer/auth.json"),
+			importer.LinuxOnly(TryComposerAuthFile("~/.config/composer/auth.json")),
+		),
+	}
+}
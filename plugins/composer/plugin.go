@@ -0,0 +1,22 @@
+package composer
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "composer",
+		Platform: schema.PlatformInfo{
+			Name:     "Composer",
+			Homepage: sdk.URL("https://getcomposer.org"),
+		},
+		Credentials: []schema.CredentialType{
+			Credentials(),
+		},
+		Executables: []schema.Executable{
+			ComposerCLI(),
+		},
+	}
+}
@@ -0,0 +1,134 @@
+package containerregistry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// dockerHubAuthKey is the key under which Docker Hub credentials are stored. crane only looks up Docker Hub
+// credentials under this key, while skopeo normalizes it to 'docker.io'.
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// AuthFile is the Docker-style config file in which crane and skopeo look up registry credentials.
+type AuthFile struct {
+	Auths map[string]Auth `json:"auths"`
+}
+
+type Auth struct {
+	Auth string `json:"auth"`
+}
+
+func authFile(in sdk.ProvisionInput) ([]byte, error) {
+	auth := in.ItemFields[fieldname.Username] + ":" + in.ItemFields[fieldname.Token]
+	file := AuthFile{
+		Auths: map[string]Auth{
+			authKey(in.ItemFields[fieldname.Host]): {
+				Auth: base64.StdEncoding.EncodeToString([]byte(auth)),
+			},
+		},
+	}
+	return json.MarshalIndent(file, "", "\t")
+}
+
+// authKey returns the key in the auth file to store the credentials for the registry host under.
+func authKey(host string) string {
+	host = normalizeHost(host)
+	if host == "docker.io" {
+		return dockerHubAuthKey
+	}
+	return host
+}
+
+// normalizeHost strips the scheme and path that are sometimes included in the registry address, and maps
+// the different Docker Hub hostnames to 'docker.io'.
+func normalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return host
+}
+
+// TryRegistryAuthFileEnvVar imports the registry credentials from the auth file set in REGISTRY_AUTH_FILE.
+func TryRegistryAuthFileEnvVar() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		tryAuthFileAt(os.Getenv("REGISTRY_AUTH_FILE"), out)
+	}
+}
+
+// TryContainersAuthFileInRuntimeDir imports the registry credentials that podman and skopeo store by default
+// on Linux, in $XDG_RUNTIME_DIR/containers/auth.json.
+func TryContainersAuthFileInRuntimeDir() sdk.Importer {
+	return importer.LinuxOnly(func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+		if runtimeDir == "" {
+			return
+		}
+		tryAuthFileAt(filepath.Join(runtimeDir, "containers", "auth.json"), out)
+	})
+}
+
+func tryAuthFileAt(path string, out *sdk.ImportOutput) {
+	if path == "" {
+		return
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			out.NewAttempt(importer.SourceFile(path)).AddError(err)
+		}
+		return
+	}
+	addAuthFileCandidates(out.NewAttempt(importer.SourceFile(path)), contents)
+}
+
+// TryAuthFile imports the registry credentials from the auth file at path.
+func TryAuthFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		addAuthFileCandidates(out, contents)
+	})
+}
+
+// addAuthFileCandidates adds a candidate for every registry of which the credentials are stored in the auth file
+// itself. Registries of which the credentials are kept in a credential helper get skipped.
+func addAuthFileCandidates(out *sdk.ImportAttempt, contents []byte) {
+	var file AuthFile
+	if err := json.Unmarshal(contents, &file); err != nil {
+		out.AddError(err)
+		return
+	}
+
+	for key, registryAuth := range file.Auths {
+		decoded, err := base64.StdEncoding.DecodeString(registryAuth.Auth)
+		if err != nil {
+			continue
+		}
+		username, token, found := strings.Cut(string(decoded), ":")
+		if !found || username == "" || token == "" {
+			continue
+		}
+
+		host := normalizeHost(key)
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.Host:     host,
+				fieldname.Username: username,
+				fieldname.Token:    token,
+			},
+			NameHint: importer.SanitizeNameHint(host),
+		})
+	}
+}
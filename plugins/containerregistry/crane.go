@@ -0,0 +1,29 @@
+package containerregistry
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func CraneCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "crane",
+		Runs:    []string{"crane"},
+		DocsURL: sdk.URL("https://github.com/google/go-containerregistry/blob/main/cmd/crane/doc/crane.md"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("auth", "login"),
+			needsauth.NotWhenContainsArgs("auth", "logout"),
+			needsauth.NotWhenContainsArgs("completion"),
+			needsauth.NotWhenContainsArgs("version"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.RegistryCredentials,
+			},
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package containerregistry
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestCraneNeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, CraneCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for copy": {
+			Args:              []string{"copy", "ghcr.io/acme/web:1.0", "registry.acme.com/web:1.0"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for auth get": {
+			Args:              []string{"auth", "get", "ghcr.io"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for auth login": {
+			Args:              []string{"auth", "login", "ghcr.io", "-u", "wendy", "--password-stdin"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for version": {
+			Args:              []string{"version"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
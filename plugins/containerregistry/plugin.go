@@ -0,0 +1,23 @@
+package containerregistry
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "containerregistry",
+		Platform: schema.PlatformInfo{
+			Name:     "Container Registry",
+			Homepage: sdk.URL("https://opencontainers.org"),
+		},
+		Credentials: []schema.CredentialType{
+			RegistryCredentials(),
+		},
+		Executables: []schema.Executable{
+			CraneCLI(),
+			SkopeoCLI(),
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package containerregistry
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func RegistryCredentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.RegistryCredentials,
+		DocsURL:       sdk.URL("https://github.com/containers/image/blob/main/docs/containers-auth.json.5.md"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Host,
+				MarkdownDescription: "The hostname of the container registry, e.g. 'ghcr.io' or 'docker.io'.",
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The username used to authenticate to the registry.",
+			},
+			{
+				Name:                fieldname.Token,
+				AlternativeNames:    []string{"Password"},
+				MarkdownDescription: "The access token or password used to authenticate to the registry.",
+				Secret:              true,
+			},
+		},
+		// crane reads the Docker config in DOCKER_CONFIG and skopeo reads the auth file in REGISTRY_AUTH_FILE.
+		// Both use the same format, so a single file serves both.
+		DefaultProvisioner: provision.TempFile(authFile,
+			provision.Filename("config.json"),
+			provision.SetPathAsEnvVar("REGISTRY_AUTH_FILE"),
+			provision.SetOutputDirAsEnvVar("DOCKER_CONFIG"),
+		),
+		Importer: importer.TryAll(
+			TryRegistryAuthFileEnvVar(),
+			TryContainersAuthFileInRuntimeDir(),
+			TryAuthFile("~/.config/containers/auth.json"),
+			TryAuthFile("~/.docker/config.json"),
+		),
+	}
+}
@@ -0,0 +1,31 @@
+package containerregistry
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func SkopeoCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Skopeo",
+		Runs:    []string{"skopeo"},
+		DocsURL: sdk.URL("https://github.com/containers/skopeo/blob/main/docs/skopeo.1.md"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("login"),
+			needsauth.NotWhenContainsArgs("logout"),
+			needsauth.NotWhenContainsArgs("manifest-digest"),
+			needsauth.NotWhenContainsArgs("generate-sigstore-key"),
+			needsauth.NotWhenContainsArgs("standalone-sign"),
+			needsauth.NotWhenContainsArgs("standalone-verify"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.RegistryCredentials,
+			},
+		},
+	}
+}
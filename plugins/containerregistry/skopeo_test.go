@@ -0,0 +1,28 @@
+package containerregistry
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestSkopeoNeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, SkopeoCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for copy": {
+			Args:              []string{"copy", "docker://ghcr.io/acme/web:1.0", "docker://registry.acme.com/web:1.0"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for inspect": {
+			Args:              []string{"inspect", "docker://ghcr.io/acme/web:1.0"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for login": {
+			Args:              []string{"login", "ghcr.io"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for manifest-digest": {
+			Args:              []string{"manifest-digest", "manifest.json"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
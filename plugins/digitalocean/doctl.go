@@ -16,6 +16,10 @@ func DigitalOceanCLI() schema.Executable {
 			needsauth.NotForHelpOrVersion(),
 			needsauth.NotWithoutArgs(),
 		),
+		ProfileHint: &schema.ProfileHint{
+			Flags:   []string{"--context"},
+			EnvVars: []string{"DIGITALOCEAN_CONTEXT"},
+		},
 		Uses: []schema.CredentialUsage{
 			{
 				Name: credname.PersonalAccessToken,
@@ -2,10 +2,10 @@ package digitalocean
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
-	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
@@ -30,19 +30,50 @@ func PersonalAccessToken() schema.CredentialType {
 					},
 				},
 			},
+			{
+				Name:                fieldname.Context,
+				MarkdownDescription: "The doctl auth context this token belongs to. If set, the token is only used for commands that target this context, e.g. with `--context`.",
+				Optional:            true,
+			},
 		},
-		DefaultProvisioner: provision.EnvVars(map[string]sdk.FieldName{
-			"DIGITALOCEAN_ACCESS_TOKEN": fieldname.Token,
-		}),
+		DefaultProvisioner: contextProvisioner{},
 		Importer: importer.TryAll(
 			importer.TryAllEnvVars(fieldname.Token, "DIGITALOCEAN_ACCESS_TOKEN"),
 			importer.MacOnly(
 				TryDigitalOceanConfigFile("~/Library/Application Support/doctl/config.yaml"),
 			),
+			importer.LinuxOnly(
+				TryDigitalOceanConfigFile("~/.config/doctl/config.yaml"),
+			),
 		),
 	}
 }
 
+// contextProvisioner provisions the token of the item, after checking that the item belongs to the auth context
+// that the command targets. This allows users with multiple doctl contexts to map each context to its own item.
+type contextProvisioner struct{}
+
+func (p contextProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	itemContext := in.ItemFields[fieldname.Context]
+	if itemContext != "" && in.Profile != "" && itemContext != in.Profile {
+		out.AddError(fmt.Errorf("the command targets doctl context '%s', but the configured 1Password item is for context '%s'", in.Profile, itemContext))
+		return
+	}
+
+	out.AddEnvVar("DIGITALOCEAN_ACCESS_TOKEN", in.ItemFields[fieldname.Token])
+	if itemContext != "" {
+		out.AddEnvVar("DIGITALOCEAN_CONTEXT", itemContext)
+	}
+}
+
+func (p contextProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p contextProvisioner) Description() string {
+	return "Provision environment variables: DIGITALOCEAN_ACCESS_TOKEN and DIGITALOCEAN_CONTEXT"
+}
+
 func TryDigitalOceanConfigFile(path string) sdk.Importer {
 	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
 		var config Config
@@ -51,18 +82,36 @@ func TryDigitalOceanConfigFile(path string) sdk.Importer {
 			return
 		}
 
-		if config.AccessToken == "" {
-			return
+		// The token of the default context is stored at the top level, the tokens of all other contexts
+		// under auth-contexts.
+		seen := map[string]bool{}
+		if config.AccessToken != "" {
+			seen[config.AccessToken] = true
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.Token: config.AccessToken,
+				},
+			})
 		}
 
-		out.AddCandidate(sdk.ImportCandidate{
-			Fields: map[sdk.FieldName]string{
-				fieldname.Token: config.AccessToken,
-			},
-		})
+		for name, token := range config.AuthContexts {
+			if token == "" || seen[token] {
+				continue
+			}
+			seen[token] = true
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.Token:   token,
+					fieldname.Context: name,
+				},
+				NameHint: importer.SanitizeNameHint(name),
+			})
+		}
 	})
 }
 
 type Config struct {
-	AccessToken string `yaml:"access-token"`
+	AccessToken  string            `yaml:"access-token"`
+	AuthContexts map[string]string `yaml:"auth-contexts"`
 }
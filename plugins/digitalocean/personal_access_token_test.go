@@ -35,6 +35,26 @@ func TestPersonalAccessTokenImporter(t *testing.T) {
 				},
 			},
 		},
+		"config file with multiple auth contexts": {
+			OS: "linux",
+			Files: map[string]string{
+				"~/.config/doctl/config.yaml": plugintest.LoadFixture(t, "config-multiple-contexts.yaml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token: "dop_v1_tr33mpd5m8q9t3ncisqbceydi8dd2n60pl1yiycg97z25fkqffp8j6ycjexample",
+					},
+				},
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token:   "dop_v1_a8k3m2n9p4q7r1s6t0u5v3w8x2y7z1b4c9d6e0f3g8h2j5k1l7m4n0p9qexample",
+						fieldname.Context: "staging",
+					},
+					NameHint: "staging",
+				},
+			},
+		},
 	})
 }
 
@@ -50,5 +70,30 @@ func TestPersonalAccessTokenProvisioner(t *testing.T) {
 				},
 			},
 		},
+		"context": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Token:   "dop_v1_a8k3m2n9p4q7r1s6t0u5v3w8x2y7z1b4c9d6e0f3g8h2j5k1l7m4n0p9qexample",
+				fieldname.Context: "staging",
+			},
+			Profile: "staging",
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"DIGITALOCEAN_ACCESS_TOKEN": "dop_v1_a8k3m2n9p4q7r1s6t0u5v3w8x2y7z1b4c9d6e0f3g8h2j5k1l7m4n0p9qexample",
+					"DIGITALOCEAN_CONTEXT":      "staging",
+				},
+			},
+		},
+		"item for another context": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Token:   "dop_v1_a8k3m2n9p4q7r1s6t0u5v3w8x2y7z1b4c9d6e0f3g8h2j5k1l7m4n0p9qexample",
+				fieldname.Context: "staging",
+			},
+			Profile: "production",
+			ExpectedOutput: sdk.ProvisionOutput{
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "the command targets doctl context 'production', but the configured 1Password item is for context 'staging'"}},
+				},
+			},
+		},
 	})
 }
@@ -0,0 +1,266 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// dockerHubServerAddress is the key under which the Docker CLI stores the Docker Hub credentials.
+const dockerHubServerAddress = "https://index.docker.io/v1/"
+
+// Config holds the parts of the Docker CLI config file that are relevant for authentication.
+// The full format is described at https://docs.docker.com/reference/cli/docker/#configuration-files
+type Config struct {
+	Auths               map[string]Auth   `json:"auths"`
+	CredsStore          string            `json:"credsStore,omitempty"`
+	CredHelpers         map[string]string `json:"credHelpers,omitempty"`
+	CLIPluginsExtraDirs []string          `json:"cliPluginsExtraDirs,omitempty"`
+}
+
+type Auth struct {
+	Auth string `json:"auth,omitempty"`
+}
+
+// dockerConfigProvisioner provisions a copy of the user's Docker config directory with the Docker Hub credentials
+// added, and points DOCKER_CONFIG to it.
+type dockerConfigProvisioner struct {
+}
+
+func (p dockerConfigProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	configDir := in.FromHomeDir(".docker")
+	existing, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil && !os.IsNotExist(err) {
+		out.AddError(err)
+		return
+	}
+
+	auth := in.ItemFields[fieldname.Username] + ":" + in.ItemFields[fieldname.Token]
+	contents, err := dockerConfig(existing, base64.StdEncoding.EncodeToString([]byte(auth)), filepath.Join(configDir, "cli-plugins"))
+	if err != nil {
+		out.AddError(fmt.Errorf("parsing Docker config file: %w", err))
+		return
+	}
+	out.AddSecretFile(in.FromTempDir("config.json"), contents)
+
+	// The Docker CLI stores the contexts in the config directory, so copy them along to keep the current context
+	// working.
+	contextsDir := filepath.Join(configDir, "contexts")
+	err = filepath.WalkDir(contextsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(configDir, path)
+		if err != nil {
+			return err
+		}
+		out.AddSecretFile(in.FromTempDir(relPath), contents)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		out.AddError(fmt.Errorf("copying Docker contexts: %w", err))
+		return
+	}
+
+	out.AddEnvVar("DOCKER_CONFIG", in.TempDir)
+}
+
+// dockerConfig returns the contents of the existing Docker config file, with the Docker Hub auth set. All other keys,
+// such as the current context and the credentials of other registries, are kept as they are.
+func dockerConfig(existing []byte, auth string, pluginsDir string) ([]byte, error) {
+	config := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(existing)) > 0 {
+		if err := json.Unmarshal(existing, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	auths := make(map[string]json.RawMessage)
+	if err := unmarshalKey(config, "auths", &auths); err != nil {
+		return nil, err
+	}
+	hubAuth, err := json.Marshal(Auth{Auth: auth})
+	if err != nil {
+		return nil, err
+	}
+	auths[dockerHubServerAddress] = hubAuth
+	if err := marshalKey(config, "auths", auths); err != nil {
+		return nil, err
+	}
+
+	// Credential helpers take precedence over the auths in the config file. An empty helper for Docker Hub makes
+	// the Docker CLI read those credentials from the config file, while other registries keep using their helpers.
+	credHelpers := make(map[string]string)
+	if err := unmarshalKey(config, "credHelpers", &credHelpers); err != nil {
+		return nil, err
+	}
+	if _, ok := credHelpers[dockerHubServerAddress]; ok || config["credsStore"] != nil {
+		credHelpers[dockerHubServerAddress] = ""
+		if err := marshalKey(config, "credHelpers", credHelpers); err != nil {
+			return nil, err
+		}
+	}
+
+	// The Docker CLI looks up CLI plugins such as compose and buildx in the config directory,
+	// so keep the ones installed in the default config directory available.
+	var pluginsDirs []string
+	if err := unmarshalKey(config, "cliPluginsExtraDirs", &pluginsDirs); err != nil {
+		return nil, err
+	}
+	hasPluginsDir := false
+	for _, dir := range pluginsDirs {
+		hasPluginsDir = hasPluginsDir || dir == pluginsDir
+	}
+	if !hasPluginsDir {
+		pluginsDirs = append(pluginsDirs, pluginsDir)
+	}
+	if err := marshalKey(config, "cliPluginsExtraDirs", pluginsDirs); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(config, "", "\t")
+}
+
+func unmarshalKey(config map[string]json.RawMessage, key string, value any) error {
+	if raw, ok := config[key]; ok {
+		return json.Unmarshal(raw, value)
+	}
+	return nil
+}
+
+func marshalKey(config map[string]json.RawMessage, key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	config[key] = raw
+	return nil
+}
+
+func (p dockerConfigProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p dockerConfigProvisioner) Description() string {
+	return "Provision environment variable DOCKER_CONFIG with a temporary copy of the config directory holding the Docker Hub credentials"
+}
+
+// credentialHelper looks up the credentials for the server with the Docker credential helper of the given name,
+// e.g. 'desktop' for docker-credential-desktop.
+type credentialHelper func(ctx context.Context, name string, serverAddress string) (username string, secret string, err error)
+
+// execCredentialHelper runs 'docker-credential-<name> get', as described in
+// https://github.com/docker/docker-credential-helpers#usage
+func execCredentialHelper(ctx context.Context, name string, serverAddress string) (string, string, error) {
+	cmd := exec.CommandContext(ctx, "docker-credential-"+name, "get")
+	cmd.Stdin = strings.NewReader(serverAddress)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		// Helpers print this message if they don't hold credentials for the server.
+		if strings.Contains(string(output)+stderr.String(), "credentials not found") {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("running docker-credential-%s: %s", name, err)
+	}
+
+	var credentials struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(output, &credentials); err != nil {
+		return "", "", fmt.Errorf("parsing output of docker-credential-%s: %s", name, err)
+	}
+	return credentials.Username, credentials.Secret, nil
+}
+
+// TryDockerConfigEnvVar imports the Docker Hub credentials from the config file in the directory set in DOCKER_CONFIG.
+func TryDockerConfigEnvVar(helper credentialHelper) sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		dir := os.Getenv("DOCKER_CONFIG")
+		if dir == "" {
+			return
+		}
+
+		path := filepath.Join(dir, "config.json")
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				out.NewAttempt(importer.SourceFile(path)).AddError(err)
+			}
+			return
+		}
+		addDockerHubCandidate(ctx, out.NewAttempt(importer.SourceFile(path)), contents, helper)
+	}
+}
+
+// TryDockerConfigFile imports the Docker Hub credentials from the Docker CLI config file at path.
+func TryDockerConfigFile(path string, helper credentialHelper) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		addDockerHubCandidate(ctx, out, contents, helper)
+	})
+}
+
+// addDockerHubCandidate adds a candidate for the Docker Hub credentials in the config file. These are either stored
+// in the config file itself, or in the credential helper configured for Docker Hub or for all registries.
+func addDockerHubCandidate(ctx context.Context, out *sdk.ImportAttempt, contents []byte, helper credentialHelper) {
+	var config Config
+	if err := json.Unmarshal(contents, &config); err != nil {
+		out.AddError(err)
+		return
+	}
+
+	var username, secret string
+	if helperName := credentialHelperName(config); helperName != "" {
+		var err error
+		username, secret, err = helper(ctx, helperName, dockerHubServerAddress)
+		if err != nil {
+			out.AddError(err)
+			return
+		}
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(config.Auths[dockerHubServerAddress].Auth)
+		if err != nil {
+			out.AddError(err)
+			return
+		}
+		username, secret, _ = strings.Cut(string(decoded), ":")
+	}
+
+	// Docker Desktop stores OAuth tokens under this username, which can't be used as a personal access token.
+	if username == "" || username == "<token>" || secret == "" {
+		return
+	}
+
+	out.AddCandidate(sdk.ImportCandidate{
+		Fields: map[sdk.FieldName]string{
+			fieldname.Username: username,
+			fieldname.Token:    secret,
+		},
+	})
+}
+
+// credentialHelperName returns the name of the credential helper that holds the Docker Hub credentials, if any.
+func credentialHelperName(config Config) string {
+	if name, ok := config.CredHelpers[dockerHubServerAddress]; ok {
+		return name
+	}
+	return config.CredsStore
+}
@@ -0,0 +1,40 @@
+package docker
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func DockerCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Docker CLI",
+		Runs:    []string{"docker"},
+		DocsURL: sdk.URL("https://docs.docker.com/reference/cli/docker/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			// Only the commands that can talk to Docker Hub need the token. 'docker login' is left out on purpose:
+			// it would store the credentials in the temporary config, which gets deleted when it exits.
+			needsauth.IfAny(
+				needsauth.ForCommand("pull"),
+				needsauth.ForCommand("push"),
+				needsauth.ForCommand("search"),
+				needsauth.ForCommand("build"),
+				needsauth.ForCommand("run"),
+				needsauth.ForCommand("create"),
+				needsauth.ForCommand("image", "pull"),
+				needsauth.ForCommand("image", "push"),
+				needsauth.ForCommand("buildx", "build"),
+				needsauth.ForCommand("manifest"),
+				needsauth.ForCommand("compose"),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.PersonalAccessToken,
+			},
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestDockerCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, DockerCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for pull": {
+			Args:              []string{"pull", "acme/web:latest"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for image push": {
+			Args:              []string{"image", "push", "acme/web:latest"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for buildx build": {
+			Args:              []string{"buildx", "build", "--push", "-t", "acme/web:latest", "."},
+			ExpectedNeedsAuth: true,
+		},
+		"no for ps": {
+			Args:              []string{"ps"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for login": {
+			Args:              []string{"login"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for push help": {
+			Args:              []string{"push", "--help"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
@@ -0,0 +1,43 @@
+package docker
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func PersonalAccessToken() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.PersonalAccessToken,
+		DocsURL:       sdk.URL("https://docs.docker.com/security/for-developers/access-tokens/"),
+		ManagementURL: sdk.URL("https://app.docker.com/settings/personal-access-tokens"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The Docker ID the token belongs to.",
+			},
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "Personal access token used to authenticate to Docker Hub.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 36,
+					Prefix: "dckr_pat_",
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'-', '_'},
+					},
+				},
+			},
+		},
+		DefaultProvisioner: dockerConfigProvisioner{},
+		Importer: importer.TryAll(
+			TryDockerConfigEnvVar(execCredentialHelper),
+			TryDockerConfigFile("~/.docker/config.json", execCredentialHelper),
+		),
+	}
+}
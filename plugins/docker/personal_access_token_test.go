@@ -0,0 +1,151 @@
+package docker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessTokenProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, PersonalAccessToken().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Username: "wendy",
+				fieldname.Token:    "dckr_pat_2sD8fK1xQ9vT4nB7mZ3pEXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"DOCKER_CONFIG": "/tmp",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/config.json": {Contents: []byte(plugintest.LoadFixture(t, "config.json"))},
+				},
+			},
+		},
+	})
+}
+
+func TestPersonalAccessTokenProvisionerWithExistingConfig(t *testing.T) {
+	homeDir := t.TempDir()
+	contextMeta := `{"Name":"desktop-linux","Endpoints":{"docker":{"Host":"unix:///home/wendy/.docker/desktop/docker.sock"}}}`
+	files := map[string]string{
+		"config.json": plugintest.LoadFixture(t, "config-existing.json"),
+		"contexts/meta/fe9c6bd7a66301f49ca9b6a70b217107cd1284598bfc254700c989b916da791e/meta.json": contextMeta,
+	}
+	for path, contents := range files {
+		path = filepath.Join(homeDir, ".docker", path)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := sdk.ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]sdk.OutputFile),
+	}
+	PersonalAccessToken().DefaultProvisioner.Provision(context.Background(), sdk.ProvisionInput{
+		HomeDir: homeDir,
+		TempDir: "/tmp",
+		ItemFields: map[sdk.FieldName]string{
+			fieldname.Username: "wendy",
+			fieldname.Token:    "dckr_pat_2sD8fK1xQ9vT4nB7mZ3pEXAMPLE",
+		},
+	}, &out)
+
+	expectedConfig := strings.ReplaceAll(plugintest.LoadFixture(t, "config-merged.json"), "HOME", homeDir)
+	assert.Empty(t, out.Diagnostics.Errors)
+	assert.Equal(t, map[string]string{"DOCKER_CONFIG": "/tmp"}, out.Environment)
+	assert.Equal(t, map[string]sdk.OutputFile{
+		"/tmp/config.json": {Contents: []byte(strings.TrimSpace(expectedConfig))},
+		"/tmp/contexts/meta/fe9c6bd7a66301f49ca9b6a70b217107cd1284598bfc254700c989b916da791e/meta.json": {Contents: []byte(contextMeta)},
+	}, out.Files)
+}
+
+func TestPersonalAccessTokenImporter(t *testing.T) {
+	plugintest.TestImporter(t, PersonalAccessToken().Importer, map[string]plugintest.ImportCase{
+		"config file": {
+			Files: map[string]string{
+				"~/.docker/config.json": plugintest.LoadFixture(t, "config.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Username: "wendy",
+						fieldname.Token:    "dckr_pat_2sD8fK1xQ9vT4nB7mZ3pEXAMPLE",
+					},
+				},
+			},
+		},
+		"DOCKER_CONFIG": {
+			Files: map[string]string{
+				"~/docker/config.json": plugintest.LoadFixture(t, "config.json"),
+			},
+			RootedEnvironment: map[string]string{
+				"DOCKER_CONFIG": "~/docker",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Username: "wendy",
+						fieldname.Token:    "dckr_pat_2sD8fK1xQ9vT4nB7mZ3pEXAMPLE",
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestDockerConfigFileWithCredentialHelpers(t *testing.T) {
+	helper := func(ctx context.Context, name string, serverAddress string) (string, string, error) {
+		if serverAddress != dockerHubServerAddress {
+			return "", "", nil
+		}
+		switch name {
+		case "desktop":
+			return "wendy", "dckr_pat_2sD8fK1xQ9vT4nB7mZ3pEXAMPLE", nil
+		case "pass":
+			return "ci", "dckr_pat_Xv2Rk7Tn1Wb6Yc3Jd8Hf5EXAMPLE", nil
+		}
+		return "", "", nil
+	}
+
+	plugintest.TestImporter(t, importer.TryAll(TryDockerConfigFile("~/.docker/config.json", helper)), map[string]plugintest.ImportCase{
+		"credsStore": {
+			Files: map[string]string{
+				"~/.docker/config.json": plugintest.LoadFixture(t, "config-creds-store.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Username: "wendy",
+						fieldname.Token:    "dckr_pat_2sD8fK1xQ9vT4nB7mZ3pEXAMPLE",
+					},
+				},
+			},
+		},
+		"credHelpers take precedence over credsStore": {
+			Files: map[string]string{
+				"~/.docker/config.json": plugintest.LoadFixture(t, "config-cred-helpers.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Username: "ci",
+						fieldname.Token:    "dckr_pat_Xv2Rk7Tn1Wb6Yc3Jd8Hf5EXAMPLE",
+					},
+				},
+			},
+		},
+	})
+}
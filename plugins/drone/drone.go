@@ -0,0 +1,32 @@
+package drone
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func DroneCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Drone CLI",
+		Runs:    []string{"drone"},
+		DocsURL: sdk.URL("https://docs.drone.io/cli/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			// These commands run pipelines or process pipeline files locally, without talking to the server.
+			needsauth.Not(needsauth.ForCommand("exec")),
+			needsauth.Not(needsauth.ForCommand("fmt")),
+			needsauth.Not(needsauth.ForCommand("lint")),
+			needsauth.Not(needsauth.ForCommand("convert")),
+			needsauth.Not(needsauth.ForCommand("jsonnet")),
+			needsauth.Not(needsauth.ForCommand("starlark")),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.PersonalAccessToken,
+			},
+		},
+	}
+}
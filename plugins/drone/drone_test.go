@@ -0,0 +1,40 @@
+package drone
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestDroneCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, DroneCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"no for --help": {
+			Args:              []string{"--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for exec": {
+			Args:              []string{"exec", "--pipeline", "default"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for lint": {
+			Args:              []string{"lint", ".drone.yml"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for build list": {
+			Args:              []string{"build", "ls", "acme/api"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for promote": {
+			Args:              []string{"build", "promote", "acme/api", "42", "production"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for secret add": {
+			Args:              []string{"secret", "add", "acme/api", "--name", "docker_password", "--data", "@password.txt"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for orgsecret list": {
+			Args:              []string{"orgsecret", "ls"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package drone
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func PersonalAccessToken() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.PersonalAccessToken,
+		DocsURL:       sdk.URL("https://docs.drone.io/cli/setup/"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Server,
+				MarkdownDescription: "The URL of the Drone server, e.g. 'https://drone.acme.com'.",
+			},
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "Personal token used to authenticate to Drone, as shown on the account settings page.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 32,
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+					},
+				},
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryDroneRCFile(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"DRONE_SERVER": fieldname.Server,
+	"DRONE_TOKEN":  fieldname.Token,
+}
+
+// TryDroneRCFile imports the server and token from ~/.dronerc. The Drone CLI has no config file of its own, so the
+// setup docs have users export DRONE_SERVER and DRONE_TOKEN, which are commonly kept in this file and sourced
+// from the shell profile.
+func TryDroneRCFile() sdk.Importer {
+	return importer.TryFile("~/.dronerc", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		env := contents.ToEnv()
+		if env["DRONE_SERVER"] == "" || env["DRONE_TOKEN"] == "" {
+			return
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.Server: env["DRONE_SERVER"],
+				fieldname.Token:  env["DRONE_TOKEN"],
+			},
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package drone
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestPersonalAccessTokenProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, PersonalAccessToken().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Server: "https://drone.acme.com",
+				fieldname.Token:  "Xk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAM",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"DRONE_SERVER": "https://drone.acme.com",
+					"DRONE_TOKEN":  "Xk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAM",
+				},
+			},
+		},
+	})
+}
+
+func TestPersonalAccessTokenImporter(t *testing.T) {
+	plugintest.TestImporter(t, PersonalAccessToken().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"DRONE_SERVER": "https://drone.acme.com",
+				"DRONE_TOKEN":  "Xk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAM",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Server: "https://drone.acme.com",
+						fieldname.Token:  "Xk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAM",
+					},
+				},
+			},
+		},
+		"rc file": {
+			Files: map[string]string{
+				"~/.dronerc": plugintest.LoadFixture(t, ".dronerc"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Server: "https://drone.acme.com",
+						fieldname.Token:  "Xk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAM",
+					},
+				},
+			},
+		},
+	})
+}
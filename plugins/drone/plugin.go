@@ -0,0 +1,22 @@
+package drone
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "drone",
+		Platform: schema.PlatformInfo{
+			Name:     "Drone",
+			Homepage: sdk.URL("https://www.drone.io"),
+		},
+		Credentials: []schema.CredentialType{
+			PersonalAccessToken(),
+		},
+		Executables: []schema.Executable{
+			DroneCLI(),
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func APIKey() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIKey,
+		DocsURL:       sdk.URL("https://community.exoscale.com/documentation/iam/quick-start"),
+		ManagementURL: sdk.URL("https://portal.exoscale.com/iam/api-keys"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.APIKey,
+				MarkdownDescription: "API key used to authenticate to Exoscale.",
+				Composition: &schema.ValueComposition{
+					Length: 27,
+					Prefix: "EXO",
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+					},
+				},
+			},
+			{
+				Name:                fieldname.APISecret,
+				MarkdownDescription: "API secret used to authenticate to Exoscale.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 43,
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'-', '_'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.Zone,
+				MarkdownDescription: "The zone to use by default, e.g. 'ch-gva-2'.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryExoscaleConfigFile("~/.config/exoscale/exoscale.toml"),
+			importer.MacOnly(TryExoscaleConfigFile("~/Library/Application Support/exoscale/exoscale.toml")),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"EXOSCALE_API_KEY":    fieldname.APIKey,
+	"EXOSCALE_API_SECRET": fieldname.APISecret,
+	"EXOSCALE_ZONE":       fieldname.Zone,
+}
+
+// TryExoscaleConfigFile imports every account configured in the Exoscale CLI config file.
+func TryExoscaleConfigFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config Config
+		if err := contents.ToTOML(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, account := range config.Accounts {
+			if account.Key == "" || account.Secret == "" {
+				continue
+			}
+
+			fields := map[sdk.FieldName]string{
+				fieldname.APIKey:    account.Key,
+				fieldname.APISecret: account.Secret,
+			}
+			if account.DefaultZone != "" {
+				fields[fieldname.Zone] = account.DefaultZone
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields:   fields,
+				NameHint: importer.SanitizeNameHint(account.Name),
+			})
+		}
+	})
+}
+
+type Config struct {
+	Accounts []ConfigAccount `toml:"accounts"`
+}
+
+type ConfigAccount struct {
+	Name        string `toml:"name"`
+	Key         string `toml:"key"`
+	Secret      string `toml:"secret"`
+	DefaultZone string `toml:"defaultZone"`
+}
@@ -0,0 +1,79 @@
+package exoscale
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPIKeyProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, APIKey().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.APIKey:    "EXO3f9a1c7b2e8d4a6f0EXAMPLE",
+				fieldname.APISecret: "Zp4Qm9Xv2Rk7Tn1Wb6Yc3Jd8Hf5Gs0La-KeUEXAMPLE",
+				fieldname.Zone:      "ch-gva-2",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"EXOSCALE_API_KEY":    "EXO3f9a1c7b2e8d4a6f0EXAMPLE",
+					"EXOSCALE_API_SECRET": "Zp4Qm9Xv2Rk7Tn1Wb6Yc3Jd8Hf5Gs0La-KeUEXAMPLE",
+					"EXOSCALE_ZONE":       "ch-gva-2",
+				},
+			},
+		},
+	})
+}
+
+func TestAPIKeyImporter(t *testing.T) {
+	expectedCandidates := []sdk.ImportCandidate{
+		{
+			Fields: map[sdk.FieldName]string{
+				fieldname.APIKey:    "EXO3f9a1c7b2e8d4a6f0EXAMPLE",
+				fieldname.APISecret: "Zp4Qm9Xv2Rk7Tn1Wb6Yc3Jd8Hf5Gs0La-KeUEXAMPLE",
+				fieldname.Zone:      "ch-gva-2",
+			},
+			NameHint: "production",
+		},
+		{
+			Fields: map[sdk.FieldName]string{
+				fieldname.APIKey:    "EXO8b2d4f6a1c3e5d7f9EXAMPLE",
+				fieldname.APISecret: "Mx7Lp2Qw9Er4Ty1Ui6Op3As8Df5Gh0Jk-ZxCEXAMPLE",
+				fieldname.Zone:      "de-fra-1",
+			},
+			NameHint: "staging",
+		},
+	}
+
+	plugintest.TestImporter(t, APIKey().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"EXOSCALE_API_KEY":    "EXO3f9a1c7b2e8d4a6f0EXAMPLE",
+				"EXOSCALE_API_SECRET": "Zp4Qm9Xv2Rk7Tn1Wb6Yc3Jd8Hf5Gs0La-KeUEXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.APIKey:    "EXO3f9a1c7b2e8d4a6f0EXAMPLE",
+						fieldname.APISecret: "Zp4Qm9Xv2Rk7Tn1Wb6Yc3Jd8Hf5Gs0La-KeUEXAMPLE",
+					},
+				},
+			},
+		},
+		"config file": {
+			Files: map[string]string{
+				"~/.config/exoscale/exoscale.toml": plugintest.LoadFixture(t, "exoscale.toml"),
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"config file on macOS": {
+			OS: "darwin",
+			Files: map[string]string{
+				"~/Library/Application Support/exoscale/exoscale.toml": plugintest.LoadFixture(t, "exoscale.toml"),
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+	})
+}
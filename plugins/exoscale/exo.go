@@ -0,0 +1,26 @@
+package exoscale
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func ExoscaleCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Exoscale CLI",
+		Runs:    []string{"exo"},
+		DocsURL: sdk.URL("https://community.exoscale.com/documentation/tools/exoscale-command-line-interface"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("config"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.APIKey,
+			},
+		},
+	}
+}
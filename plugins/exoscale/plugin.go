@@ -0,0 +1,22 @@
+package exoscale
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "exoscale",
+		Platform: schema.PlatformInfo{
+			Name:     "Exoscale",
+			Homepage: sdk.URL("https://www.exoscale.com"),
+		},
+		Credentials: []schema.CredentialType{
+			APIKey(),
+		},
+		Executables: []schema.Executable{
+			ExoscaleCLI(),
+		},
+	}
+}
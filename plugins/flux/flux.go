@@ -0,0 +1,43 @@
+package flux
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func FluxCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Flux CLI",
+		Runs:    []string{"flux"},
+		DocsURL: sdk.URL("https://fluxcd.io/flux/cmd/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.ForCommand("bootstrap"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name:        credname.PersonalAccessToken,
+				Plugin:      "github",
+				Description: "Token used by 'flux bootstrap github' to create the repository and deploy key",
+				Provisioner: provision.EnvVars(map[string]sdk.FieldName{
+					"GITHUB_TOKEN": fieldname.Token,
+				}),
+				NeedsAuth: needsauth.ForCommand("bootstrap", "github"),
+			},
+			{
+				Name:        credname.PersonalAccessToken,
+				Plugin:      "gitlab",
+				Description: "Token used by 'flux bootstrap gitlab' to create the project and deploy key",
+				Provisioner: provision.EnvVars(map[string]sdk.FieldName{
+					"GITLAB_TOKEN": fieldname.Token,
+				}),
+				NeedsAuth: needsauth.ForCommand("bootstrap", "gitlab"),
+			},
+		},
+	}
+}
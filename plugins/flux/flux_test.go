@@ -0,0 +1,58 @@
+package flux
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestFluxNeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, FluxCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for bootstrap github": {
+			Args:              []string{"bootstrap", "github", "--owner=acme", "--repository=fleet-infra"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for bootstrap gitlab": {
+			Args:              []string{"bootstrap", "gitlab", "--owner=acme", "--repository=fleet-infra"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for bootstrap help": {
+			Args:              []string{"bootstrap", "github", "--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for get sources": {
+			Args:              []string{"get", "sources", "git"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for version": {
+			Args:              []string{"--version"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
+
+func TestFluxCredentialUsagesNeedAuth(t *testing.T) {
+	uses := FluxCLI().Uses
+
+	plugintest.TestNeedsAuth(t, uses[0].NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"GitHub token for bootstrap github": {
+			Args:              []string{"bootstrap", "github", "--owner=acme"},
+			ExpectedNeedsAuth: true,
+		},
+		"no GitHub token for bootstrap gitlab": {
+			Args:              []string{"bootstrap", "gitlab", "--owner=acme"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+
+	plugintest.TestNeedsAuth(t, uses[1].NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"GitLab token for bootstrap gitlab": {
+			Args:              []string{"bootstrap", "gitlab", "--owner=acme"},
+			ExpectedNeedsAuth: true,
+		},
+		"no GitLab token for bootstrap github": {
+			Args:              []string{"bootstrap", "github", "--owner=acme"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
@@ -0,0 +1,19 @@
+package flux
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "flux",
+		Platform: schema.PlatformInfo{
+			Name:     "Flux",
+			Homepage: sdk.URL("https://fluxcd.io"),
+		},
+		Executables: []schema.Executable{
+			FluxCLI(),
+		},
+	}
+}
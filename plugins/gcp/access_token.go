@@ -0,0 +1,169 @@
+package gcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const (
+	defaultTokenURI    = "https://oauth2.googleapis.com/token"
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	// assertionLifetime is the lifetime of the signed assertion, which is also the lifetime of the access token.
+	assertionLifetime = time.Hour
+
+	// tokenExpiryMargin makes sure a cached access token doesn't expire while the executable is using it.
+	tokenExpiryMargin = 5 * time.Minute
+
+	accessTokenCacheKey = "access-token"
+
+	// dryRunAccessToken is provisioned instead of a real access token in dry runs, to skip the token exchange.
+	dryRunAccessToken = "<access token>"
+)
+
+type cachedAccessToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// accessToken returns a cached access token for the service account, or exchanges a signed JWT assertion for a new
+// one, as described in https://developers.google.com/identity/protocols/oauth2/service-account#httprest.
+func (p serviceAccountProvisioner) accessToken(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput, key ServiceAccountKeyFile) (string, error) {
+	if in.DryRun {
+		return dryRunAccessToken, nil
+	}
+
+	cache := in.NamespacedCache(out, "gcp", in.ItemFingerprint(fieldname.Credentials))
+	var cached cachedAccessToken
+	if cache.Get(accessTokenCacheKey, &cached) && cached.AccessToken != "" {
+		return cached.AccessToken, nil
+	}
+
+	now := in.Now()
+	tokenURI := key.TokenURI
+	if tokenURI == "" {
+		tokenURI = defaultTokenURI
+	}
+	assertion, err := signAssertion(key, tokenURI, now)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {jwtBearerGrantType},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := p.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("exchanging service account key for an access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("decoding access token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		if token.ErrorDescription != "" {
+			return "", fmt.Errorf("exchanging service account key for an access token: %s (%s)", token.ErrorDescription, resp.Status)
+		}
+		return "", fmt.Errorf("exchanging service account key for an access token: unexpected response: %s", resp.Status)
+	}
+
+	expiresAt := now.Add(time.Duration(token.ExpiresIn) * time.Second).Add(-tokenExpiryMargin)
+	if expiresAt.After(now) {
+		err = cache.PutUntil(accessTokenCacheKey, cachedAccessToken{AccessToken: token.AccessToken, ExpiresAt: expiresAt}, expiresAt)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return token.AccessToken, nil
+}
+
+// signAssertion creates the JWT assertion that proves possession of the service account's private key.
+func signAssertion(key ServiceAccountKeyFile, audience string, now time.Time) (string, error) {
+	privateKey, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": key.PrivateKeyID,
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iss":   key.ClientEmail,
+		"scope": cloudPlatformScope,
+		"aud":   audience,
+		"iat":   now.Unix(),
+		"exp":   now.Add(assertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signing access token request: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parsePrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("the private key of the service account is not PEM-encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the private key of the service account: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key of the service account is not an RSA key")
+	}
+	return key, nil
+}
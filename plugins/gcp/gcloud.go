@@ -0,0 +1,62 @@
+package gcp
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func GoogleCloudCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Google Cloud CLI",
+		Runs:    []string{"gcloud"},
+		DocsURL: sdk.URL("https://cloud.google.com/sdk/gcloud"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("auth"),
+			needsauth.NotWhenContainsArgs("config"),
+			needsauth.NotWhenContainsArgs("components"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.ServiceAccountKey,
+			},
+		},
+	}
+}
+
+func CloudStorageCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Cloud Storage CLI",
+		Runs:    []string{"gsutil"},
+		DocsURL: sdk.URL("https://cloud.google.com/storage/docs/gsutil"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.ServiceAccountKey,
+			},
+		},
+	}
+}
+
+func BigQueryCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "BigQuery CLI",
+		Runs:    []string{"bq"},
+		DocsURL: sdk.URL("https://cloud.google.com/bigquery/docs/bq-command-line-tool"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.ServiceAccountKey,
+			},
+		},
+	}
+}
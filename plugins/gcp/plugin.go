@@ -0,0 +1,27 @@
+package gcp
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "gcp",
+		Platform: schema.PlatformInfo{
+			Name:     "Google Cloud",
+			Homepage: sdk.URL("https://cloud.google.com"),
+		},
+		Credentials: []schema.CredentialType{
+			ServiceAccountKey(),
+		},
+		Executables: []schema.Executable{
+			GoogleCloudCLI(),
+			CloudStorageCLI(),
+			BigQueryCLI(),
+		},
+		Settings: []schema.Setting{
+			CredentialsMode(),
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const credentialsModeSetting = "credentials-mode"
+
+const (
+	keyFileCredentialsMode     = "key-file"
+	accessTokenCredentialsMode = "access-token"
+)
+
+func CredentialsMode() schema.Setting {
+	return schema.Setting{
+		Name:        credentialsModeSetting,
+		Description: "How to provide the service account: as a temporary key file, or as a short-lived access token exchanged for the key, so that the key itself is never exposed to the executable.",
+		Default:     keyFileCredentialsMode,
+		Options:     []string{keyFileCredentialsMode, accessTokenCredentialsMode},
+	}
+}
+
+// serviceAccountProvisioner provisions the service account key as a temporary key file that both gcloud and the
+// client libraries pick up, or as an access token if that mode is configured.
+type serviceAccountProvisioner struct {
+	// client is the HTTP client used to exchange the key for an access token. Defaults to http.DefaultClient.
+	client *http.Client
+}
+
+func (p serviceAccountProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	contents := []byte(in.ItemFields[fieldname.Credentials])
+	key, ok := parseServiceAccountKey(contents)
+	if !ok {
+		out.AddError(errors.New("the credentials field does not contain a service account key in JSON format"))
+		return
+	}
+
+	projectID := in.ItemFields[fieldname.ProjectID]
+	if projectID == "" {
+		projectID = key.ProjectID
+	}
+	if projectID != "" {
+		out.AddEnvVar("CLOUDSDK_CORE_PROJECT", projectID)
+		out.AddEnvVar("GOOGLE_CLOUD_PROJECT", projectID)
+	}
+
+	if in.Settings.Get(credentialsModeSetting) == accessTokenCredentialsMode {
+		token, err := p.accessToken(ctx, in, out, key)
+		if err != nil {
+			out.AddError(err)
+			return
+		}
+		out.AddEnvVar("CLOUDSDK_AUTH_ACCESS_TOKEN", token)
+		out.AddEnvVar("GOOGLE_OAUTH_ACCESS_TOKEN", token)
+		return
+	}
+
+	path := in.FromTempDir("service-account.json")
+	out.AddSecretFile(path, contents)
+	out.AddEnvVar("GOOGLE_APPLICATION_CREDENTIALS", path)
+	out.AddEnvVar("CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE", path)
+}
+
+func (p serviceAccountProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p serviceAccountProvisioner) Description() string {
+	return "Provision the service account key as a temporary file set in GOOGLE_APPLICATION_CREDENTIALS and CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE, or as an access token in CLOUDSDK_AUTH_ACCESS_TOKEN"
+}
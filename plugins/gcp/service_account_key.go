@@ -0,0 +1,124 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func ServiceAccountKey() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.ServiceAccountKey,
+		DocsURL:       sdk.URL("https://cloud.google.com/iam/docs/keys-create-delete"),
+		ManagementURL: sdk.URL("https://console.cloud.google.com/iam-admin/serviceaccounts"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Credentials,
+				MarkdownDescription: "The JSON key file of the service account.",
+				Secret:              true,
+			},
+			{
+				Name:                fieldname.ProjectID,
+				MarkdownDescription: "The ID of the project to use by default. Defaults to the project the service account belongs to.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: serviceAccountProvisioner{},
+		Importer: importer.TryAll(
+			TryGoogleApplicationCredentialsEnvVar(),
+			TryApplicationDefaultCredentialsFile(),
+			TryGcloudLegacyCredentials(),
+		),
+	}
+}
+
+// ServiceAccountKeyFile is the JSON key file of a service account, as downloaded from the Google Cloud console.
+type ServiceAccountKeyFile struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// parseServiceAccountKey parses the contents of a credentials file, returning false if it doesn't contain a service
+// account key, like the application default credentials of a user account.
+func parseServiceAccountKey(contents []byte) (ServiceAccountKeyFile, bool) {
+	var key ServiceAccountKeyFile
+	if err := json.Unmarshal(contents, &key); err != nil {
+		return key, false
+	}
+	return key, key.Type == "service_account" && key.PrivateKey != "" && key.ClientEmail != ""
+}
+
+// addKeyCandidate adds the key as a candidate if it is a service account key, using the name of the service
+// account as name hint.
+func addKeyCandidate(out *sdk.ImportAttempt, contents []byte) {
+	key, ok := parseServiceAccountKey(contents)
+	if !ok {
+		return
+	}
+
+	out.AddCandidate(sdk.ImportCandidate{
+		Fields: map[sdk.FieldName]string{
+			fieldname.Credentials: string(contents),
+		},
+		NameHint: importer.SanitizeNameHint(strings.Split(key.ClientEmail, "@")[0]),
+	})
+}
+
+// TryGoogleApplicationCredentialsEnvVar imports the key file that GOOGLE_APPLICATION_CREDENTIALS points to.
+func TryGoogleApplicationCredentialsEnvVar() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+		if path == "" {
+			return
+		}
+
+		attempt := out.NewAttempt(importer.SourceEnvName("GOOGLE_APPLICATION_CREDENTIALS"))
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			attempt.AddError(err)
+			return
+		}
+		addKeyCandidate(attempt, contents)
+	}
+}
+
+// TryApplicationDefaultCredentialsFile imports the application default credentials set up with
+// 'gcloud auth application-default login', if these belong to a service account.
+func TryApplicationDefaultCredentialsFile() sdk.Importer {
+	return importer.TryFile("~/.config/gcloud/application_default_credentials.json", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		addKeyCandidate(out, contents)
+	})
+}
+
+// TryGcloudLegacyCredentials imports the keys of the service accounts that were activated with
+// 'gcloud auth activate-service-account', which gcloud stores per account.
+func TryGcloudLegacyCredentials() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		dir := filepath.Join(in.HomeDir, ".config", "gcloud", "legacy_credentials")
+		accounts, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+
+		for _, account := range accounts {
+			if !account.IsDir() {
+				continue
+			}
+			importer.TryFile(filepath.Join("~/.config/gcloud/legacy_credentials", account.Name(), "adc.json"), func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+				addKeyCandidate(out, contents)
+			})(ctx, in, out)
+		}
+	}
+}
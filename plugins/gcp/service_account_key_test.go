@@ -0,0 +1,217 @@
+package gcp
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAccountKeyProvisioner(t *testing.T) {
+	key := plugintest.LoadFixture(t, "service-account.json")
+
+	plugintest.TestProvisioner(t, ServiceAccountKey().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"key file": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Credentials: key,
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"GOOGLE_APPLICATION_CREDENTIALS":         "/tmp/service-account.json",
+					"CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE": "/tmp/service-account.json",
+					"CLOUDSDK_CORE_PROJECT":                  "acme-production",
+					"GOOGLE_CLOUD_PROJECT":                   "acme-production",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/service-account.json": {Contents: []byte(key)},
+				},
+			},
+		},
+		"project ID": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Credentials: key,
+				fieldname.ProjectID:   "acme-staging",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"GOOGLE_APPLICATION_CREDENTIALS":         "/tmp/service-account.json",
+					"CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE": "/tmp/service-account.json",
+					"CLOUDSDK_CORE_PROJECT":                  "acme-staging",
+					"GOOGLE_CLOUD_PROJECT":                   "acme-staging",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/service-account.json": {Contents: []byte(key)},
+				},
+			},
+		},
+		"not a service account key": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Credentials: plugintest.LoadFixture(t, "authorized-user.json"),
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "the credentials field does not contain a service account key in JSON format"}},
+				},
+			},
+		},
+	})
+}
+
+func TestServiceAccountKeyProvisionerAccessToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, jwtBearerGrantType, r.Form.Get("grant_type"))
+
+		parts := strings.Split(r.Form.Get("assertion"), ".")
+		require.Len(t, parts, 3)
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		assert.NoError(t, rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, digest[:], signature))
+
+		claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var claims map[string]any
+		require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+		assert.Equal(t, "deployer@acme-production.iam.gserviceaccount.com", claims["iss"])
+		assert.Equal(t, cloudPlatformScope, claims["scope"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "ya29.c.b0AXv0zTEXAMPLE", "expires_in": 3599, "token_type": "Bearer"}`))
+	}))
+	defer server.Close()
+
+	keyJSON, err := json.Marshal(ServiceAccountKeyFile{
+		Type:         "service_account",
+		ProjectID:    "acme-production",
+		PrivateKeyID: "8f3c2a1b9d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a",
+		PrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER})),
+		ClientEmail:  "deployer@acme-production.iam.gserviceaccount.com",
+		TokenURI:     server.URL,
+	})
+	require.NoError(t, err)
+	itemFields := map[sdk.FieldName]string{
+		fieldname.Credentials: string(keyJSON),
+	}
+
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(3599 * time.Second).Add(-tokenExpiryMargin)
+	in := sdk.ProvisionInput{ItemFields: itemFields}
+	cacheKey := "gcp|" + in.ItemFingerprint(fieldname.Credentials) + "|" + accessTokenCacheKey
+	expectedCache := sdk.CacheOperations{Puts: map[string]sdk.CacheEntry{}}
+	require.NoError(t, expectedCache.Put(cacheKey, cachedAccessToken{AccessToken: "ya29.c.b0AXv0zTEXAMPLE", ExpiresAt: expiresAt}, expiresAt))
+
+	environment := map[string]string{
+		"CLOUDSDK_AUTH_ACCESS_TOKEN": "ya29.c.b0AXv0zTEXAMPLE",
+		"GOOGLE_OAUTH_ACCESS_TOKEN":  "ya29.c.b0AXv0zTEXAMPLE",
+		"CLOUDSDK_CORE_PROJECT":      "acme-production",
+		"GOOGLE_CLOUD_PROJECT":       "acme-production",
+	}
+	settings := sdk.Settings{credentialsModeSetting: accessTokenCredentialsMode}
+
+	plugintest.TestProvisioner(t, serviceAccountProvisioner{client: server.Client()}, map[string]plugintest.ProvisionCase{
+		"exchange": {
+			ItemFields: itemFields,
+			Settings:   settings,
+			Now:        now,
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: environment,
+				Cache:       expectedCache,
+			},
+		},
+	})
+	assert.Equal(t, 1, requests)
+
+	plugintest.TestProvisioner(t, serviceAccountProvisioner{client: server.Client()}, map[string]plugintest.ProvisionCase{
+		"cached": {
+			ItemFields: itemFields,
+			Settings:   settings,
+			Now:        now.Add(30 * time.Minute),
+			Cache: sdk.CacheState{
+				cacheKey: expectedCache.Puts[cacheKey],
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: environment,
+			},
+		},
+	})
+	assert.Equal(t, 1, requests, "the cached access token should be used")
+}
+
+func TestServiceAccountKeyImporter(t *testing.T) {
+	key := plugintest.LoadFixture(t, "service-account.json")
+
+	plugintest.TestImporter(t, ServiceAccountKey().Importer, map[string]plugintest.ImportCase{
+		"application default credentials": {
+			Files: map[string]string{
+				"~/.config/gcloud/application_default_credentials.json": key,
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Credentials: key,
+					},
+					NameHint: "deployer",
+				},
+			},
+		},
+		"user account application default credentials": {
+			Files: map[string]string{
+				"~/.config/gcloud/application_default_credentials.json": plugintest.LoadFixture(t, "authorized-user.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{},
+		},
+		"activated service accounts": {
+			Files: map[string]string{
+				"~/.config/gcloud/legacy_credentials/deployer@acme-production.iam.gserviceaccount.com/adc.json": key,
+				"~/.config/gcloud/legacy_credentials/wendy@example.com/adc.json":                                plugintest.LoadFixture(t, "authorized-user.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Credentials: key,
+					},
+					NameHint: "deployer",
+				},
+			},
+		},
+		"GOOGLE_APPLICATION_CREDENTIALS": {
+			Files: map[string]string{
+				"~/keys/deployer.json": key,
+			},
+			RootedEnvironment: map[string]string{
+				"GOOGLE_APPLICATION_CREDENTIALS": "~/keys/deployer.json",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Credentials: key,
+					},
+					NameHint: "deployer",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,72 @@
+package gem
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func APIKey() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIKey,
+		DocsURL:       sdk.URL("https://guides.rubygems.org/api-key-scopes/"),
+		ManagementURL: sdk.URL("https://rubygems.org/profile/api_keys"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.APIKey,
+				MarkdownDescription: "API key used to push and manage gems on RubyGems.org.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 57,
+					Prefix: "rubygems_",
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+					},
+				},
+			},
+		},
+		// RubyGems reads GEM_HOST_API_KEY since version 3.0. Older versions only read the API key from
+		// ~/.gem/credentials, which isn't provisioned: it's shared with the user's own 'gem signin' and with
+		// concurrent invocations, so provisioning it could overwrite or delete their credentials.
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryGemCredentialsFile(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"GEM_HOST_API_KEY": fieldname.APIKey,
+}
+
+// rubygemsAPIKeyName is the key under which the credentials file holds the API key for RubyGems.org.
+const rubygemsAPIKeyName = ":rubygems_api_key"
+
+// TryGemCredentialsFile imports the RubyGems.org API key from the credentials file written by 'gem signin'.
+func TryGemCredentialsFile() sdk.Importer {
+	return importer.TryFile("~/.gem/credentials", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var credentials map[string]string
+		if err := contents.ToYAML(&credentials); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		apiKey := credentials[rubygemsAPIKeyName]
+		if apiKey == "" {
+			return
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.APIKey: apiKey,
+			},
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package gem
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPIKeyProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, APIKey().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.APIKey: "rubygems_3f9a1c7e5b2d8f4a6c0e9b7d5f3a1c8e2b4d6f8a0example",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"GEM_HOST_API_KEY": "rubygems_3f9a1c7e5b2d8f4a6c0e9b7d5f3a1c8e2b4d6f8a0example",
+				},
+			},
+		},
+	})
+}
+
+func TestAPIKeyImporter(t *testing.T) {
+	expectedCandidates := []sdk.ImportCandidate{
+		{
+			Fields: map[sdk.FieldName]string{
+				fieldname.APIKey: "rubygems_3f9a1c7e5b2d8f4a6c0e9b7d5f3a1c8e2b4d6f8a0example",
+			},
+		},
+	}
+
+	plugintest.TestImporter(t, APIKey().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"GEM_HOST_API_KEY": "rubygems_3f9a1c7e5b2d8f4a6c0e9b7d5f3a1c8e2b4d6f8a0example",
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"credentials file": {
+			Files: map[string]string{
+				"~/.gem/credentials": plugintest.LoadFixture(t, "credentials"),
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"credentials file with multiple hosts": {
+			Files: map[string]string{
+				"~/.gem/credentials": plugintest.LoadFixture(t, "credentials-multiple-hosts"),
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+	})
+}
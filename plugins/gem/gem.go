@@ -0,0 +1,29 @@
+package gem
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func GemCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "RubyGems CLI",
+		Runs:    []string{"gem"},
+		DocsURL: sdk.URL("https://guides.rubygems.org/command-reference/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.IfAny(
+				needsauth.ForCommand("push"),
+				needsauth.ForCommand("yank"),
+				needsauth.ForCommand("owner"),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.APIKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package gem
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "gem",
+		Platform: schema.PlatformInfo{
+			Name:     "RubyGems",
+			Homepage: sdk.URL("https://rubygems.org"),
+		},
+		Credentials: []schema.CredentialType{
+			APIKey(),
+		},
+		Executables: []schema.Executable{
+			GemCLI(),
+		},
+	}
+}
@@ -6,10 +6,10 @@ import (
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
-	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/1Password/shell-plugins/sdk/verify"
 )
 
 func PersonalAccessToken() schema.CredentialType {
@@ -34,11 +34,12 @@ func PersonalAccessToken() schema.CredentialType {
 			},
 			{
 				Name:                fieldname.Host,
-				MarkdownDescription: "The GitHub host to authenticate to. Defaults to 'github.com'.",
+				MarkdownDescription: "The GitHub host to authenticate to, such as the hostname of a GitHub Enterprise Server instance. Defaults to 'github.com'.",
 				Optional:            true,
 			},
 		},
-		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		DefaultProvisioner: hostProvisioner{},
+		Verifier:           verify.HTTP(verifyRequest),
 		Importer: importer.TryAll(
 			importer.TryEnvVarPair(defaultEnvVarMapping),
 			importer.TryAllEnvVars(fieldname.Token, "GH_TOKEN", "GITHUB_PAT"),
@@ -67,7 +68,15 @@ var defaultEnvVarMapping = map[string]sdk.FieldName{
 	"GITHUB_TOKEN": fieldname.Token,
 }
 
+// Config is the configuration of a single host in the GitHub CLI's hosts.yml. Since GitHub CLI 2.40 a host can have
+// multiple accounts listed under 'users', next to the active account at the top level.
 type Config struct {
+	User  string                `yaml:"user"`
+	Token string                `yaml:"oauth_token"`
+	Users map[string]UserConfig `yaml:"users"`
+}
+
+type UserConfig struct {
 	Token string `yaml:"oauth_token"`
 }
 
@@ -80,20 +89,43 @@ func TryGitHubConfigFile() sdk.Importer {
 		}
 
 		for host, values := range config {
-			if strings.HasPrefix(values.Token, "ghp_") {
+			tokens := map[string]string{}
+			if values.Token != "" {
+				tokens[values.Token] = values.User
+			}
+			for user, userValues := range values.Users {
+				if userValues.Token != "" {
+					tokens[userValues.Token] = user
+				}
+			}
+
+			for token, user := range tokens {
+				if !isPersonalAccessToken(token) {
+					continue
+				}
+
 				candidate := sdk.ImportCandidate{
 					Fields: map[sdk.FieldName]string{
-						fieldname.Token: values.Token,
+						fieldname.Token: token,
 					},
 				}
 
-				if host != "github.com" {
+				if normalizeHost(host) != defaultHost {
 					candidate.NameHint = host
 					candidate.Fields[fieldname.Host] = host
 				}
+				if len(tokens) > 1 && user != "" {
+					candidate.NameHint = importer.SanitizeNameHint(strings.TrimSpace(candidate.NameHint + " " + user))
+				}
 
 				out.AddCandidate(candidate)
 			}
 		}
 	})
 }
+
+// isPersonalAccessToken reports whether the token is a classic or fine-grained personal access token. OAuth tokens
+// (gho_) obtained through 'gh auth login' are bound to the GitHub CLI app and are not imported.
+func isPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, "ghp_") || strings.HasPrefix(token, "github_pat_")
+}
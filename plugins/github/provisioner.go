@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const defaultHost = "github.com"
+
+// hostProvisioner provisions the token in the environment variable that the GitHub CLI reads for the item's
+// host: GITHUB_TOKEN for github.com, and GH_ENTERPRISE_TOKEN together with GH_HOST for GitHub Enterprise Server.
+type hostProvisioner struct{}
+
+func (p hostProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	host := normalizeHost(in.ItemFields[fieldname.Host])
+	if host == defaultHost {
+		out.AddEnvVar("GITHUB_TOKEN", in.ItemFields[fieldname.Token])
+		return
+	}
+
+	out.AddEnvVar("GH_HOST", host)
+	out.AddEnvVar("GH_ENTERPRISE_TOKEN", in.ItemFields[fieldname.Token])
+}
+
+func (p hostProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p hostProvisioner) Description() string {
+	return "Provision environment variables: GITHUB_TOKEN, or GH_HOST and GH_ENTERPRISE_TOKEN for GitHub Enterprise Server"
+}
+
+// normalizeHost turns the value of the host field into a bare hostname, so that both 'github.acme.com' and
+// 'https://github.acme.com/' are accepted. An empty host means github.com.
+func normalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host = strings.TrimSuffix(host, "/")
+	if host == "" || host == "api.github.com" {
+		return defaultHost
+	}
+	return host
+}
+
+// apiURL returns the REST API base URL for the specified host. GitHub Enterprise Server serves its API under
+// /api/v3 on the host itself.
+func apiURL(host string) string {
+	host = normalizeHost(host)
+	if host == defaultHost {
+		return "https://api.github.com"
+	}
+	return fmt.Sprintf("https://%s/api/v3", host)
+}
+
+// verifyRequest builds the request that checks the token against the /user endpoint of the item's host.
+func verifyRequest(ctx context.Context, in sdk.VerifyInput) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL(in.ItemFields[fieldname.Host])+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+in.ItemFields[fieldname.Token])
+	return req, nil
+}
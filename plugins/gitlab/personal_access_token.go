@@ -5,10 +5,10 @@ import (
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
-	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/1Password/shell-plugins/sdk/verify"
 )
 
 func PersonalAccessToken() schema.CredentialType {
@@ -33,7 +33,7 @@ func PersonalAccessToken() schema.CredentialType {
 			},
 			{
 				Name:                fieldname.Host,
-				MarkdownDescription: "URL where GitLab is hosted. Defaults to 'https://gitlab.com'.",
+				MarkdownDescription: "URL where GitLab is hosted, such as the address of a self-managed instance. Defaults to 'https://gitlab.com'.",
 				Optional:            true,
 			},
 			{
@@ -42,7 +42,8 @@ func PersonalAccessToken() schema.CredentialType {
 				Optional:            true,
 			},
 		},
-		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		DefaultProvisioner: hostProvisioner{},
+		Verifier:           verify.HTTP(verifyRequest),
 		Importer: importer.TryAll(
 			importer.TryEnvVarPair(defaultEnvVarMapping),
 			TryGlabConfigFile(),
@@ -74,12 +75,15 @@ func TryGlabConfigFile() sdk.Importer {
 			}
 
 			nameHint := ""
-			if hostname != "gitlab.com" {
+			if hostname != defaultHost {
 				fields[fieldname.Host] = hostname
+				if hostConfig.APIProtocol == "http" {
+					fields[fieldname.Host] = "http://" + hostname
+				}
 				nameHint = hostname
 			}
 
-			if hostConfig.APIHost != "" && hostConfig.APIHost != "gitlab.com" {
+			if hostConfig.APIHost != "" && hostConfig.APIHost != defaultHost && hostConfig.APIHost != hostname {
 				fields[fieldname.APIHost] = hostConfig.APIHost
 			}
 
@@ -96,6 +100,7 @@ type GlabConfig struct {
 }
 
 type GlabHost struct {
-	Token   string `yaml:"token"`
-	APIHost string `yaml:"api_host"`
+	Token       string `yaml:"token"`
+	APIHost     string `yaml:"api_host"`
+	APIProtocol string `yaml:"api_protocol"`
 }
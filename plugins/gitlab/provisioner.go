@@ -0,0 +1,63 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const defaultHost = "gitlab.com"
+
+// hostProvisioner provisions the token together with the host it belongs to. glab sends GITLAB_TOKEN to whichever
+// host it resolves from the current repository, so GITLAB_HOST is always set to keep a gitlab.com token from being
+// sent to a self-managed instance, and vice versa.
+type hostProvisioner struct{}
+
+func (p hostProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	out.AddEnvVar("GITLAB_TOKEN", in.ItemFields[fieldname.Token])
+	out.AddEnvVar("GITLAB_HOST", host(in.ItemFields))
+	if apiHost := in.ItemFields[fieldname.APIHost]; apiHost != "" {
+		out.AddEnvVar("GITLAB_API_HOST", apiHost)
+	}
+}
+
+func (p hostProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p hostProvisioner) Description() string {
+	return "Provision environment variables: GITLAB_TOKEN, GITLAB_HOST and GITLAB_API_HOST"
+}
+
+// host returns the host of the GitLab instance the item belongs to, defaulting to gitlab.com.
+func host(fields map[sdk.FieldName]string) string {
+	if host := strings.TrimSuffix(strings.TrimSpace(fields[fieldname.Host]), "/"); host != "" {
+		return host
+	}
+	return defaultHost
+}
+
+// apiURL returns the REST API base URL of the GitLab instance the item belongs to, preferring the API host if set.
+func apiURL(fields map[sdk.FieldName]string) string {
+	apiHost := strings.TrimSuffix(strings.TrimSpace(fields[fieldname.APIHost]), "/")
+	if apiHost == "" {
+		apiHost = host(fields)
+	}
+	if !strings.HasPrefix(apiHost, "http://") && !strings.HasPrefix(apiHost, "https://") {
+		apiHost = "https://" + apiHost
+	}
+	return apiHost + "/api/v4"
+}
+
+// verifyRequest builds the request that checks the token against the /user endpoint of the item's instance.
+func verifyRequest(ctx context.Context, in sdk.VerifyInput) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL(in.ItemFields)+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", in.ItemFields[fieldname.Token])
+	return req, nil
+}
@@ -0,0 +1,51 @@
+package goreleaser
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func GoReleaserCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "GoReleaser",
+		Runs:    []string{"goreleaser"},
+		DocsURL: sdk.URL("https://goreleaser.com/cmd/goreleaser/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.IfAny(
+				needsauth.ForCommand("release"),
+				needsauth.ForCommand("publish"),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name:        credname.PersonalAccessToken,
+				Plugin:      "github",
+				Description: "Token used to publish releases to GitHub",
+				Provisioner: provision.EnvVars(map[string]sdk.FieldName{
+					"GITHUB_TOKEN": fieldname.Token,
+				}),
+				Optional: true,
+			},
+			{
+				Name:        credname.PersonalAccessToken,
+				Plugin:      "gitlab",
+				Description: "Token used to publish releases to GitLab",
+				Provisioner: provision.EnvVars(map[string]sdk.FieldName{
+					"GITLAB_TOKEN": fieldname.Token,
+				}),
+				Optional: true,
+			},
+			{
+				Name:        credname.Credentials,
+				Description: "Gemfury push token and key used to sign the release artifacts",
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package goreleaser
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestGoReleaserCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, GoReleaserCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"no for --help": {
+			Args:              []string{"--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for build": {
+			Args:              []string{"build", "--snapshot"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for check": {
+			Args:              []string{"check"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for release": {
+			Args:              []string{"release", "--clean"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for publish": {
+			Args:              []string{"publish", "--merge"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package goreleaser
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "goreleaser",
+		Platform: schema.PlatformInfo{
+			Name:     "GoReleaser",
+			Homepage: sdk.URL("https://goreleaser.com"),
+		},
+		Credentials: []schema.CredentialType{
+			ReleaseCredentials(),
+		},
+		Executables: []schema.Executable{
+			GoReleaserCLI(),
+		},
+	}
+}
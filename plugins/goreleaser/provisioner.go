@@ -0,0 +1,49 @@
+package goreleaser
+
+import (
+	"context"
+	"errors"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// releaseProvisioner provisions the Gemfury push token and the signing key. The signing key is written to a file
+// referenced by GPG_KEY_PATH, the environment variable the GoReleaser docs use for the 'key_file' of nFPM signatures.
+type releaseProvisioner struct {
+}
+
+var provisionedEnvVarMapping = map[string]sdk.FieldName{
+	"FURY_TOKEN":      fieldname.PushToken,
+	"GPG_FINGERPRINT": fieldname.Fingerprint,
+	"NFPM_PASSPHRASE": fieldname.Passphrase,
+}
+
+func (p releaseProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	_, hasPushToken := in.ItemFields[fieldname.PushToken]
+	signingKey, hasSigningKey := in.ItemFields[fieldname.SigningKey]
+	if !hasPushToken && !hasSigningKey {
+		out.AddError(errors.New("either a push token or a signing key is required"))
+		return
+	}
+
+	for envVarName, fieldName := range provisionedEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
+
+	if hasSigningKey {
+		path := in.FromTempDir("signing-key.asc")
+		out.AddSecretFile(path, []byte(signingKey))
+		out.AddEnvVar("GPG_KEY_PATH", path)
+	}
+}
+
+func (p releaseProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p releaseProvisioner) Description() string {
+	return "Provision environment variables FURY_TOKEN, GPG_FINGERPRINT, NFPM_PASSPHRASE and GPG_KEY_PATH with the Gemfury push token and signing key"
+}
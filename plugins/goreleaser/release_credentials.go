@@ -0,0 +1,48 @@
+package goreleaser
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func ReleaseCredentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://goreleaser.com/customization/sign/"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.PushToken,
+				MarkdownDescription: "Gemfury push token used to publish packages with the 'furies' publisher.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.SigningKey,
+				MarkdownDescription: "ASCII-armored GPG private key used to sign packages and artifacts.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Fingerprint,
+				MarkdownDescription: "Fingerprint of the signing key, passed to GPG as the key to sign with.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Passphrase,
+				MarkdownDescription: "Passphrase of the signing key.",
+				Secret:              true,
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: releaseProvisioner{},
+		Importer:           importer.TryEnvVarPair(defaultEnvVarMapping),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"FURY_TOKEN": fieldname.PushToken,
+}
@@ -0,0 +1,66 @@
+package gradle
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func Credentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://docs.gradle.org/current/userguide/build_environment.html#sec:project_properties"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name: fieldname.Repository,
+				MarkdownDescription: "The name of the repository, as passed to `credentials(PasswordCredentials)`, e.g. 'mavenCentral'. " +
+					"The credentials are provisioned as the '<name>Username' and '<name>Password' project properties.",
+				Optional: true,
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The username used to authenticate to the repository.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Password,
+				MarkdownDescription: "The password or token used to authenticate to the repository.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.SigningKey,
+				MarkdownDescription: "The ASCII-armored PGP private key used to sign the publications, provisioned as 'signingInMemoryKey'.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.SigningKeyID,
+				MarkdownDescription: "The ID of the signing key, provisioned as 'signingInMemoryKeyId'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Passphrase,
+				MarkdownDescription: "The passphrase of the signing key, provisioned as 'signingInMemoryKeyPassword'.",
+				Secret:              true,
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: gradleProvisioner{},
+		Importer: importer.TryAll(
+			TryGradlePropertiesFile(),
+		),
+	}
+}
+
+// signingProperties maps the project properties for in-memory signing keys to the fields that hold them.
+// The property names follow the convention of the gradle-maven-publish plugin, and can be used with
+// Gradle's own signing plugin through `useInMemoryPgpKeys`.
+var signingProperties = map[string]sdk.FieldName{
+	"signingInMemoryKey":         fieldname.SigningKey,
+	"signingInMemoryKeyId":       fieldname.SigningKeyID,
+	"signingInMemoryKeyPassword": fieldname.Passphrase,
+}
@@ -0,0 +1,27 @@
+package gradle
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func GradleCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Gradle",
+		Runs:    []string{"gradle"},
+		DocsURL: sdk.URL("https://docs.gradle.org/current/userguide/command_line_interface.html"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("--stop"),
+			needsauth.NotWhenContainsArgs("--status"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.Credentials,
+			},
+		},
+	}
+}
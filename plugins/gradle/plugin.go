@@ -0,0 +1,22 @@
+package gradle
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "gradle",
+		Platform: schema.PlatformInfo{
+			Name:     "Gradle",
+			Homepage: sdk.URL("https://gradle.org"),
+		},
+		Credentials: []schema.CredentialType{
+			Credentials(),
+		},
+		Executables: []schema.Executable{
+			GradleCLI(),
+		},
+	}
+}
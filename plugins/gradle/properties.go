@@ -0,0 +1,131 @@
+package gradle
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// TryGradlePropertiesFile imports the repository credentials and signing key from the user's gradle.properties.
+// Every pair of '<name>Username' and '<name>Password' properties results in a candidate for that repository,
+// which also holds the signing key if there is one.
+func TryGradlePropertiesFile() sdk.Importer {
+	return importer.TryFile("~/.gradle/gradle.properties", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		properties := parseProperties(contents.ToString())
+
+		signingFields := make(map[sdk.FieldName]string)
+		for property, fieldName := range signingProperties {
+			if value := properties[property]; value != "" {
+				signingFields[fieldName] = value
+			}
+		}
+
+		var repositories []string
+		for property, value := range properties {
+			repository := strings.TrimSuffix(property, "Username")
+			if repository == property || repository == "" || value == "" || properties[repository+"Password"] == "" {
+				continue
+			}
+			repositories = append(repositories, repository)
+		}
+		sort.Strings(repositories)
+
+		for _, repository := range repositories {
+			fields := map[sdk.FieldName]string{
+				fieldname.Repository: repository,
+				fieldname.Username:   properties[repository+"Username"],
+				fieldname.Password:   properties[repository+"Password"],
+			}
+			for fieldName, value := range signingFields {
+				fields[fieldName] = value
+			}
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields:   fields,
+				NameHint: importer.SanitizeNameHint(repository),
+			})
+		}
+
+		if len(repositories) == 0 && signingFields[fieldname.SigningKey] != "" {
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: signingFields,
+			})
+		}
+	})
+}
+
+// parseProperties parses the contents of a Java properties file, as described in
+// https://docs.oracle.com/javase/8/docs/api/java/util/Properties.html#load-java.io.Reader-
+// Unicode escapes are not supported, since they don't occur in the values this plugin imports.
+func parseProperties(contents string) map[string]string {
+	properties := make(map[string]string)
+
+	var logicalLine string
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimLeft(strings.TrimSuffix(line, "\r"), " \t\f")
+		if logicalLine == "" && (line == "" || line[0] == '#' || line[0] == '!') {
+			continue
+		}
+
+		// A line that ends with an odd number of backslashes continues on the next line.
+		trailing := len(line) - len(strings.TrimRight(line, "\\"))
+		if trailing%2 == 1 {
+			logicalLine += line[:len(line)-1]
+			continue
+		}
+		logicalLine += line
+
+		key, value := splitProperty(logicalLine)
+		properties[unescapeProperty(key)] = unescapeProperty(value)
+		logicalLine = ""
+	}
+
+	return properties
+}
+
+// splitProperty splits the line on the first unescaped '=', ':' or whitespace.
+func splitProperty(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':', ' ', '\t', '\f':
+			value := strings.TrimLeft(line[i+1:], " \t\f")
+			if line[i] == ' ' || line[i] == '\t' || line[i] == '\f' {
+				// Whitespace may be followed by an actual separator.
+				if value != "" && (value[0] == '=' || value[0] == ':') {
+					value = strings.TrimLeft(value[1:], " \t\f")
+				}
+			}
+			return line[:i], value
+		}
+	}
+	return line, ""
+}
+
+func unescapeProperty(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package gradle
+
+import (
+	"context"
+	"errors"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// projectPropertyPrefix is the prefix of environment variables that Gradle turns into project properties.
+const projectPropertyPrefix = "ORG_GRADLE_PROJECT_"
+
+// gradleProvisioner provisions the repository credentials and signing key as project properties,
+// through ORG_GRADLE_PROJECT_* environment variables.
+type gradleProvisioner struct {
+}
+
+func (p gradleProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	provisioned := false
+
+	if repository, ok := in.ItemFields[fieldname.Repository]; ok {
+		username, hasUsername := in.ItemFields[fieldname.Username]
+		password, hasPassword := in.ItemFields[fieldname.Password]
+		if !hasUsername || !hasPassword {
+			out.AddError(errors.New("a username and password are required to authenticate to the repository"))
+			return
+		}
+		out.AddEnvVar(projectPropertyPrefix+repository+"Username", username)
+		out.AddEnvVar(projectPropertyPrefix+repository+"Password", password)
+		provisioned = true
+	}
+
+	for property, fieldName := range signingProperties {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(projectPropertyPrefix+property, value)
+			provisioned = true
+		}
+	}
+
+	if !provisioned {
+		out.AddError(errors.New("either repository credentials or a signing key are required"))
+	}
+}
+
+func (p gradleProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p gradleProvisioner) Description() string {
+	return "Provision environment variables ORG_GRADLE_PROJECT_* with the repository credentials and signing key"
+}
@@ -2,6 +2,7 @@ package hcloud
 
 import (
 	"context"
+	"os"
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
@@ -35,6 +36,7 @@ func APIToken() schema.CredentialType {
 		Importer: importer.TryAll(
 			importer.TryEnvVarPair(defaultEnvVarMapping),
 			TryHetznerCloudConfigFile(),
+			TryHetznerCloudConfigFileFromEnvVar(),
 		)}
 }
 
@@ -43,22 +45,51 @@ var defaultEnvVarMapping = map[string]sdk.FieldName{
 }
 
 func TryHetznerCloudConfigFile() sdk.Importer {
-	return importer.TryFile("~/.config/hcloud/cli.toml", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
-		var config Config
-		if err := contents.ToTOML(&config); err != nil {
-			out.AddError(err)
+	return importer.TryFile("~/.config/hcloud/cli.toml", importContexts)
+}
+
+// TryHetznerCloudConfigFileFromEnvVar imports the contexts from the config file that HCLOUD_CONFIG points to,
+// if it's set to a custom location.
+func TryHetznerCloudConfigFileFromEnvVar() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		path := os.Getenv("HCLOUD_CONFIG")
+		if path == "" {
 			return
 		}
 
-		for _, configContext := range config.Contexts {
-			out.AddCandidate(sdk.ImportCandidate{
-				Fields: map[sdk.FieldName]string{
-					fieldname.Token: configContext.Token,
-				},
-				NameHint: importer.SanitizeNameHint(configContext.Name),
-			})
+		attempt := out.NewAttempt(importer.SourceFile(path))
+		contents, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			return
+		} else if err != nil {
+			attempt.AddError(err)
+			return
 		}
-	})
+		importContexts(ctx, contents, in, attempt)
+	}
+}
+
+// importContexts adds a candidate for every context in the config file, named after the context, so that users
+// with several Hetzner Cloud projects get an item per project.
+func importContexts(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+	var config Config
+	if err := contents.ToTOML(&config); err != nil {
+		out.AddError(err)
+		return
+	}
+
+	for _, configContext := range config.Contexts {
+		if configContext.Token == "" {
+			continue
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.Token: configContext.Token,
+			},
+			NameHint: importer.SanitizeNameHint(configContext.Name),
+		})
+	}
 }
 
 type Config struct {
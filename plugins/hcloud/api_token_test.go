@@ -22,5 +22,34 @@ func TestImportingTheHetznerConfig(t *testing.T) {
 				{Fields: expectedFields, NameHint: ""},
 			},
 		},
+		"Hcloud config file with multiple contexts": {
+			Files: map[string]string{
+				"~/.config/hcloud/cli.toml": plugintest.LoadFixture(t, "hcloud-multiple-contexts.toml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{Fields: expectedFields, NameHint: "production"},
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token: "Kq8WbT3nRzLm5YpXv2JdHs9FgC4aNe7UoB1iMl6QwZxEyS0tVr3kPuGjDEXAMPLE",
+					},
+					NameHint: "staging",
+				},
+			},
+		},
+		"HCLOUD_CONFIG": {
+			Files: map[string]string{
+				"~/projects/hcloud.toml": plugintest.LoadFixture(t, "hcloud.toml"),
+			},
+			RootedEnvironment: map[string]string{
+				"HCLOUD_CONFIG": "~/projects/hcloud.toml",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{Fields: expectedFields, NameHint: ""},
+			},
+		},
 	})
 }
+
+func FuzzHetznerCloudConfigFile(f *testing.F) {
+	plugintest.FuzzImporter(f, TryHetznerCloudConfigFile(), "~/.config/hcloud/cli.toml", plugintest.LoadFixture(f, "hcloud.toml"))
+}
@@ -0,0 +1,11 @@
+package hcloud
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestHetznerCloudSetupDocs(t *testing.T) {
+	plugintest.TestSetupDocs(t, New(), "links.txt")
+}
@@ -0,0 +1,44 @@
+package helm
+
+import (
+	"regexp"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+// ociReference matches chart references that point to an OCI registry.
+var ociReference = regexp.MustCompile(`^oci://`)
+
+func HelmCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Helm CLI",
+		Runs:    []string{"helm"},
+		DocsURL: sdk.URL("https://helm.sh/docs/topics/registries/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.IfAny(
+				needsauth.ForCommand("registry", "login"),
+				needsauth.ForCommand("push"),
+				needsauth.IfAll(
+					needsauth.IfAny(
+						needsauth.ForCommand("pull"),
+						needsauth.ForCommand("install"),
+						needsauth.ForCommand("upgrade"),
+						needsauth.ForCommand("template"),
+						needsauth.ForCommand("show"),
+					),
+					needsauth.IfArgsMatch(ociReference),
+				),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.RegistryCredentials,
+			},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestHelmNeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, HelmCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for registry login": {
+			Args:              []string{"registry", "login", "ghcr.io"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for push": {
+			Args:              []string{"push", "mychart-0.1.0.tgz", "oci://ghcr.io/acme/charts"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for pull from an OCI registry": {
+			Args:              []string{"pull", "oci://ghcr.io/acme/charts/mychart", "--version", "0.1.0"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for install from an OCI registry": {
+			Args:              []string{"install", "myrelease", "oci://ghcr.io/acme/charts/mychart"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for pull from a chart repository": {
+			Args:              []string{"pull", "bitnami/nginx"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for list": {
+			Args:              []string{"list", "--all-namespaces"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for registry login help": {
+			Args:              []string{"registry", "login", "--help"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
@@ -0,0 +1,106 @@
+package helm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func RegistryCredentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.RegistryCredentials,
+		DocsURL:       sdk.URL("https://helm.sh/docs/helm/helm_registry_login/"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Host,
+				MarkdownDescription: "The hostname of the OCI registry, e.g. 'ghcr.io'.",
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The username used to authenticate to the registry.",
+			},
+			{
+				Name:                fieldname.Password,
+				MarkdownDescription: "The password or access token used to authenticate to the registry.",
+				Secret:              true,
+			},
+		},
+		DefaultProvisioner: provision.TempFile(registryConfig,
+			provision.Filename("registry.json"),
+			provision.SetPathAsEnvVar("HELM_REGISTRY_CONFIG"),
+		),
+		Importer: importer.TryAll(
+			TryHelmRegistryConfigFile("~/.config/helm/registry/config.json"),
+			importer.MacOnly(TryHelmRegistryConfigFile("~/Library/Preferences/helm/registry/config.json")),
+		),
+	}
+}
+
+// RegistryConfig is the Docker-style config file in which Helm stores registry credentials.
+type RegistryConfig struct {
+	Auths map[string]RegistryAuth `json:"auths"`
+}
+
+type RegistryAuth struct {
+	Auth string `json:"auth"`
+}
+
+func registryConfig(in sdk.ProvisionInput) ([]byte, error) {
+	auth := in.ItemFields[fieldname.Username] + ":" + in.ItemFields[fieldname.Password]
+	config := RegistryConfig{
+		Auths: map[string]RegistryAuth{
+			normalizeHost(in.ItemFields[fieldname.Host]): {
+				Auth: base64.StdEncoding.EncodeToString([]byte(auth)),
+			},
+		},
+	}
+	return json.MarshalIndent(config, "", "\t")
+}
+
+// normalizeHost strips the scheme that is sometimes included when copying the registry address.
+func normalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(host, "oci://")
+	host = strings.TrimPrefix(host, "https://")
+	return strings.TrimSuffix(host, "/")
+}
+
+// TryHelmRegistryConfigFile imports the credentials of every registry that was logged in to with 'helm registry login'.
+func TryHelmRegistryConfigFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config RegistryConfig
+		if err := contents.ToJSON(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for host, registryAuth := range config.Auths {
+			decoded, err := base64.StdEncoding.DecodeString(registryAuth.Auth)
+			if err != nil {
+				continue
+			}
+			username, password, found := strings.Cut(string(decoded), ":")
+			if !found || username == "" || password == "" {
+				continue
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.Host:     host,
+					fieldname.Username: username,
+					fieldname.Password: password,
+				},
+				NameHint: importer.SanitizeNameHint(host),
+			})
+		}
+	})
+}
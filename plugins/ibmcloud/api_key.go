@@ -0,0 +1,104 @@
+package ibmcloud
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func APIKey() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIKey,
+		DocsURL:       sdk.URL("https://cloud.ibm.com/docs/account?topic=account-userapikey"),
+		ManagementURL: sdk.URL("https://cloud.ibm.com/iam/apikeys"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.APIKey,
+				MarkdownDescription: "API Key used to authenticate to IBM Cloud.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 44,
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'-', '_'},
+					},
+				},
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			importer.TryAllEnvVars(fieldname.APIKey, "IC_API_KEY", "BLUEMIX_API_KEY"),
+			TryIBMCloudConfigFile(),
+			TryAPIKeyFile("~/apikey.json"),
+			TryAPIKeyFile("~/Downloads/apikey.json"),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"IBMCLOUD_API_KEY": fieldname.APIKey,
+}
+
+// TryIBMCloudConfigFile imports the API key that the IBM Cloud CLI stores after logging in with '--apikey'.
+func TryIBMCloudConfigFile() sdk.Importer {
+	return importer.TryFile("~/.bluemix/config.json", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config Config
+		if err := contents.ToJSON(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		if config.APIKey == "" {
+			return
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.APIKey: config.APIKey,
+			},
+			NameHint: importer.SanitizeNameHint(config.Account.Name),
+		})
+	})
+}
+
+type Config struct {
+	APIKey  string `json:"APIKey"`
+	Account struct {
+		Name string `json:"Name"`
+	} `json:"Account"`
+}
+
+// TryAPIKeyFile imports the API key file that can be downloaded when creating an API key in the IBM Cloud console.
+func TryAPIKeyFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var file APIKeyFile
+		if err := contents.ToJSON(&file); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		if file.APIKey == "" {
+			return
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.APIKey: file.APIKey,
+			},
+			NameHint: importer.SanitizeNameHint(file.Name),
+		})
+	})
+}
+
+type APIKeyFile struct {
+	Name   string `json:"name"`
+	APIKey string `json:"apikey"`
+}
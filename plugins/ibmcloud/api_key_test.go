@@ -0,0 +1,79 @@
+package ibmcloud
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPIKeyProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, APIKey().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.APIKey: "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"IBMCLOUD_API_KEY": "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+				},
+			},
+		},
+	})
+}
+
+func TestAPIKeyImporter(t *testing.T) {
+	plugintest.TestImporter(t, APIKey().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"IBMCLOUD_API_KEY": "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.APIKey: "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+					},
+				},
+			},
+		},
+		"legacy environment variable": {
+			Environment: map[string]string{
+				"IC_API_KEY": "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.APIKey: "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+					},
+				},
+			},
+		},
+		"config file": {
+			Files: map[string]string{
+				"~/.bluemix/config.json": plugintest.LoadFixture(t, "config.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.APIKey: "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+					},
+					NameHint: "Acme Corp",
+				},
+			},
+		},
+		"downloaded API key file": {
+			Files: map[string]string{
+				"~/Downloads/apikey.json": plugintest.LoadFixture(t, "apikey.json"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.APIKey: "x7Qw2eR9tY4uI1oP3aS6dF8gH0jK5lZ-cV_bNEXAMPLE",
+					},
+					NameHint: "deploy-key",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package jenkins
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func APIToken() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIToken,
+		DocsURL:       sdk.URL("https://www.jenkins.io/doc/book/system-administration/authenticating-scripted-clients/"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.URL,
+				MarkdownDescription: "The URL of the Jenkins controller, e.g. 'https://jenkins.acme.com'.",
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The Jenkins user ID the token belongs to.",
+			},
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "API token used to authenticate to Jenkins.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 34,
+					Prefix: "11",
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+					},
+				},
+			},
+		},
+		DefaultProvisioner: jenkinsProvisioner{},
+		Importer:           importer.TryEnvVarPair(defaultEnvVarMapping),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"JENKINS_URL":       fieldname.URL,
+	"JENKINS_USER_ID":   fieldname.Username,
+	"JENKINS_API_TOKEN": fieldname.Token,
+}
@@ -0,0 +1,72 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPITokenProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, APIToken().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.URL:      "https://jenkins.acme.com",
+				fieldname.Username: "wendy",
+				fieldname.Token:    "113f9c2e7b84d1f6a0c5e9b2d7fexample",
+			},
+			CommandLine: []string{"jenkins-cli", "build", "deploy", "-p", "ENV=prod"},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"JENKINS_URL":       "https://jenkins.acme.com",
+					"JENKINS_USER_ID":   "wendy",
+					"JENKINS_API_TOKEN": "113f9c2e7b84d1f6a0c5e9b2d7fexample",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/jenkins-auth": {
+						Contents: []byte("wendy:113f9c2e7b84d1f6a0c5e9b2d7fexample"),
+					},
+				},
+				CommandLine: []string{"jenkins-cli", "-auth", "@/tmp/jenkins-auth", "build", "deploy", "-p", "ENV=prod"},
+			},
+		},
+		"explicit -auth": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.URL:      "https://jenkins.acme.com",
+				fieldname.Username: "wendy",
+				fieldname.Token:    "113f9c2e7b84d1f6a0c5e9b2d7fexample",
+			},
+			CommandLine: []string{"jenkins-cli", "-auth", "@/home/wendy/.jenkins-auth", "who-am-i"},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"JENKINS_URL":       "https://jenkins.acme.com",
+					"JENKINS_USER_ID":   "wendy",
+					"JENKINS_API_TOKEN": "113f9c2e7b84d1f6a0c5e9b2d7fexample",
+				},
+				CommandLine: []string{"jenkins-cli", "-auth", "@/home/wendy/.jenkins-auth", "who-am-i"},
+			},
+		},
+	})
+}
+
+func TestAPITokenImporter(t *testing.T) {
+	plugintest.TestImporter(t, APIToken().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"JENKINS_URL":       "https://jenkins.acme.com",
+				"JENKINS_USER_ID":   "wendy",
+				"JENKINS_API_TOKEN": "113f9c2e7b84d1f6a0c5e9b2d7fexample",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.URL:      "https://jenkins.acme.com",
+						fieldname.Username: "wendy",
+						fieldname.Token:    "113f9c2e7b84d1f6a0c5e9b2d7fexample",
+					},
+				},
+			},
+		},
+	})
+}
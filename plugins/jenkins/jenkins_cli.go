@@ -0,0 +1,26 @@
+package jenkins
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+// JenkinsCLI is the 'jenkins-cli' wrapper around 'java -jar jenkins-cli.jar', which passes all args on to the jar.
+func JenkinsCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Jenkins CLI",
+		Runs:    []string{"jenkins-cli"},
+		DocsURL: sdk.URL("https://www.jenkins.io/doc/book/managing/cli/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.APIToken,
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package jenkins
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "jenkins",
+		Platform: schema.PlatformInfo{
+			Name:     "Jenkins",
+			Homepage: sdk.URL("https://www.jenkins.io"),
+		},
+		Credentials: []schema.CredentialType{
+			APIToken(),
+		},
+		Executables: []schema.Executable{
+			JenkinsCLI(),
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package jenkins
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// jenkinsProvisioner provisions the URL, user ID and API token as the environment variables read by jenkins-cli.jar.
+// The credentials are also written to a temporary file that is passed with '-auth @<file>', for CLI versions that
+// don't read the user ID and API token from the environment. Unlike '-auth user:token', this keeps the token
+// out of the process list.
+type jenkinsProvisioner struct {
+}
+
+func (p jenkinsProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	for envVarName, fieldName := range defaultEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
+
+	// Respect an '-auth' arg passed explicitly, and only pass the file when there's a command to pass it to.
+	if len(out.CommandLine) == 0 || containsArg(out.CommandLine[1:], "-auth") {
+		return
+	}
+
+	path := in.FromTempDir("jenkins-auth")
+	out.AddSecretFile(path, []byte(in.ItemFields[fieldname.Username]+":"+in.ItemFields[fieldname.Token]))
+
+	// jenkins-cli.jar only accepts options before the command, so the arg is inserted right after the executable.
+	commandLine := []string{out.CommandLine[0], "-auth", "@" + path}
+	out.CommandLine = append(commandLine, out.CommandLine[1:]...)
+}
+
+func (p jenkinsProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p jenkinsProvisioner) Description() string {
+	return "Provision environment variables JENKINS_URL, JENKINS_USER_ID and JENKINS_API_TOKEN, and a credentials file passed with '-auth'"
+}
+
+func containsArg(args []string, arg string) bool {
+	for _, a := range args {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
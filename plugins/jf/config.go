@@ -0,0 +1,277 @@
+package jf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const (
+	// configVersion is the version of the config file format written by JFrog CLI v2.
+	configVersion = "6"
+
+	configFilePrefix = "jfrog-cli.conf.v"
+
+	defaultServerID = "default"
+
+	// apiKeyPrefix is the prefix of Artifactory API keys. The CLI stores them in the password field of a server.
+	apiKeyPrefix = "AKC"
+)
+
+// Config holds the parts of the JFrog CLI config file that are relevant for authentication.
+type Config struct {
+	Servers []ServerDetails `json:"servers"`
+	Version string          `json:"version"`
+	Enc     bool            `json:"enc,omitempty"`
+}
+
+type ServerDetails struct {
+	URL               string `json:"url,omitempty"`
+	ArtifactoryURL    string `json:"artifactoryUrl,omitempty"`
+	DistributionURL   string `json:"distributionUrl,omitempty"`
+	XrayURL           string `json:"xrayUrl,omitempty"`
+	MissionControlURL string `json:"missionControlUrl,omitempty"`
+	PipelinesURL      string `json:"pipelinesUrl,omitempty"`
+	AccessURL         string `json:"accessUrl,omitempty"`
+	User              string `json:"user,omitempty"`
+	Password          string `json:"password,omitempty"`
+	AccessToken       string `json:"accessToken,omitempty"`
+	ServerID          string `json:"serverId"`
+	IsDefault         bool   `json:"isDefault"`
+}
+
+// jfrogConfigProvisioner provisions a copy of the user's JFrog CLI home directory with the server added to the config
+// file, and points JFROG_CLI_HOME_DIR to it.
+type jfrogConfigProvisioner struct {
+}
+
+func (p jfrogConfigProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	platformURL := platformURL(in.ItemFields[fieldname.URL])
+	server := ServerDetails{
+		URL:               platformURL + "/",
+		ArtifactoryURL:    platformURL + "/artifactory/",
+		DistributionURL:   platformURL + "/distribution/",
+		XrayURL:           platformURL + "/xray/",
+		MissionControlURL: platformURL + "/mc/",
+		PipelinesURL:      platformURL + "/pipelines/",
+		AccessURL:         platformURL + "/access/",
+		ServerID:          defaultServerID,
+		IsDefault:         true,
+	}
+	if serverID, ok := in.ItemFields[fieldname.ServerID]; ok {
+		server.ServerID = serverID
+	}
+
+	if accessToken, ok := in.ItemFields[fieldname.AccessToken]; ok {
+		server.AccessToken = accessToken
+	} else if apiKey, ok := in.ItemFields[fieldname.APIKey]; ok {
+		username, ok := in.ItemFields[fieldname.Username]
+		if !ok {
+			out.AddError(errors.New("a username is required to authenticate with an API key"))
+			return
+		}
+		server.User = username
+		server.Password = apiKey
+	} else {
+		out.AddError(errors.New("either an access token or an API key is required"))
+		return
+	}
+
+	homeDir := in.FromHomeDir(".jfrog")
+	existing, err := os.ReadFile(filepath.Join(homeDir, configFilePrefix+configVersion))
+	if err != nil && !os.IsNotExist(err) {
+		out.AddError(err)
+		return
+	}
+
+	contents, err := jfrogConfig(existing, server)
+	if err != nil {
+		out.AddError(fmt.Errorf("parsing JFrog CLI config file: %w", err))
+		return
+	}
+	out.AddSecretFile(in.FromTempDir(configFilePrefix+configVersion), contents)
+
+	// Copy the rest of the home directory along, such as the trusted certificates in security/. Logs and the
+	// dependencies cache are left where they are, and so are the installed plugins, since provisioned files
+	// can't be executable.
+	err = filepath.WalkDir(homeDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(homeDir, path)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			switch relPath {
+			case "logs", "dependencies", "plugins", "backup":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(relPath, configFilePrefix) || !entry.Type().IsRegular() {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		out.AddSecretFile(in.FromTempDir(relPath), contents)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		out.AddError(fmt.Errorf("copying JFrog CLI home directory: %w", err))
+		return
+	}
+
+	out.AddEnvVar("JFROG_CLI_HOME_DIR", in.TempDir)
+	if _, ok := os.LookupEnv("JFROG_CLI_DEPENDENCIES_DIR"); !ok {
+		out.AddEnvVar("JFROG_CLI_DEPENDENCIES_DIR", filepath.Join(homeDir, "dependencies"))
+	}
+}
+
+// jfrogConfig returns the contents of the existing JFrog CLI config file, with the server added as the default
+// server. A server with the same ID is replaced, while other servers and keys are kept as they are. The servers
+// of an encrypted config file can't be mixed with a plaintext one, so those are left out.
+func jfrogConfig(existing []byte, server ServerDetails) ([]byte, error) {
+	config := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(existing)) > 0 {
+		if err := json.Unmarshal(existing, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	var encrypted bool
+	if raw, ok := config["enc"]; ok {
+		if err := json.Unmarshal(raw, &encrypted); err != nil {
+			return nil, err
+		}
+	}
+
+	var servers []map[string]json.RawMessage
+	if raw, ok := config["servers"]; ok && !encrypted {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return nil, err
+		}
+	}
+
+	serverID, err := json.Marshal(server.ServerID)
+	if err != nil {
+		return nil, err
+	}
+	merged := make([]any, 0, len(servers)+1)
+	merged = append(merged, server)
+	for _, existingServer := range servers {
+		if bytes.Equal(existingServer["serverId"], serverID) {
+			continue
+		}
+		existingServer["isDefault"] = json.RawMessage("false")
+		merged = append(merged, existingServer)
+	}
+
+	for key, value := range map[string]any{"servers": merged, "version": configVersion} {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		config[key] = raw
+	}
+	delete(config, "enc")
+
+	return json.MarshalIndent(config, "", "  ")
+}
+
+func (p jfrogConfigProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p jfrogConfigProvisioner) Description() string {
+	return "Provision environment variable JFROG_CLI_HOME_DIR with a temporary copy of the home directory holding the JFrog Platform credentials"
+}
+
+// platformURL returns the URL of the JFrog Platform without a trailing slash, also accepting the
+// Artifactory URL, e.g. 'https://acme.jfrog.io/artifactory/'.
+func platformURL(url string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(url, "/"), "/artifactory")
+}
+
+// TryJFrogCLIConfig imports every server configured with 'jf config add' from the latest version of the
+// config file in ~/.jfrog. Older versions are left behind by the CLI when it migrates the config file.
+func TryJFrogCLIConfig() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		entries, err := os.ReadDir(in.FromHomeDir(".jfrog"))
+		if err != nil {
+			return
+		}
+
+		latestFile := ""
+		latestVersion := 0
+		for _, entry := range entries {
+			versionSuffix := strings.TrimPrefix(entry.Name(), configFilePrefix)
+			if entry.IsDir() || versionSuffix == entry.Name() {
+				continue
+			}
+			version, err := strconv.Atoi(versionSuffix)
+			if err != nil {
+				continue
+			}
+			if version > latestVersion {
+				latestFile = entry.Name()
+				latestVersion = version
+			}
+		}
+		if latestFile == "" {
+			return
+		}
+
+		importer.TryFile(filepath.Join("~/.jfrog", latestFile), func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+			var config Config
+			if err := contents.ToJSON(&config); err != nil {
+				out.AddError(err)
+				return
+			}
+
+			if config.Enc {
+				out.AddError(errors.New("the config file is encrypted with a master key and can't be imported"))
+				return
+			}
+
+			for _, server := range config.Servers {
+				if server.URL == "" {
+					continue
+				}
+
+				fields := map[sdk.FieldName]string{
+					fieldname.URL: strings.TrimSuffix(server.URL, "/"),
+				}
+				if server.AccessToken != "" {
+					fields[fieldname.AccessToken] = server.AccessToken
+				} else if strings.HasPrefix(server.Password, apiKeyPrefix) && server.User != "" {
+					fields[fieldname.Username] = server.User
+					fields[fieldname.APIKey] = server.Password
+				} else {
+					continue
+				}
+				if server.ServerID != "" && server.ServerID != defaultServerID {
+					fields[fieldname.ServerID] = server.ServerID
+				}
+
+				out.AddCandidate(sdk.ImportCandidate{
+					Fields:   fields,
+					NameHint: importer.SanitizeNameHint(server.ServerID),
+				})
+			}
+		})(ctx, in, out)
+	}
+}
@@ -0,0 +1,46 @@
+package jf
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func Credentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://jfrog.com/help/r/jfrog-cli/authentication"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.URL,
+				MarkdownDescription: "The URL of the JFrog Platform, e.g. 'https://acme.jfrog.io'.",
+			},
+			{
+				Name:                fieldname.AccessToken,
+				MarkdownDescription: "Access token used to authenticate to the JFrog Platform.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The JFrog user the API key belongs to.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.APIKey,
+				MarkdownDescription: "API key used to authenticate to Artifactory, if no access token is set.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.ServerID,
+				MarkdownDescription: "The server ID to configure, for scripts that pass '--server-id'. Defaults to 'default'.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: jfrogConfigProvisioner{},
+		Importer:           TryJFrogCLIConfig(),
+	}
+}
@@ -0,0 +1,138 @@
+package jf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, Credentials().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"access token": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.URL:         "https://acme.jfrog.io/artifactory/",
+				fieldname.AccessToken: "eyJ2ZXIiOiIyIiwidHlwIjoiSldUIiwiYWxnIjoiUlMyNTYifQ.EXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"JFROG_CLI_HOME_DIR":         "/tmp",
+					"JFROG_CLI_DEPENDENCIES_DIR": "~/.jfrog/dependencies",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/jfrog-cli.conf.v6": {
+						Contents: []byte(plugintest.LoadFixture(t, "jfrog-cli.conf.v6-generated")),
+					},
+				},
+			},
+		},
+		"API key without username": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.URL:    "https://artifacts.acme.com",
+				fieldname.APIKey: "AKCp8jQ8tAahqpT5JjZ4FRcQ3kcdTxTqQ9bWw5zN7vUXwEXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "a username is required to authenticate with an API key"}},
+				},
+			},
+		},
+		"no secret": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.URL: "https://acme.jfrog.io",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "either an access token or an API key is required"}},
+				},
+			},
+		},
+	})
+}
+
+func TestCredentialsProvisionerWithExistingHome(t *testing.T) {
+	homeDir := t.TempDir()
+	caCert := "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIUEXAMPLE\n-----END CERTIFICATE-----\n"
+	files := map[string]string{
+		"jfrog-cli.conf.v6":       plugintest.LoadFixture(t, "jfrog-cli.conf.v6"),
+		"jfrog-cli.conf.v5":       plugintest.LoadFixture(t, "jfrog-cli.conf.v5"),
+		"security/certs/ca.pem":   caCert,
+		"logs/jfrog-cli.log":      "[Info] Uploading artifacts",
+		"plugins/rt-fs/bin/rt-fs": "#!/bin/sh",
+	}
+	for path, contents := range files {
+		path = filepath.Join(homeDir, ".jfrog", path)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := sdk.ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]sdk.OutputFile),
+	}
+	Credentials().DefaultProvisioner.Provision(context.Background(), sdk.ProvisionInput{
+		HomeDir: homeDir,
+		TempDir: "/tmp",
+		ItemFields: map[sdk.FieldName]string{
+			fieldname.URL:         "https://acme.jfrog.io",
+			fieldname.AccessToken: "eyJ2ZXIiOiIyIiwidHlwIjoiSldUIiwiYWxnIjoiUlMyNTYifQ.ROTATED",
+			fieldname.ServerID:    "acme",
+		},
+	}, &out)
+
+	assert.Empty(t, out.Diagnostics.Errors)
+	assert.Equal(t, map[string]string{
+		"JFROG_CLI_HOME_DIR":         "/tmp",
+		"JFROG_CLI_DEPENDENCIES_DIR": filepath.Join(homeDir, ".jfrog", "dependencies"),
+	}, out.Environment)
+	assert.Equal(t, map[string]sdk.OutputFile{
+		"/tmp/jfrog-cli.conf.v6":     {Contents: []byte(strings.TrimSpace(plugintest.LoadFixture(t, "jfrog-cli.conf.v6-merged")))},
+		"/tmp/security/certs/ca.pem": {Contents: []byte(caCert)},
+	}, out.Files)
+}
+
+func TestCredentialsImporter(t *testing.T) {
+	plugintest.TestImporter(t, Credentials().Importer, map[string]plugintest.ImportCase{
+		"config file": {
+			Files: map[string]string{
+				"~/.jfrog/jfrog-cli.conf.v5": plugintest.LoadFixture(t, "jfrog-cli.conf.v5"),
+				"~/.jfrog/jfrog-cli.conf.v6": plugintest.LoadFixture(t, "jfrog-cli.conf.v6"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.URL:         "https://acme.jfrog.io",
+						fieldname.AccessToken: "eyJ2ZXIiOiIyIiwidHlwIjoiSldUIiwiYWxnIjoiUlMyNTYifQ.EXAMPLE",
+						fieldname.ServerID:    "acme",
+					},
+					NameHint: "acme",
+				},
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.URL:      "https://artifacts.acme.com",
+						fieldname.Username: "ci",
+						fieldname.APIKey:   "AKCp8jQ8tAahqpT5JjZ4FRcQ3kcdTxTqQ9bWw5zN7vUXwEXAMPLE",
+						fieldname.ServerID: "on-prem",
+					},
+					NameHint: "on-prem",
+				},
+			},
+		},
+		"encrypted config file": {
+			Files: map[string]string{
+				"~/.jfrog/jfrog-cli.conf.v6": `{"servers": [{"url": "https://acme.jfrog.io/", "accessToken": "ZW5jcnlwdGVk", "serverId": "acme"}], "version": "6", "enc": true}`,
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package jf
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func JFrogCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "JFrog CLI",
+		Runs:    []string{"jf"},
+		DocsURL: sdk.URL("https://jfrog.com/help/r/jfrog-cli/jfrog-cli"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			// These commands manage the local configuration or run the web login flow,
+			// which would only affect the temporary configuration provisioned by 1Password.
+			needsauth.Not(needsauth.ForCommand("config")),
+			needsauth.Not(needsauth.ForCommand("c")),
+			needsauth.Not(needsauth.ForCommand("login")),
+			needsauth.Not(needsauth.ForCommand("completion")),
+			needsauth.Not(needsauth.ForCommand("intro")),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.Credentials,
+			},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package jf
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestJFrogCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, JFrogCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"no for no args": {
+			Args:              []string{},
+			ExpectedNeedsAuth: false,
+		},
+		"no for help": {
+			Args:              []string{"--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for config": {
+			Args:              []string{"config", "show"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for config shorthand": {
+			Args:              []string{"c", "add"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for login": {
+			Args:              []string{"login"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for upload": {
+			Args:              []string{"rt", "upload", "build/*.jar", "libs-release-local/"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for audit": {
+			Args:              []string{"audit"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package jf
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "jf",
+		Platform: schema.PlatformInfo{
+			Name:     "JFrog",
+			Homepage: sdk.URL("https://jfrog.com"),
+		},
+		Credentials: []schema.CredentialType{
+			Credentials(),
+		},
+		Executables: []schema.Executable{
+			JFrogCLI(),
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package jira
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func APIToken() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIToken,
+		DocsURL:       sdk.URL("https://support.atlassian.com/atlassian-account/docs/manage-api-tokens-for-your-atlassian-account/"),
+		ManagementURL: sdk.URL("https://id.atlassian.com/manage-profile/security/api-tokens"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.URL,
+				MarkdownDescription: "The URL of the Jira site, e.g. 'https://acme.atlassian.net'.",
+			},
+			{
+				Name:                fieldname.Email,
+				MarkdownDescription: "The email address of the Atlassian account the token belongs to.",
+			},
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "API token used to authenticate to Jira.",
+				Secret:              true,
+			},
+		},
+		DefaultProvisioner: jiraProvisioner{},
+		Importer: importer.TryAll(
+			TryJiraCLIConfigFile(),
+			TryGoJiraConfigFile(),
+		),
+	}
+}
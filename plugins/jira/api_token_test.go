@@ -0,0 +1,76 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPITokenProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, APIToken().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.URL:   "https://acme.atlassian.net",
+				fieldname.Email: "wendy@acme.com",
+				fieldname.Token: "ATATT3xFfGF0T9qQk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"JIRA_API_TOKEN":             "ATATT3xFfGF0T9qQk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAMPLE",
+					"JIRA_SERVER":                "https://acme.atlassian.net",
+					"JIRA_ENDPOINT":              "https://acme.atlassian.net",
+					"JIRA_LOGIN":                 "wendy@acme.com",
+					"JIRA_AUTHENTICATION_METHOD": "api-token",
+				},
+			},
+		},
+	})
+}
+
+func TestAPITokenImporter(t *testing.T) {
+	expectedCandidates := []sdk.ImportCandidate{
+		{
+			Fields: map[sdk.FieldName]string{
+				fieldname.URL:   "https://acme.atlassian.net",
+				fieldname.Email: "wendy@acme.com",
+				fieldname.Token: "ATATT3xFfGF0T9qQk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAMPLE",
+			},
+		},
+	}
+
+	plugintest.TestImporter(t, APIToken().Importer, map[string]plugintest.ImportCase{
+		"jira-cli config file and token in environment": {
+			Environment: map[string]string{
+				"JIRA_API_TOKEN": "ATATT3xFfGF0T9qQk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAMPLE",
+			},
+			Files: map[string]string{
+				"~/.config/.jira/.config.yml": plugintest.LoadFixture(t, ".config.yml"),
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"jira-cli config file and token in netrc": {
+			Files: map[string]string{
+				"~/.config/.jira/.config.yml": plugintest.LoadFixture(t, ".config.yml"),
+				"~/.netrc":                    plugintest.LoadFixture(t, ".netrc"),
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"go-jira config file": {
+			Environment: map[string]string{
+				"JIRA_API_TOKEN": "ATATT3xFfGF0T9qQk7RmP2qW9vN4tY8bE3cL6hJ1sDfEXAMPLE",
+			},
+			Files: map[string]string{
+				"~/.jira.d/config.yml": plugintest.LoadFixture(t, "config.yml"),
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"config file without token": {
+			Files: map[string]string{
+				"~/.jira.d/config.yml": plugintest.LoadFixture(t, "config.yml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{},
+		},
+	})
+}
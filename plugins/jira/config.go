@@ -0,0 +1,102 @@
+package jira
+
+import (
+	"context"
+	"net/url"
+	"os"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// JiraCLIConfig holds the parts of the jira-cli config file, as created by 'jira init', that are relevant for
+// authentication.
+type JiraCLIConfig struct {
+	Server string `yaml:"server"`
+	Login  string `yaml:"login"`
+}
+
+// GoJiraConfig holds the parts of the go-jira config file that are relevant for authentication.
+type GoJiraConfig struct {
+	Endpoint string `yaml:"endpoint"`
+	User     string `yaml:"user"`
+	Login    string `yaml:"login"`
+}
+
+// TryJiraCLIConfigFile imports the site and email from the jira-cli config file. Since jira-cli keeps the API token
+// out of its config file, the token is looked up as described in apiToken.
+func TryJiraCLIConfigFile() sdk.Importer {
+	return importer.TryFile("~/.config/.jira/.config.yml", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config JiraCLIConfig
+		if err := contents.ToYAML(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		addCandidate(in, out, config.Server, config.Login)
+	})
+}
+
+// TryGoJiraConfigFile imports the site and email from the go-jira config file. Since go-jira keeps the API token
+// out of its config file, the token is looked up as described in apiToken.
+func TryGoJiraConfigFile() sdk.Importer {
+	return importer.TryFile("~/.jira.d/config.yml", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config GoJiraConfig
+		if err := contents.ToYAML(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		// go-jira authenticates with the login, which defaults to the user.
+		login := config.Login
+		if login == "" {
+			login = config.User
+		}
+		addCandidate(in, out, config.Endpoint, login)
+	})
+}
+
+func addCandidate(in sdk.ImportInput, out *sdk.ImportAttempt, server string, email string) {
+	if server == "" || email == "" {
+		return
+	}
+
+	token := apiToken(in, server, email)
+	if token == "" {
+		return
+	}
+
+	out.AddCandidate(sdk.ImportCandidate{
+		Fields: map[sdk.FieldName]string{
+			fieldname.URL:   server,
+			fieldname.Email: email,
+			fieldname.Token: token,
+		},
+	})
+}
+
+// apiToken returns the API token for the site: the one set in JIRA_API_TOKEN, which both CLIs read, or otherwise
+// the password of the matching ~/.netrc entry, which jira-cli falls back to.
+func apiToken(in sdk.ImportInput, server string, email string) string {
+	if token := os.Getenv("JIRA_API_TOKEN"); token != "" {
+		return token
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return ""
+	}
+
+	contents, err := os.ReadFile(in.FromHomeDir(".netrc"))
+	if err != nil {
+		return ""
+	}
+
+	for _, machine := range importer.FileContents(contents).ToNetrc() {
+		if machine.Machine == serverURL.Hostname() && (machine.Login == "" || machine.Login == email) {
+			return machine.Password
+		}
+	}
+	return ""
+}
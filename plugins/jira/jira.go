@@ -0,0 +1,28 @@
+package jira
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+// JiraCLI covers both jira-cli (github.com/ankitpokhrel/jira-cli) and go-jira (github.com/go-jira/jira),
+// which are both installed as 'jira'.
+func JiraCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Jira CLI",
+		Runs:    []string{"jira"},
+		DocsURL: sdk.URL("https://github.com/ankitpokhrel/jira-cli"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.Not(needsauth.ForCommand("completion")),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.APIToken,
+			},
+		},
+	}
+}
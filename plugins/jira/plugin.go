@@ -0,0 +1,22 @@
+package jira
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "jira",
+		Platform: schema.PlatformInfo{
+			Name:     "Jira",
+			Homepage: sdk.URL("https://www.atlassian.com/software/jira"),
+		},
+		Credentials: []schema.CredentialType{
+			APIToken(),
+		},
+		Executables: []schema.Executable{
+			JiraCLI(),
+		},
+	}
+}
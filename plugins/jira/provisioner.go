@@ -0,0 +1,41 @@
+package jira
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// jiraProvisioner provisions the API token along with the site URL and email, which both CLIs otherwise read
+// from their config file. jira-cli reads JIRA_SERVER and JIRA_LOGIN as overrides for the 'server' and 'login'
+// config fields, and go-jira reads JIRA_ENDPOINT, JIRA_LOGIN and JIRA_AUTHENTICATION_METHOD for 'endpoint',
+// 'login' and 'authentication-method'.
+type jiraProvisioner struct {
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"JIRA_API_TOKEN": fieldname.Token,
+	"JIRA_SERVER":    fieldname.URL,
+	"JIRA_ENDPOINT":  fieldname.URL,
+	"JIRA_LOGIN":     fieldname.Email,
+}
+
+func (p jiraProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	for envVarName, fieldName := range defaultEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
+
+	// go-jira only uses JIRA_API_TOKEN when it's configured to authenticate with an API token.
+	out.AddEnvVar("JIRA_AUTHENTICATION_METHOD", "api-token")
+}
+
+func (p jiraProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p jiraProvisioner) Description() string {
+	return "Provision environment variables JIRA_API_TOKEN, JIRA_SERVER, JIRA_ENDPOINT, JIRA_LOGIN and JIRA_AUTHENTICATION_METHOD"
+}
@@ -0,0 +1,59 @@
+package kubectl
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func ClusterCredentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.ClusterCredentials,
+		DocsURL:       sdk.URL("https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Server,
+				MarkdownDescription: "The URL of the Kubernetes API server, e.g. 'https://k8s.acme.com:6443'.",
+			},
+			{
+				Name:                fieldname.CACertificate,
+				MarkdownDescription: "The PEM-encoded certificate of the CA that signed the API server's certificate.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "The bearer token used to authenticate to the cluster, e.g. a service account token.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.ClientCertificate,
+				MarkdownDescription: "The PEM-encoded client certificate used to authenticate to the cluster, as an alternative to a token.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.ClientKey,
+				MarkdownDescription: "The PEM-encoded private key of the client certificate.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Namespace,
+				MarkdownDescription: "The namespace to use by default.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: provision.TempFile(kubeconfig,
+			provision.Filename("kubeconfig"),
+			provision.SetPathAsEnvVar("KUBECONFIG"),
+		),
+		Importer: importer.TryAll(
+			TryKubeconfigEnvVar(),
+			TryKubeconfigFile(),
+		),
+	}
+}
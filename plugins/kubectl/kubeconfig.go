@@ -0,0 +1,261 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"gopkg.in/yaml.v3"
+)
+
+// Name of the cluster, user and context in the provisioned kubeconfig.
+const kubeconfigEntryName = "1password"
+
+// Kubeconfig holds the parts of a kubeconfig file that are relevant for authentication.
+// The full format is described at https://kubernetes.io/docs/reference/config-api/kubeconfig.v1/
+type Kubeconfig struct {
+	APIVersion     string         `yaml:"apiVersion"`
+	Kind           string         `yaml:"kind"`
+	Clusters       []NamedCluster `yaml:"clusters"`
+	Users          []NamedUser    `yaml:"users"`
+	Contexts       []NamedContext `yaml:"contexts"`
+	CurrentContext string         `yaml:"current-context"`
+}
+
+type NamedCluster struct {
+	Name    string  `yaml:"name"`
+	Cluster Cluster `yaml:"cluster"`
+}
+
+type Cluster struct {
+	Server                   string `yaml:"server"`
+	CertificateAuthority     string `yaml:"certificate-authority,omitempty"`
+	CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
+}
+
+type NamedUser struct {
+	Name string `yaml:"name"`
+	User User   `yaml:"user"`
+}
+
+type User struct {
+	Token                 string `yaml:"token,omitempty"`
+	TokenFile             string `yaml:"tokenFile,omitempty"`
+	ClientCertificate     string `yaml:"client-certificate,omitempty"`
+	ClientCertificateData string `yaml:"client-certificate-data,omitempty"`
+	ClientKey             string `yaml:"client-key,omitempty"`
+	ClientKeyData         string `yaml:"client-key-data,omitempty"`
+}
+
+type NamedContext struct {
+	Name    string  `yaml:"name"`
+	Context Context `yaml:"context"`
+}
+
+type Context struct {
+	Cluster   string `yaml:"cluster"`
+	User      string `yaml:"user"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// kubeconfig generates a kubeconfig with a single context for the cluster and user in the item.
+func kubeconfig(in sdk.ProvisionInput) ([]byte, error) {
+	user := User{
+		Token: in.ItemFields[fieldname.Token],
+	}
+	if user.Token == "" {
+		certificate, hasCertificate := in.ItemFields[fieldname.ClientCertificate]
+		key, hasKey := in.ItemFields[fieldname.ClientKey]
+		if !hasCertificate || !hasKey {
+			return nil, errors.New("either a token, or a client certificate and key are required to authenticate to the cluster")
+		}
+		user.ClientCertificateData = base64.StdEncoding.EncodeToString([]byte(certificate))
+		user.ClientKeyData = base64.StdEncoding.EncodeToString([]byte(key))
+	}
+
+	cluster := Cluster{
+		Server: in.ItemFields[fieldname.Server],
+	}
+	if caCertificate, ok := in.ItemFields[fieldname.CACertificate]; ok {
+		cluster.CertificateAuthorityData = base64.StdEncoding.EncodeToString([]byte(caCertificate))
+	}
+
+	config := Kubeconfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		Clusters:       []NamedCluster{{Name: kubeconfigEntryName, Cluster: cluster}},
+		Users:          []NamedUser{{Name: kubeconfigEntryName, User: user}},
+		Contexts:       []NamedContext{{Name: kubeconfigEntryName, Context: Context{Cluster: kubeconfigEntryName, User: kubeconfigEntryName, Namespace: in.ItemFields[fieldname.Namespace]}}},
+		CurrentContext: kubeconfigEntryName,
+	}
+	return yaml.Marshal(config)
+}
+
+// TryKubeconfigEnvVar imports the users from the kubeconfig files listed in KUBECONFIG.
+func TryKubeconfigEnvVar() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		for _, path := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+			if path == "" {
+				continue
+			}
+
+			attempt := out.NewAttempt(importer.SourceFile(path))
+			path = resolvePath(in, path, "")
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				attempt.AddError(err)
+				continue
+			}
+			addKubeconfigCandidates(in, attempt, contents, filepath.Dir(path))
+		}
+	}
+}
+
+// TryKubeconfigFile imports the users from the default kubeconfig file, unless it's listed in KUBECONFIG, in which case
+// TryKubeconfigEnvVar already imports them.
+func TryKubeconfigFile() sdk.Importer {
+	tryFile := importer.TryFile("~/.kube/config", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		addKubeconfigCandidates(in, out, contents, in.FromHomeDir(".kube"))
+	})
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		defaultPath := in.FromHomeDir(".kube", "config")
+		for _, path := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+			if path != "" && filepath.Clean(resolvePath(in, path, "")) == defaultPath {
+				return
+			}
+		}
+		tryFile(ctx, in, out)
+	}
+}
+
+// addKubeconfigCandidates adds a candidate for every context in the kubeconfig of which the user has a static token
+// or client certificate. Users that authenticate through an exec plugin or auth provider get skipped. Relative paths
+// in the kubeconfig are resolved against dir, the directory of the kubeconfig file.
+func addKubeconfigCandidates(in sdk.ImportInput, out *sdk.ImportAttempt, contents []byte, dir string) {
+	var config Kubeconfig
+	if err := yaml.Unmarshal(contents, &config); err != nil {
+		out.AddError(err)
+		return
+	}
+
+	clusters := make(map[string]Cluster)
+	for _, cluster := range config.Clusters {
+		clusters[cluster.Name] = cluster.Cluster
+	}
+	users := make(map[string]User)
+	for _, user := range config.Users {
+		users[user.Name] = user.User
+	}
+
+	for _, namedContext := range config.Contexts {
+		cluster, ok := clusters[namedContext.Context.Cluster]
+		if !ok || cluster.Server == "" {
+			continue
+		}
+		user, ok := users[namedContext.Context.User]
+		if !ok {
+			continue
+		}
+
+		fields := map[sdk.FieldName]string{
+			fieldname.Server: cluster.Server,
+		}
+		caCertificate, err := readData(in, cluster.CertificateAuthorityData, cluster.CertificateAuthority, dir)
+		if err != nil {
+			out.AddError(fmt.Errorf("reading certificate authority of cluster %q: %w", namedContext.Context.Cluster, err))
+			continue
+		}
+		if caCertificate != "" {
+			fields[fieldname.CACertificate] = caCertificate
+		}
+		if namedContext.Context.Namespace != "" {
+			fields[fieldname.Namespace] = namedContext.Context.Namespace
+		}
+
+		token, err := readToken(in, user, dir)
+		if err != nil {
+			out.AddError(fmt.Errorf("reading token of user %q: %w", namedContext.Context.User, err))
+			continue
+		}
+		if token != "" {
+			fields[fieldname.Token] = token
+		} else {
+			certificate, err := readData(in, user.ClientCertificateData, user.ClientCertificate, dir)
+			if err != nil {
+				out.AddError(fmt.Errorf("reading client certificate of user %q: %w", namedContext.Context.User, err))
+				continue
+			}
+			key, err := readData(in, user.ClientKeyData, user.ClientKey, dir)
+			if err != nil {
+				out.AddError(fmt.Errorf("reading client key of user %q: %w", namedContext.Context.User, err))
+				continue
+			}
+			if certificate == "" || key == "" {
+				continue
+			}
+			fields[fieldname.ClientCertificate] = certificate
+			fields[fieldname.ClientKey] = key
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields:   fields,
+			NameHint: importer.SanitizeNameHint(namedContext.Name),
+		})
+	}
+}
+
+// readToken returns the inline token of the user if set, or else the contents of the token file.
+func readToken(in sdk.ImportInput, user User, dir string) (string, error) {
+	if user.Token != "" {
+		return user.Token, nil
+	}
+	if user.TokenFile == "" {
+		return "", nil
+	}
+	contents, err := os.ReadFile(resolvePath(in, user.TokenFile, dir))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
+
+// readData returns the base64-decoded inline data if set, or else the contents of the file at path.
+func readData(in sdk.ImportInput, data string, path string, dir string) (string, error) {
+	if data != "" {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	}
+	if path == "" {
+		return "", nil
+	}
+	contents, err := os.ReadFile(resolvePath(in, path, dir))
+	if err != nil {
+		return "", err
+	}
+	return string(contents), nil
+}
+
+// resolvePath resolves a path from KUBECONFIG or a kubeconfig file in the filesystem of the import input. Relative
+// paths are resolved against dir.
+func resolvePath(in sdk.ImportInput, path string, dir string) string {
+	switch {
+	case strings.HasPrefix(path, "~/"):
+		return in.FromHomeDir(strings.TrimPrefix(path, "~/"))
+	case strings.HasPrefix(path, "/"):
+		return in.FromRootDir(path)
+	case filepath.IsAbs(path):
+		return path
+	default:
+		return filepath.Join(dir, path)
+	}
+}
@@ -0,0 +1,30 @@
+package kubectl
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func KubernetesCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Kubernetes CLI",
+		Runs:    []string{"kubectl"},
+		DocsURL: sdk.URL("https://kubernetes.io/docs/reference/kubectl/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.Not(needsauth.ForCommand("config")),
+			needsauth.Not(needsauth.ForCommand("completion")),
+			needsauth.Not(needsauth.ForCommand("kustomize")),
+			needsauth.Not(needsauth.ForCommand("plugin")),
+			needsauth.NotWhenContainsArgs("version", "--client"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.ClusterCredentials,
+			},
+		},
+	}
+}
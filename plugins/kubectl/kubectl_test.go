@@ -0,0 +1,60 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestKubernetesCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, KubernetesCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"no for no args": {
+			Args:              []string{},
+			ExpectedNeedsAuth: false,
+		},
+		"no for help": {
+			Args:              []string{"--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for client version": {
+			Args:              []string{"version", "--client"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for config": {
+			Args:              []string{"config", "view"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for completion": {
+			Args:              []string{"completion", "zsh"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for kustomize": {
+			Args:              []string{"kustomize", "overlays/production"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for plugin": {
+			Args:              []string{"plugin", "list"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for get": {
+			Args:              []string{"get", "pods"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for configmap named config": {
+			Args:              []string{"get", "configmap", "config"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for secret named plugin": {
+			Args:              []string{"delete", "secret", "plugin"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for apply of kustomize directory": {
+			Args:              []string{"apply", "-k", "kustomize"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for exec running completion": {
+			Args:              []string{"exec", "web", "--", "completion"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package mvn
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func Credentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://maven.apache.org/settings.html#servers"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.ServerID,
+				MarkdownDescription: "The ID of the repository or mirror the credentials are for, as used in the POM or settings.xml.",
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The username used to authenticate to the server.",
+			},
+			{
+				Name:                fieldname.Password,
+				MarkdownDescription: "The password or token used to authenticate to the server.",
+				Secret:              true,
+			},
+		},
+		DefaultProvisioner: provision.TempFile(settingsFile,
+			provision.Filename("settings.xml"),
+			provision.AddArgs("-s", "{{ .Path }}"),
+		),
+		Importer: importer.TryAll(
+			TrySettingsFile("~/.m2/settings.xml"),
+		),
+	}
+}
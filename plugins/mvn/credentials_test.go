@@ -0,0 +1,63 @@
+package mvn
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, Credentials().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.ServerID: "acme-nexus",
+				fieldname.Username: "ci",
+				fieldname.Password: "Xv2Rk7Tn1Wb6Yc3Jd8HfEXAMPLE",
+			},
+			CommandLine: []string{"mvn", "deploy"},
+			ExpectedOutput: sdk.ProvisionOutput{
+				CommandLine: []string{"mvn", "deploy", "-s", "/tmp/settings.xml"},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/settings.xml": {Contents: []byte(plugintest.LoadFixture(t, "settings-generated.xml"))},
+				},
+			},
+		},
+	})
+}
+
+func TestAddServer(t *testing.T) {
+	settings, err := addServer([]byte(plugintest.LoadFixture(t, "settings.xml")), Server{
+		ID:       "acme-nexus",
+		Username: "ci",
+		Password: "Xv2Rk7Tn1Wb6Yc3Jd8HfEXAMPLE",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, plugintest.LoadFixture(t, "settings-merged.xml"), string(settings))
+
+	settings, err = addServer([]byte("<settings>\n</settings>\n"), Server{ID: "ossrh", Username: "acme", Password: "p<&"})
+	assert.NoError(t, err)
+	assert.Equal(t, "<settings>\n  <servers>\n    <server>\n      <id>ossrh</id>\n      <username>acme</username>\n      <password>p&lt;&amp;</password>\n    </server>\n  </servers>\n</settings>\n", string(settings))
+}
+
+func TestCredentialsImporter(t *testing.T) {
+	plugintest.TestImporter(t, Credentials().Importer, map[string]plugintest.ImportCase{
+		"settings file": {
+			Files: map[string]string{
+				"~/.m2/settings.xml": plugintest.LoadFixture(t, "settings.xml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.ServerID: "acme-nexus",
+						fieldname.Username: "wendy",
+						fieldname.Password: "Zx8Qm2Rt6Yw0N3cK1jH5fEXAMPLE",
+					},
+					NameHint: "acme-nexus",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package mvn
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func MavenCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Maven",
+		Runs:    []string{"mvn"},
+		DocsURL: sdk.URL("https://maven.apache.org/ref/current/maven-embedder/cli.html"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.Credentials,
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package mvn
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "mvn",
+		Platform: schema.PlatformInfo{
+			Name:     "Maven",
+			Homepage: sdk.URL("https://maven.apache.org"),
+		},
+		Credentials: []schema.CredentialType{
+			Credentials(),
+		},
+		Executables: []schema.Executable{
+			MavenCLI(),
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package mvn
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// Settings holds the parts of a Maven settings.xml file that are relevant for authentication.
+// The full format is described at https://maven.apache.org/settings.html
+type Settings struct {
+	XMLName xml.Name `xml:"settings"`
+	Servers []Server `xml:"servers>server"`
+}
+
+type Server struct {
+	XMLName  xml.Name `xml:"server"`
+	ID       string   `xml:"id"`
+	Username string   `xml:"username"`
+	Password string   `xml:"password"`
+}
+
+// serverElement matches a single <server> element in a settings.xml file.
+var serverElement = regexp.MustCompile(`(?s)[ \t]*<server>.*?</server>[ \t]*\n?`)
+
+// settingsFile generates a settings.xml with the server from the item. If the user already has a settings.xml, the
+// server gets added to a copy of it instead, so that mirrors, profiles and other servers keep working.
+func settingsFile(in sdk.ProvisionInput) ([]byte, error) {
+	server := Server{
+		ID:       in.ItemFields[fieldname.ServerID],
+		Username: in.ItemFields[fieldname.Username],
+		Password: in.ItemFields[fieldname.Password],
+	}
+
+	existing, err := os.ReadFile(in.FromHomeDir(".m2", "settings.xml"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		contents, err := xml.MarshalIndent(Settings{Servers: []Server{server}}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(xml.Header), append(contents, '\n')...), nil
+	}
+
+	return addServer(existing, server)
+}
+
+// addServer adds the server to the settings.xml contents, replacing any existing server with the same ID.
+// The contents are edited as text rather than decoded and encoded again, which would lose everything that
+// the Settings struct doesn't cover.
+func addServer(settings []byte, server Server) ([]byte, error) {
+	element, err := xml.MarshalIndent(server, "    ", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var id bytes.Buffer
+	if err := xml.EscapeText(&id, []byte(server.ID)); err != nil {
+		return nil, err
+	}
+	settings = serverElement.ReplaceAllFunc(settings, func(match []byte) []byte {
+		if bytes.Contains(match, []byte("<id>"+id.String()+"</id>")) {
+			return nil
+		}
+		return match
+	})
+
+	contents := string(settings)
+	if i := lineStart(contents, "</servers>"); i >= 0 {
+		return []byte(contents[:i] + string(element) + "\n" + contents[i:]), nil
+	}
+	if i := lineStart(contents, "</settings>"); i >= 0 {
+		return []byte(contents[:i] + "  <servers>\n" + string(element) + "\n  </servers>\n" + contents[i:]), nil
+	}
+	return nil, errors.New("no <settings> element found in ~/.m2/settings.xml")
+}
+
+// lineStart returns the index of the start of the line on which tag occurs last, or -1 if it doesn't occur.
+func lineStart(contents string, tag string) int {
+	i := strings.LastIndex(contents, tag)
+	if i < 0 {
+		return -1
+	}
+	return strings.LastIndex(contents[:i], "\n") + 1
+}
+
+// TrySettingsFile imports the credentials of every server in the settings.xml file at path.
+func TrySettingsFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var settings Settings
+		if err := contents.ToXML(&settings); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, server := range settings.Servers {
+			// Skip passwords that reference a property, e.g. '${env.NEXUS_PASSWORD}', or that are encrypted
+			// with the Maven master password, e.g. '{COQLCE6DU6GtcS5P=}'.
+			if server.ID == "" || server.Username == "" || server.Password == "" ||
+				strings.Contains(server.Password, "${") || strings.HasPrefix(server.Password, "{") {
+				continue
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.ServerID: server.ID,
+					fieldname.Username: server.Username,
+					fieldname.Password: server.Password,
+				},
+				NameHint: importer.SanitizeNameHint(server.ID),
+			})
+		}
+	})
+}
@@ -70,22 +70,39 @@ func TryngrokConfigFile(path string) sdk.Importer {
 			return
 		}
 
-		if config.AuthToken == "" || config.APIKey == "" || config.Version == "" {
+		// Version 3 of the config format moved the credentials to the "agent" section.
+		authToken, apiKey := config.AuthToken, config.APIKey
+		if config.Agent.AuthToken != "" {
+			authToken, apiKey = config.Agent.AuthToken, config.Agent.APIKey
+		}
+
+		if authToken == "" {
 			return
 		}
 
+		fields := map[sdk.FieldName]string{
+			fieldname.Authtoken: authToken,
+		}
+		if apiKey != "" {
+			fields[fieldname.APIKey] = apiKey
+		}
+
 		out.AddCandidate(sdk.ImportCandidate{
-			Fields: map[sdk.FieldName]string{
-				fieldname.Authtoken: config.AuthToken,
-				fieldname.APIKey:    config.APIKey,
-			},
+			Fields: fields,
 		})
 	})
 }
 
 // Config struct is exhaustive, covering all documented configurations.
 type Config struct {
+	AuthToken string      `yaml:"authtoken"`
+	APIKey    string      `yaml:"api_key"`
+	Version   string      `yaml:"version"`
+	Agent     AgentConfig `yaml:"agent"`
+}
+
+// AgentConfig holds the credentials in the "agent" section of a version 3 config file.
+type AgentConfig struct {
 	AuthToken string `yaml:"authtoken"`
 	APIKey    string `yaml:"api_key"`
-	Version   string `yaml:"version"`
 }
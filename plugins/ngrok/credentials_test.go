@@ -73,6 +73,33 @@ func TestCredentialsImporter(t *testing.T) {
 				},
 			},
 		},
+		"config file with only an authtoken": {
+			OS: "linux",
+			Files: map[string]string{
+				"~/.config/ngrok/ngrok.yml": plugintest.LoadFixture(t, "authtoken-only.yml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Authtoken: "cxG2Im21Yzkh8VnvFQaetlPHcQ9ZDUUk1IzzyHhcGcEXAMPLE",
+					},
+				},
+			},
+		},
+		"version 3 config file": {
+			OS: "linux",
+			Files: map[string]string{
+				"~/.config/ngrok/ngrok.yml": plugintest.LoadFixture(t, "config-v3.yml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Authtoken: "cxG2Im21Yzkh8VnvFQaetlPHcQ9ZDUUk1IzzyHhcGcEXAMPLE",
+						fieldname.APIKey:    "NQdxymVXmWC15916Mmy1vYkpzzNG6a84Bo4mYKuDahEXAMPLE",
+					},
+				},
+			},
+		},
 	})
 }
 
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/1Password/shell-plugins/sdk"
-	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
 	"golang.org/x/mod/semver"
 )
 
@@ -29,8 +28,11 @@ func (p ngrokEnvVarProvisioner) Provision(ctx context.Context, in sdk.ProvisionI
 		return
 	}
 
-	out.AddEnvVar("NGROK_AUTHTOKEN", in.ItemFields[fieldname.Authtoken])
-	out.AddEnvVar("NGROK_API_KEY", in.ItemFields[fieldname.APIKey])
+	for envVarName, fieldName := range defaultEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
 }
 
 func (p ngrokEnvVarProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
@@ -38,5 +40,5 @@ func (p ngrokEnvVarProvisioner) Deprovision(ctx context.Context, in sdk.Deprovis
 }
 
 func (p ngrokEnvVarProvisioner) Description() string {
-	return "Provision ngrok credentials as environment variables NGROK_AUTH_TOKEN and NGROK_API_KEY"
+	return "Provision ngrok credentials as environment variables NGROK_AUTHTOKEN and NGROK_API_KEY"
 }
@@ -83,7 +83,9 @@ func (f fileProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, o
 	}
 
 	config[authTokenYamlName] = in.ItemFields[fieldname.Authtoken]
-	config[apiKeyYamlName] = in.ItemFields[fieldname.APIKey]
+	if apiKey, ok := in.ItemFields[fieldname.APIKey]; ok {
+		config[apiKeyYamlName] = apiKey
+	}
 	config[versionYamlName] = version
 
 	newContents, err := yaml.Marshal(&config)
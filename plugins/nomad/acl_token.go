@@ -0,0 +1,128 @@
+package nomad
+
+import (
+	"context"
+	"os"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func ACLToken() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.ACLToken,
+		DocsURL:       sdk.URL("https://developer.hashicorp.com/nomad/tutorials/access-control/access-control-tokens"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "The secret ID of the ACL token used to authenticate to Nomad.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 36,
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'-'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.Address,
+				MarkdownDescription: "The address of the Nomad server, e.g. 'https://nomad.acme.com:4646'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Region,
+				MarkdownDescription: "The region of the Nomad servers to forward commands to.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Namespace,
+				MarkdownDescription: "The namespace to use for commands.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.CACertificate,
+				MarkdownDescription: "The PEM-encoded CA certificate used to verify the Nomad server's TLS certificate.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.ClientCertificate,
+				MarkdownDescription: "The PEM-encoded client certificate used for mutual TLS with the Nomad server.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.ClientKey,
+				MarkdownDescription: "The PEM-encoded private key of the client certificate.",
+				Secret:              true,
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: nomadProvisioner{},
+		Importer: importer.TryAll(
+			TryNomadEnvVars(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"NOMAD_TOKEN":     fieldname.Token,
+	"NOMAD_ADDR":      fieldname.Address,
+	"NOMAD_REGION":    fieldname.Region,
+	"NOMAD_NAMESPACE": fieldname.Namespace,
+}
+
+// tlsFileEnvVarMapping holds the env vars that point to the files used for mutual TLS.
+var tlsFileEnvVarMapping = map[string]sdk.FieldName{
+	"NOMAD_CACERT":      fieldname.CACertificate,
+	"NOMAD_CLIENT_CERT": fieldname.ClientCertificate,
+	"NOMAD_CLIENT_KEY":  fieldname.ClientKey,
+}
+
+// TryNomadEnvVars imports the token and connection settings from the NOMAD_* env vars, including the contents
+// of the TLS files that NOMAD_CACERT, NOMAD_CLIENT_CERT and NOMAD_CLIENT_KEY point to.
+func TryNomadEnvVars() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		token := os.Getenv("NOMAD_TOKEN")
+		if token == "" {
+			return
+		}
+
+		var envVarNames []string
+		fields := make(map[sdk.FieldName]string)
+		for envVarName, fieldName := range defaultEnvVarMapping {
+			if value := os.Getenv(envVarName); value != "" {
+				fields[fieldName] = value
+				envVarNames = append(envVarNames, envVarName)
+			}
+		}
+
+		var tlsErrs []error
+		for envVarName, fieldName := range tlsFileEnvVarMapping {
+			path := os.Getenv(envVarName)
+			if path == "" {
+				continue
+			}
+			envVarNames = append(envVarNames, envVarName)
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				tlsErrs = append(tlsErrs, err)
+				continue
+			}
+			fields[fieldName] = string(contents)
+		}
+
+		attempt := out.NewAttempt(importer.SourceEnvVars(envVarNames...))
+		for _, err := range tlsErrs {
+			attempt.AddError(err)
+		}
+		attempt.AddCandidate(sdk.ImportCandidate{
+			Fields: fields,
+		})
+	}
+}
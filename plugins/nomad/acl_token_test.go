@@ -0,0 +1,109 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestACLTokenProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, ACLToken().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Token:     "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+				fieldname.Address:   "https://nomad.acme.com:4646",
+				fieldname.Region:    "eu-west",
+				fieldname.Namespace: "web",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"NOMAD_TOKEN":     "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+					"NOMAD_ADDR":      "https://nomad.acme.com:4646",
+					"NOMAD_REGION":    "eu-west",
+					"NOMAD_NAMESPACE": "web",
+				},
+			},
+		},
+		"mutual TLS": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Token:             "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+				fieldname.Address:           "https://nomad.acme.com:4646",
+				fieldname.CACertificate:     plugintest.LoadFixture(t, "ca.pem"),
+				fieldname.ClientCertificate: plugintest.LoadFixture(t, "client.pem"),
+				fieldname.ClientKey:         plugintest.LoadFixture(t, "client-key.pem"),
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"NOMAD_TOKEN":       "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+					"NOMAD_ADDR":        "https://nomad.acme.com:4646",
+					"NOMAD_CACERT":      "/tmp/nomad-ca.pem",
+					"NOMAD_CLIENT_CERT": "/tmp/nomad-client.pem",
+					"NOMAD_CLIENT_KEY":  "/tmp/nomad-client-key.pem",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/nomad-ca.pem":         {Contents: []byte(plugintest.LoadFixture(t, "ca.pem"))},
+					"/tmp/nomad-client.pem":     {Contents: []byte(plugintest.LoadFixture(t, "client.pem"))},
+					"/tmp/nomad-client-key.pem": {Contents: []byte(plugintest.LoadFixture(t, "client-key.pem"))},
+				},
+			},
+		},
+	})
+}
+
+func TestACLTokenImporter(t *testing.T) {
+	plugintest.TestImporter(t, ACLToken().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"NOMAD_TOKEN":     "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+				"NOMAD_ADDR":      "https://nomad.acme.com:4646",
+				"NOMAD_REGION":    "eu-west",
+				"NOMAD_NAMESPACE": "web",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token:     "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+						fieldname.Address:   "https://nomad.acme.com:4646",
+						fieldname.Region:    "eu-west",
+						fieldname.Namespace: "web",
+					},
+				},
+			},
+		},
+		"environment with TLS files": {
+			Environment: map[string]string{
+				"NOMAD_TOKEN": "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+				"NOMAD_ADDR":  "https://nomad.acme.com:4646",
+			},
+			Files: map[string]string{
+				"~/.nomad/ca.pem":         plugintest.LoadFixture(t, "ca.pem"),
+				"~/.nomad/client.pem":     plugintest.LoadFixture(t, "client.pem"),
+				"~/.nomad/client-key.pem": plugintest.LoadFixture(t, "client-key.pem"),
+			},
+			RootedEnvironment: map[string]string{
+				"NOMAD_CACERT":      "~/.nomad/ca.pem",
+				"NOMAD_CLIENT_CERT": "~/.nomad/client.pem",
+				"NOMAD_CLIENT_KEY":  "~/.nomad/client-key.pem",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token:             "5b1f3a2e-8c4d-4e6f-9a7b-0c1d2e3f4a5b",
+						fieldname.Address:           "https://nomad.acme.com:4646",
+						fieldname.CACertificate:     plugintest.LoadFixture(t, "ca.pem"),
+						fieldname.ClientCertificate: plugintest.LoadFixture(t, "client.pem"),
+						fieldname.ClientKey:         plugintest.LoadFixture(t, "client-key.pem"),
+					},
+				},
+			},
+		},
+		"no token": {
+			Environment: map[string]string{
+				"NOMAD_ADDR": "https://nomad.acme.com:4646",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{},
+		},
+	})
+}
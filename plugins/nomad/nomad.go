@@ -0,0 +1,29 @@
+package nomad
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func NomadCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Nomad CLI",
+		Runs:    []string{"nomad"},
+		DocsURL: sdk.URL("https://developer.hashicorp.com/nomad/docs/commands"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("agent"),
+			needsauth.NotWhenContainsArgs("fmt"),
+			needsauth.NotWhenContainsArgs("job", "init"),
+			needsauth.NotWhenContainsArgs("job", "validate"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.ACLToken,
+			},
+		},
+	}
+}
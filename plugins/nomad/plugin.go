@@ -0,0 +1,22 @@
+package nomad
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "nomad",
+		Platform: schema.PlatformInfo{
+			Name:     "HashiCorp Nomad",
+			Homepage: sdk.URL("https://www.nomadproject.io"),
+		},
+		Credentials: []schema.CredentialType{
+			ACLToken(),
+		},
+		Executables: []schema.Executable{
+			NomadCLI(),
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package nomad
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// tlsFilenames are the names of the temp files the TLS fields get provisioned to.
+var tlsFilenames = map[string]string{
+	"NOMAD_CACERT":      "nomad-ca.pem",
+	"NOMAD_CLIENT_CERT": "nomad-client.pem",
+	"NOMAD_CLIENT_KEY":  "nomad-client-key.pem",
+}
+
+// nomadProvisioner provisions the token and connection settings as env vars, and writes the TLS certificates and
+// key to temp files whose paths get passed in the env vars the Nomad CLI reads them from.
+type nomadProvisioner struct{}
+
+func (p nomadProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	for envVarName, fieldName := range defaultEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
+
+	for envVarName, fieldName := range tlsFileEnvVarMapping {
+		if contents, ok := in.ItemFields[fieldName]; ok && contents != "" {
+			path := in.FromTempDir(tlsFilenames[envVarName])
+			out.AddSecretFile(path, []byte(contents))
+			out.AddEnvVar(envVarName, path)
+		}
+	}
+}
+
+func (p nomadProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p nomadProvisioner) Description() string {
+	return "Provision environment variables NOMAD_TOKEN, NOMAD_ADDR, NOMAD_REGION and NOMAD_NAMESPACE, and the TLS files in NOMAD_CACERT, NOMAD_CLIENT_CERT and NOMAD_CLIENT_KEY"
+}
@@ -0,0 +1,88 @@
+package npm
+
+import (
+	"context"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const defaultRegistryHost = "registry.npmjs.org"
+
+// TryNPMConfigFile imports the auth tokens from the user's .npmrc file, which npm, pnpm and Yarn 1 read.
+func TryNPMConfigFile() sdk.Importer {
+	return importer.TryFile("~/.npmrc", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		for _, line := range strings.Split(contents.ToString(), "\n") {
+			key, value, found := strings.Cut(line, "=")
+			if !found {
+				continue
+			}
+			key = strings.TrimSpace(key)
+			if !strings.HasPrefix(key, "//") || !strings.HasSuffix(key, ":_authToken") {
+				continue
+			}
+			host := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(key, "//"), ":_authToken"), "/")
+			addTokenCandidate(out, host, strings.TrimSpace(value))
+		}
+	})
+}
+
+// YarnConfig holds the parts of a Yarn 2+ .yarnrc.yml file that are relevant for authentication.
+// The full format is described at https://yarnpkg.com/configuration/yarnrc
+type YarnConfig struct {
+	NPMAuthToken      string                        `yaml:"npmAuthToken"`
+	NPMRegistryServer string                        `yaml:"npmRegistryServer"`
+	NPMRegistries     map[string]YarnRegistryConfig `yaml:"npmRegistries"`
+}
+
+type YarnRegistryConfig struct {
+	NPMAuthToken string `yaml:"npmAuthToken"`
+}
+
+// TryYarnConfigFile imports the auth tokens from the user's .yarnrc.yml file.
+func TryYarnConfigFile() sdk.Importer {
+	return importer.TryFile("~/.yarnrc.yml", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config YarnConfig
+		if err := contents.ToYAML(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		addTokenCandidate(out, registryHost(config.NPMRegistryServer), config.NPMAuthToken)
+		for server, registry := range config.NPMRegistries {
+			addTokenCandidate(out, registryHost(server), registry.NPMAuthToken)
+		}
+	})
+}
+
+// registryHost returns the host and path of the registry server URL, e.g. 'npm.pkg.github.com' for
+// 'https://npm.pkg.github.com/'.
+func registryHost(server string) string {
+	server = strings.TrimPrefix(server, "https://")
+	server = strings.TrimPrefix(server, "http://")
+	server = strings.TrimPrefix(server, "//")
+	return strings.TrimSuffix(server, "/")
+}
+
+func addTokenCandidate(out *sdk.ImportAttempt, host string, token string) {
+	// Skip tokens that are references to environment variables, e.g. '${NPM_TOKEN}'.
+	if token == "" || strings.Contains(token, "${") {
+		return
+	}
+
+	fields := map[sdk.FieldName]string{
+		fieldname.Token: token,
+	}
+	nameHint := ""
+	if host != "" && host != defaultRegistryHost {
+		fields[fieldname.Host] = host
+		nameHint = importer.SanitizeNameHint(host)
+	}
+
+	out.AddCandidate(sdk.ImportCandidate{
+		Fields:   fields,
+		NameHint: nameHint,
+	})
+}
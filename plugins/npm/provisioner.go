@@ -0,0 +1,83 @@
+package npm
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// npmProvisioner provisions a temporary user config for npm and pnpm, which both read .npmrc files, and the
+// environment variables that Yarn and project-level config files pick up the token from.
+type npmProvisioner struct {
+}
+
+func (p npmProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	token := in.ItemFields[fieldname.Token]
+
+	// Keep the settings of the user's own .npmrc, so that only the auth token for the registry changes.
+	existing, err := os.ReadFile(in.FromHomeDir(".npmrc"))
+	if err != nil && !os.IsNotExist(err) {
+		out.AddError(err)
+		return
+	}
+
+	path := in.FromTempDir(".npmrc")
+	out.AddSecretFile(path, npmrc(existing, in.ItemFields[fieldname.Host], token))
+	out.AddEnvVar("NPM_CONFIG_USERCONFIG", path)
+
+	// Project-level .npmrc and .yarnrc.yml files commonly reference the token as ${NPM_TOKEN}.
+	out.AddEnvVar("NPM_TOKEN", token)
+
+	// Yarn 2+ doesn't read .npmrc files. It uses YARN_NPM_AUTH_TOKEN for the registry set as npmRegistryServer, which
+	// is the default registry unless configured otherwise, so that's only safe for tokens of the default registry.
+	// Tokens for other registries can only be set in the npmRegistries setting of the project's .yarnrc.yml file,
+	// which can reference the token as `npmAuthToken: ${NPM_TOKEN}`.
+	host := registryHost(strings.TrimSpace(in.ItemFields[fieldname.Host]))
+	if host == "" || host == defaultRegistryHost {
+		out.AddEnvVar("YARN_NPM_AUTH_TOKEN", token)
+	}
+}
+
+func (p npmProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p npmProvisioner) Description() string {
+	return "Provision environment variables NPM_TOKEN and YARN_NPM_AUTH_TOKEN, and NPM_CONFIG_USERCONFIG with a temporary .npmrc file"
+}
+
+// npmrc returns the contents of the existing .npmrc file, with the auth token for the registry host set to token.
+func npmrc(existing []byte, host string, token string) []byte {
+	key := authTokenKey(host)
+
+	var lines []string
+	for _, line := range strings.Split(string(existing), "\n") {
+		if line == "" {
+			continue
+		}
+		if name, _, found := strings.Cut(line, "="); found && strings.TrimSpace(name) == key {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, key+"="+token)
+
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// authTokenKey returns the .npmrc key that holds the auth token for the registry host,
+// e.g. '//registry.npmjs.org/:_authToken'.
+func authTokenKey(host string) string {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host = strings.TrimPrefix(host, "//")
+	host = strings.TrimSuffix(host, "/")
+	if host == "" {
+		host = defaultRegistryHost
+	}
+	return "//" + host + "/:_authToken"
+}
@@ -0,0 +1,29 @@
+package npm
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func YarnCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Yarn",
+		Runs:    []string{"yarn"},
+		DocsURL: sdk.URL("https://yarnpkg.com/cli"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWhenContainsArgs("config"),
+			needsauth.NotWhenContainsArgs("npm", "login"),
+			needsauth.NotWhenContainsArgs("npm", "logout"),
+			needsauth.NotWhenContainsArgs("run"),
+			needsauth.NotWhenContainsArgs("test"),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.AccessToken,
+			},
+		},
+	}
+}
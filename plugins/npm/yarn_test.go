@@ -0,0 +1,28 @@
+package npm
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestYarnNeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, YarnCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes without args, which installs the dependencies": {
+			Args:              []string{},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for npm publish": {
+			Args:              []string{"npm", "publish"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for npm login": {
+			Args:              []string{"npm", "login"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for run": {
+			Args:              []string{"run", "build"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
@@ -0,0 +1,186 @@
+package oci
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const defaultProfileName = "DEFAULT"
+
+func APIKey() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIKey,
+		DocsURL:       sdk.URL("https://docs.oracle.com/iaas/Content/API/Concepts/apisigningkey.htm"),
+		ManagementURL: sdk.URL("https://cloud.oracle.com/identity/domains/my-profile/api-keys"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.TenancyOCID,
+				MarkdownDescription: "The OCID of the tenancy.",
+				Composition: &schema.ValueComposition{
+					Prefix: "ocid1.tenancy.",
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'.'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.UserOCID,
+				MarkdownDescription: "The OCID of the user the API key belongs to.",
+				Composition: &schema.ValueComposition{
+					Prefix: "ocid1.user.",
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'.'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.Fingerprint,
+				MarkdownDescription: "The fingerprint of the public key that was uploaded for the user.",
+				Composition: &schema.ValueComposition{
+					Length: 47,
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{':'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.PrivateKey,
+				MarkdownDescription: "The PEM-encoded private key used to sign API requests.",
+				Secret:              true,
+			},
+			{
+				Name:                fieldname.Passphrase,
+				MarkdownDescription: "The passphrase of the private key, if it is encrypted.",
+				Secret:              true,
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Region,
+				MarkdownDescription: "The region to use by default, e.g. 'us-ashburn-1'.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: configFileProvisioner{},
+		Importer: importer.TryAll(
+			TryOCIConfigFile(),
+		),
+	}
+}
+
+// configFileProvisioner provisions a temporary OCI config file with the API key, since the OCI CLI has no way to
+// read the private key from the environment. The profile is named after the one the invocation targets, so that
+// '--profile' keeps working.
+type configFileProvisioner struct{}
+
+func (p configFileProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	profile := in.Profile
+	if profile == "" {
+		profile = defaultProfileName
+	}
+
+	keyPath := in.FromTempDir("oci_api_key.pem")
+	out.AddSecretFile(keyPath, []byte(in.ItemFields[fieldname.PrivateKey]))
+
+	config := fmt.Sprintf("[%s]\n", profile)
+	config += configFileEntry("user", in.ItemFields[fieldname.UserOCID])
+	config += configFileEntry("fingerprint", in.ItemFields[fieldname.Fingerprint])
+	config += configFileEntry("tenancy", in.ItemFields[fieldname.TenancyOCID])
+	if region, ok := in.ItemFields[fieldname.Region]; ok && region != "" {
+		config += configFileEntry("region", region)
+	}
+	config += configFileEntry("key_file", keyPath)
+	if passphrase, ok := in.ItemFields[fieldname.Passphrase]; ok && passphrase != "" {
+		config += configFileEntry("pass_phrase", passphrase)
+	}
+
+	configPath := in.FromTempDir("config")
+	out.AddSecretFile(configPath, []byte(config))
+	out.AddEnvVar("OCI_CLI_CONFIG_FILE", configPath)
+	out.AddEnvVar("OCI_CLI_PROFILE", profile)
+}
+
+func (p configFileProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p configFileProvisioner) Description() string {
+	return "Provision a temporary OCI config file and private key file, set in OCI_CLI_CONFIG_FILE"
+}
+
+func configFileEntry(key string, value string) string {
+	return fmt.Sprintf("%s=%s\n", key, value)
+}
+
+// TryOCIConfigFile imports the profiles in the OCI config file, together with the private keys they refer to.
+func TryOCIConfigFile() sdk.Importer {
+	return importer.TryFile("~/.oci/config", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		configFile, err := contents.ToINI()
+		if err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, section := range configFile.Sections() {
+			user := section.Key("user").Value()
+			tenancy := section.Key("tenancy").Value()
+			keyFile := section.Key("key_file").Value()
+			if user == "" || tenancy == "" || keyFile == "" {
+				continue
+			}
+
+			privateKey, err := os.ReadFile(resolvePath(in, keyFile))
+			if err != nil {
+				out.AddError(fmt.Errorf("reading the private key of profile %s: %w", section.Name(), err))
+				continue
+			}
+
+			fields := map[sdk.FieldName]string{
+				fieldname.UserOCID:    user,
+				fieldname.TenancyOCID: tenancy,
+				fieldname.Fingerprint: section.Key("fingerprint").Value(),
+				fieldname.PrivateKey:  string(privateKey),
+			}
+			if region := section.Key("region").Value(); region != "" {
+				fields[fieldname.Region] = region
+			}
+			if passphrase := section.Key("pass_phrase").Value(); passphrase != "" {
+				fields[fieldname.Passphrase] = passphrase
+			}
+
+			nameHint := section.Name()
+			if nameHint == defaultProfileName {
+				nameHint = ""
+			}
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields:   fields,
+				NameHint: importer.SanitizeNameHint(nameHint),
+			})
+		}
+	})
+}
+
+// resolvePath resolves key file paths in the OCI config file, which can be relative to the home dir.
+func resolvePath(in sdk.ImportInput, path string) string {
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(in.HomeDir, strings.TrimPrefix(path, "~/"))
+	}
+	if strings.HasPrefix(path, "/") {
+		return filepath.Join(in.RootDir, path)
+	}
+	return filepath.Join(in.HomeDir, ".oci", path)
+}
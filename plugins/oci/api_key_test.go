@@ -0,0 +1,101 @@
+package oci
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPIKeyProvisioner(t *testing.T) {
+	privateKey := plugintest.LoadFixture(t, "oci_api_key.pem")
+
+	plugintest.TestProvisioner(t, APIKey().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.UserOCID:    "ocid1.user.oc1..aaaaaaaa7kxvq3zm5example",
+				fieldname.Fingerprint: "20:3b:97:13:55:1c:5b:0d:d3:37:d8:50:4e:c5:3a:34",
+				fieldname.TenancyOCID: "ocid1.tenancy.oc1..aaaaaaaab2lqw4zexample",
+				fieldname.Region:      "us-ashburn-1",
+				fieldname.PrivateKey:  privateKey,
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"OCI_CLI_CONFIG_FILE": "/tmp/config",
+					"OCI_CLI_PROFILE":     "DEFAULT",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/oci_api_key.pem": {Contents: []byte(privateKey)},
+					"/tmp/config": {Contents: []byte("[DEFAULT]\n" +
+						"user=ocid1.user.oc1..aaaaaaaa7kxvq3zm5example\n" +
+						"fingerprint=20:3b:97:13:55:1c:5b:0d:d3:37:d8:50:4e:c5:3a:34\n" +
+						"tenancy=ocid1.tenancy.oc1..aaaaaaaab2lqw4zexample\n" +
+						"region=us-ashburn-1\n" +
+						"key_file=/tmp/oci_api_key.pem\n"),
+					},
+				},
+			},
+		},
+		"profile with passphrase": {
+			Profile: "ADMIN",
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.UserOCID:    "ocid1.user.oc1..aaaaaaaaf4mzn8yexample",
+				fieldname.Fingerprint: "7c:0e:9a:51:d2:88:41:3f:e6:9b:13:0d:32:7a:c4:58",
+				fieldname.TenancyOCID: "ocid1.tenancy.oc1..aaaaaaaab2lqw4zexample",
+				fieldname.PrivateKey:  privateKey,
+				fieldname.Passphrase:  "correcthorsebatterystapleEXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"OCI_CLI_CONFIG_FILE": "/tmp/config",
+					"OCI_CLI_PROFILE":     "ADMIN",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/oci_api_key.pem": {Contents: []byte(privateKey)},
+					"/tmp/config": {Contents: []byte("[ADMIN]\n" +
+						"user=ocid1.user.oc1..aaaaaaaaf4mzn8yexample\n" +
+						"fingerprint=7c:0e:9a:51:d2:88:41:3f:e6:9b:13:0d:32:7a:c4:58\n" +
+						"tenancy=ocid1.tenancy.oc1..aaaaaaaab2lqw4zexample\n" +
+						"key_file=/tmp/oci_api_key.pem\n" +
+						"pass_phrase=correcthorsebatterystapleEXAMPLE\n"),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAPIKeyImporter(t *testing.T) {
+	plugintest.TestImporter(t, APIKey().Importer, map[string]plugintest.ImportCase{
+		"config file": {
+			Files: map[string]string{
+				"~/.oci/config":          plugintest.LoadFixture(t, "config"),
+				"~/.oci/oci_api_key.pem": plugintest.LoadFixture(t, "oci_api_key.pem"),
+				"~/.oci/admin_key.pem":   plugintest.LoadFixture(t, "admin_key.pem"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.UserOCID:    "ocid1.user.oc1..aaaaaaaa7kxvq3zm5example",
+						fieldname.Fingerprint: "20:3b:97:13:55:1c:5b:0d:d3:37:d8:50:4e:c5:3a:34",
+						fieldname.TenancyOCID: "ocid1.tenancy.oc1..aaaaaaaab2lqw4zexample",
+						fieldname.Region:      "us-ashburn-1",
+						fieldname.PrivateKey:  plugintest.LoadFixture(t, "oci_api_key.pem"),
+					},
+				},
+				{
+					NameHint: "ADMIN",
+					Fields: map[sdk.FieldName]string{
+						fieldname.UserOCID:    "ocid1.user.oc1..aaaaaaaaf4mzn8yexample",
+						fieldname.Fingerprint: "7c:0e:9a:51:d2:88:41:3f:e6:9b:13:0d:32:7a:c4:58",
+						fieldname.TenancyOCID: "ocid1.tenancy.oc1..aaaaaaaab2lqw4zexample",
+						fieldname.Region:      "eu-frankfurt-1",
+						fieldname.PrivateKey:  plugintest.LoadFixture(t, "admin_key.pem"),
+						fieldname.Passphrase:  "correcthorsebatterystapleEXAMPLE",
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,31 @@
+package oci
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func OracleCloudCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Oracle Cloud Infrastructure CLI",
+		Runs:    []string{"oci"},
+		DocsURL: sdk.URL("https://docs.oracle.com/iaas/Content/API/Concepts/cliconcepts.htm"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.NotWhenContainsArgs("setup"),
+		),
+		ProfileHint: &schema.ProfileHint{
+			Flags:   []string{"--profile"},
+			EnvVars: []string{"OCI_CLI_PROFILE"},
+			Default: defaultProfileName,
+		},
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.APIKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package packer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func PackerCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Packer CLI",
+		Runs:    []string{"packer"},
+		DocsURL: sdk.URL("https://developer.hashicorp.com/packer/docs/commands"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.IfAny(
+				needsauth.ForCommand("build"),
+				needsauth.ForCommand("push"),
+			),
+			usesHCPPackerRegistry(),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.ServicePrincipal,
+			},
+		},
+	}
+}
+
+// hcpPackerKeywords are the template blocks that make Packer talk to the HCP Packer registry: the
+// hcp_packer_registry build block and the hcp-packer-* data sources.
+var hcpPackerKeywords = []string{
+	"hcp_packer_registry",
+	"hcp-packer-",
+}
+
+// usesHCPPackerRegistry returns a NeedsAuthentication rule to require authentication only if the template passed
+// to the command uses HCP Packer, or if HCP_PACKER_BUCKET_NAME is set, which enables HCP Packer for any template.
+func usesHCPPackerRegistry() sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		if in.Environment["HCP_PACKER_BUCKET_NAME"] != "" {
+			return true
+		}
+
+		// The template is the last argument, since flags have to precede it.
+		if len(in.CommandArgs) < 2 {
+			return false
+		}
+		template := in.CommandArgs[len(in.CommandArgs)-1]
+		if strings.HasPrefix(template, "-") {
+			return false
+		}
+
+		return needsauth.IfWorkingDir(func(dir string) bool {
+			if !filepath.IsAbs(template) {
+				template = filepath.Join(dir, template)
+			}
+			return templateUsesHCPPacker(template)
+		})(in)
+	}
+}
+
+// templateUsesHCPPacker checks whether the template file, or any of the HCL2 template files in the template
+// directory, contain an HCP Packer block.
+func templateUsesHCPPacker(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.pkr.hcl"))
+		if err != nil {
+			return false
+		}
+	}
+
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, keyword := range hcpPackerKeywords {
+			if strings.Contains(string(contents), keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package packer
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestPackerNeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, PackerCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for build of a template with an HCP Packer registry block": {
+			Args:              []string{"build", "-var", "region=eu-west-1", "registry.pkr.hcl"},
+			WorkingDir:        "test-fixtures",
+			ExpectedNeedsAuth: true,
+		},
+		"yes for build of a template directory with an HCP Packer registry block": {
+			Args:              []string{"build", "test-fixtures"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for build of a template without HCP Packer blocks": {
+			Args:              []string{"build", "local.pkr.hcl"},
+			WorkingDir:        "test-fixtures",
+			ExpectedNeedsAuth: false,
+		},
+		"yes for build of a template without HCP Packer blocks with HCP_PACKER_BUCKET_NAME set": {
+			Args:              []string{"build", "local.pkr.hcl"},
+			WorkingDir:        "test-fixtures",
+			Environment:       map[string]string{"HCP_PACKER_BUCKET_NAME": "web-server"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for build of a template without HCP Packer blocks with HCP_PACKER_BUCKET_NAME empty": {
+			Args:              []string{"build", "local.pkr.hcl"},
+			WorkingDir:        "test-fixtures",
+			Environment:       map[string]string{"HCP_PACKER_BUCKET_NAME": ""},
+			ExpectedNeedsAuth: false,
+		},
+		"no for validate with HCP_PACKER_BUCKET_NAME set": {
+			Args:              []string{"validate", "local.pkr.hcl"},
+			WorkingDir:        "test-fixtures",
+			Environment:       map[string]string{"HCP_PACKER_BUCKET_NAME": "web-server"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for validate": {
+			Args:              []string{"validate", "registry.pkr.hcl"},
+			WorkingDir:        "test-fixtures",
+			ExpectedNeedsAuth: false,
+		},
+		"no for build help": {
+			Args:              []string{"build", "--help"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
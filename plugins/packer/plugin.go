@@ -0,0 +1,22 @@
+package packer
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "packer",
+		Platform: schema.PlatformInfo{
+			Name:     "HCP Packer",
+			Homepage: sdk.URL("https://developer.hashicorp.com/hcp/docs/packer"),
+		},
+		Credentials: []schema.CredentialType{
+			ServicePrincipal(),
+		},
+		Executables: []schema.Executable{
+			PackerCLI(),
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package packer
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func ServicePrincipal() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.ServicePrincipal,
+		DocsURL:       sdk.URL("https://developer.hashicorp.com/hcp/docs/hcp/iam/service-principal"),
+		ManagementURL: sdk.URL("https://portal.cloud.hashicorp.com/access/service-principals"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.ClientID,
+				MarkdownDescription: "The client ID of the HCP service principal key.",
+				Composition: &schema.ValueComposition{
+					Length: 32,
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+					},
+				},
+			},
+			{
+				Name:                fieldname.ClientSecret,
+				MarkdownDescription: "The client secret of the HCP service principal key.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 64,
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'-', '_'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.ProjectID,
+				MarkdownDescription: "The ID of the HCP project to push to. Only required if the organization has multiple projects.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer:           importer.TryEnvVarPair(defaultEnvVarMapping),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"HCP_CLIENT_ID":     fieldname.ClientID,
+	"HCP_CLIENT_SECRET": fieldname.ClientSecret,
+	"HCP_PROJECT_ID":    fieldname.ProjectID,
+}
@@ -0,0 +1,47 @@
+package packer
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestServicePrincipalProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, ServicePrincipal().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.ClientID:     "Qm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0EX",
+				fieldname.ClientSecret: "Lp4Wb9Yc6Jd1Hf5Gs0KaQm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0Zp4Qm9EXAMPLE",
+				fieldname.ProjectID:    "5c7d9e1f-2a4b-4c6d-8e0f-1a3b5c7d9e1f",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"HCP_CLIENT_ID":     "Qm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0EX",
+					"HCP_CLIENT_SECRET": "Lp4Wb9Yc6Jd1Hf5Gs0KaQm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0Zp4Qm9EXAMPLE",
+					"HCP_PROJECT_ID":    "5c7d9e1f-2a4b-4c6d-8e0f-1a3b5c7d9e1f",
+				},
+			},
+		},
+	})
+}
+
+func TestServicePrincipalImporter(t *testing.T) {
+	plugintest.TestImporter(t, ServicePrincipal().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"HCP_CLIENT_ID":     "Qm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0EX",
+				"HCP_CLIENT_SECRET": "Lp4Wb9Yc6Jd1Hf5Gs0KaQm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0Zp4Qm9EXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.ClientID:     "Qm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0EX",
+						fieldname.ClientSecret: "Lp4Wb9Yc6Jd1Hf5Gs0KaQm7Xv2Rk8Tn3Wb6Yc1Jd4Hf9Gs5La0Zp4Qm9EXAMPLE",
+					},
+				},
+			},
+		},
+	})
+}
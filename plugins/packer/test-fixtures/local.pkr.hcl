@@ -0,0 +1,8 @@
+source "docker" "ubuntu" {
+  image  = "ubuntu:22.04"
+  commit = true
+}
+
+build {
+  sources = ["source.docker.ubuntu"]
+}
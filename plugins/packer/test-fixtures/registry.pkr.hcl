@@ -0,0 +1,13 @@
+source "amazon-ebs" "ubuntu" {
+  ami_name      = "acme-ubuntu-{{timestamp}}"
+  instance_type = "t3.micro"
+  region        = "eu-west-1"
+}
+
+build {
+  hcp_packer_registry {
+    bucket_name = "acme-ubuntu"
+  }
+
+  sources = ["source.amazon-ebs.ubuntu"]
+}
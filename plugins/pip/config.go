@@ -0,0 +1,89 @@
+package pip
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"gopkg.in/ini.v1"
+)
+
+// TryIndexURLEnvVars imports the credentials embedded in the index URLs set in PIP_INDEX_URL and PIP_EXTRA_INDEX_URL.
+func TryIndexURLEnvVars() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		for _, envVarName := range []string{"PIP_INDEX_URL", "PIP_EXTRA_INDEX_URL"} {
+			value := os.Getenv(envVarName)
+			if value == "" {
+				continue
+			}
+
+			mode := ModeIndex
+			if envVarName == "PIP_EXTRA_INDEX_URL" {
+				mode = ModeExtra
+			}
+
+			attempt := out.NewAttempt(importer.SourceEnvVars(envVarName))
+			// Like pip itself, allow multiple URLs separated by whitespace.
+			for _, indexURL := range strings.Fields(value) {
+				addIndexCandidate(attempt, indexURL, mode)
+			}
+		}
+	}
+}
+
+// TryPipConfigFile imports the credentials embedded in the index URLs in the 'global' section of the pip config file.
+func TryPipConfigFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		// pip allows multiple extra index URLs as a Python-style multiline value.
+		config, err := ini.LoadSources(ini.LoadOptions{AllowPythonMultilineValues: true}, []byte(contents))
+		if err != nil {
+			out.AddError(err)
+			return
+		}
+
+		global := config.Section("global")
+		for _, indexURL := range strings.Fields(global.Key("index-url").String()) {
+			addIndexCandidate(out, indexURL, ModeIndex)
+		}
+		for _, indexURL := range strings.Fields(global.Key("extra-index-url").String()) {
+			addIndexCandidate(out, indexURL, ModeExtra)
+		}
+	})
+}
+
+// addIndexCandidate adds a candidate for the index URL if it has credentials embedded.
+func addIndexCandidate(out *sdk.ImportAttempt, rawURL string, mode string) {
+	indexURL, err := url.Parse(rawURL)
+	if err != nil || indexURL.User == nil {
+		return
+	}
+
+	fields := map[sdk.FieldName]string{}
+	if password, ok := indexURL.User.Password(); ok {
+		if username := indexURL.User.Username(); username != "" {
+			fields[fieldname.Username] = username
+		}
+		fields[fieldname.Token] = password
+	} else {
+		fields[fieldname.Token] = indexURL.User.Username()
+	}
+	if fields[fieldname.Token] == "" {
+		return
+	}
+	if mode == ModeExtra {
+		fields[fieldname.Mode] = ModeExtra
+	}
+
+	host := indexURL.Host
+	indexURL.User = nil
+	fields[fieldname.URL] = indexURL.String()
+
+	out.AddCandidate(sdk.ImportCandidate{
+		Fields:   fields,
+		NameHint: importer.SanitizeNameHint(host),
+	})
+}
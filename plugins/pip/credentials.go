@@ -0,0 +1,91 @@
+package pip
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func Credentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://pip.pypa.io/en/stable/topics/authentication/"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.URL,
+				MarkdownDescription: "The URL of the private package index, e.g. 'https://pypi.acme.com/simple/'.",
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The username used to authenticate to the index. Leave empty for indexes that take the token as username.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "The token or password used to authenticate to the index.",
+				Secret:              true,
+			},
+			{
+				Name:                fieldname.Mode,
+				MarkdownDescription: "Whether the index replaces PyPI ('index', the default) or is searched in addition to it ('extra').",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: pipProvisioner{},
+		Importer: importer.TryAll(
+			TryIndexURLEnvVars(),
+			TryPipConfigFile("~/.config/pip/pip.conf"),
+			TryPipConfigFile("~/.pip/pip.conf"),
+			importer.MacOnly(TryPipConfigFile("~/Library/Application Support/pip/pip.conf")),
+		),
+	}
+}
+
+const (
+	ModeIndex = "index"
+	ModeExtra = "extra"
+)
+
+// pipProvisioner provisions the index URL with the credentials embedded as PIP_INDEX_URL, or as
+// PIP_EXTRA_INDEX_URL if the index is used in addition to PyPI.
+type pipProvisioner struct {
+}
+
+func (p pipProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	indexURL, err := url.Parse(in.ItemFields[fieldname.URL])
+	if err != nil || indexURL.Host == "" {
+		out.AddError(fmt.Errorf("invalid index URL %q", in.ItemFields[fieldname.URL]))
+		return
+	}
+
+	token := in.ItemFields[fieldname.Token]
+	if username, ok := in.ItemFields[fieldname.Username]; ok {
+		indexURL.User = url.UserPassword(username, token)
+	} else {
+		indexURL.User = url.User(token)
+	}
+
+	switch mode := in.ItemFields[fieldname.Mode]; mode {
+	case "", ModeIndex:
+		out.AddEnvVar("PIP_INDEX_URL", indexURL.String())
+	case ModeExtra:
+		out.AddEnvVar("PIP_EXTRA_INDEX_URL", indexURL.String())
+	default:
+		out.AddError(fmt.Errorf("unknown mode %q, expected '%s' or '%s'", mode, ModeIndex, ModeExtra))
+	}
+}
+
+func (p pipProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p pipProvisioner) Description() string {
+	return "Provision environment variable PIP_INDEX_URL or PIP_EXTRA_INDEX_URL with the credentials embedded in the URL"
+}
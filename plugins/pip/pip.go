@@ -0,0 +1,30 @@
+package pip
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func PipCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "pip",
+		Runs:    []string{"pip"},
+		Aliases: []string{"pip3"},
+		DocsURL: sdk.URL("https://pip.pypa.io/en/stable/cli/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.IfAny(
+				needsauth.ForCommand("install"),
+				needsauth.ForCommand("download"),
+				needsauth.ForCommand("wheel"),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.Credentials,
+			},
+		},
+	}
+}
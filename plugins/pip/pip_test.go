@@ -0,0 +1,36 @@
+package pip
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestPipNeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, PipCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for install": {
+			Args:              []string{"install", "-r", "requirements.txt"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for download": {
+			Args:              []string{"download", "acme-sdk"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for wheel": {
+			Args:              []string{"wheel", "."},
+			ExpectedNeedsAuth: true,
+		},
+		"no for list": {
+			Args:              []string{"list"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for freeze": {
+			Args:              []string{"freeze"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for install help": {
+			Args:              []string{"install", "--help"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
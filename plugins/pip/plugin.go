@@ -0,0 +1,22 @@
+package pip
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "pip",
+		Platform: schema.PlatformInfo{
+			Name:     "pip",
+			Homepage: sdk.URL("https://pip.pypa.io"),
+		},
+		Credentials: []schema.CredentialType{
+			Credentials(),
+		},
+		Executables: []schema.Executable{
+			PipCLI(),
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package podman
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "podman",
+		Platform: schema.PlatformInfo{
+			Name:     "Podman",
+			Homepage: sdk.URL("https://podman.io"),
+		},
+		Executables: []schema.Executable{
+			PodmanCLI(),
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package podman
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func PodmanCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Podman CLI",
+		Runs:    []string{"podman"},
+		DocsURL: sdk.URL("https://docs.podman.io/en/latest/Commands.html"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			// Only the commands that can talk to a registry need the credentials. 'podman login' is left out on
+			// purpose: it would store the credentials in the temporary auth file, which gets deleted when it exits.
+			needsauth.IfAny(
+				needsauth.ForCommand("pull"),
+				needsauth.ForCommand("push"),
+				needsauth.ForCommand("search"),
+				needsauth.ForCommand("build"),
+				needsauth.ForCommand("run"),
+				needsauth.ForCommand("create"),
+				needsauth.ForCommand("image", "pull"),
+				needsauth.ForCommand("image", "push"),
+				needsauth.ForCommand("image", "build"),
+				needsauth.ForCommand("manifest", "push"),
+				needsauth.ForCommand("kube", "play"),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				// The registry credentials are shared with crane and skopeo. Their default provisioner generates a
+				// containers-auth.json and points REGISTRY_AUTH_FILE to it.
+				Name:   credname.RegistryCredentials,
+				Plugin: "containerregistry",
+			},
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package podman
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestPodmanCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, PodmanCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes for pull": {
+			Args:              []string{"pull", "ghcr.io/acme/web:1.0"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for manifest push": {
+			Args:              []string{"manifest", "push", "web:1.0", "docker://ghcr.io/acme/web:1.0"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for kube play": {
+			Args:              []string{"kube", "play", "web.yaml"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for ps": {
+			Args:              []string{"ps", "-a"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for login": {
+			Args:              []string{"login", "ghcr.io"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
@@ -37,6 +37,12 @@ func PulumiAccessToken() schema.CredentialType {
 				MarkdownDescription: "The Pulumi host to authenticate to. Defaults to 'app.pulumi.com'.",
 				Optional:            true,
 			},
+			{
+				Name:                fieldname.Passphrase,
+				MarkdownDescription: "The passphrase used to encrypt the config secrets of stacks that use the passphrase secrets provider.",
+				Secret:              true,
+				Optional:            true,
+			},
 		},
 		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
 		Importer: importer.TryAll(
@@ -46,8 +52,9 @@ func PulumiAccessToken() schema.CredentialType {
 }
 
 var defaultEnvVarMapping = map[string]sdk.FieldName{
-	"PULUMI_ACCESS_TOKEN": fieldname.Token,
-	"PULUMI_BACKEND_URL":  fieldname.Host,
+	"PULUMI_ACCESS_TOKEN":      fieldname.Token,
+	"PULUMI_BACKEND_URL":       fieldname.Host,
+	"PULUMI_CONFIG_PASSPHRASE": fieldname.Passphrase,
 }
 
 // Duplicated from:
@@ -32,6 +32,18 @@ func TestAccessTokenProvisioner(t *testing.T) {
 				},
 			},
 		},
+		"with-passphrase": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Token:      "pul-8s9b3qf8rx7x8x8pn03ibkemilm1zfs10example",
+				fieldname.Passphrase: "correct-horse-battery-staple",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"PULUMI_ACCESS_TOKEN":      "pul-8s9b3qf8rx7x8x8pn03ibkemilm1zfs10example",
+					"PULUMI_CONFIG_PASSPHRASE": "correct-horse-battery-staple",
+				},
+			},
+		},
 	})
 }
 
@@ -63,6 +75,20 @@ func TestAccessTokenImporter(t *testing.T) {
 				},
 			},
 		},
+		"environment-with-passphrase": {
+			Environment: map[string]string{
+				"PULUMI_ACCESS_TOKEN":      "pul-8s9b3qf8rx7x8x8pn03ibkemilm1zfs10example",
+				"PULUMI_CONFIG_PASSPHRASE": "correct-horse-battery-staple",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token:      "pul-8s9b3qf8rx7x8x8pn03ibkemilm1zfs10example",
+						fieldname.Passphrase: "correct-horse-battery-staple",
+					},
+				},
+			},
+		},
 		"config file": {
 			Files: map[string]string{
 				"~/.pulumi/credentials.json": plugintest.LoadFixture(t, "credentials.json"),
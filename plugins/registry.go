@@ -39,7 +39,7 @@ func Get(pluginName string) (schema.Plugin, error) {
 func GetByExecutable(executableQuery string) (schema.Plugin, schema.Executable, error) {
 	for _, p := range registry {
 		for _, e := range p.Executables {
-			if strings.EqualFold(executableQuery, e.Command()) || strings.EqualFold(executableQuery, e.Name) {
+			if e.RunsCommand(executableQuery) || strings.EqualFold(executableQuery, e.Name) {
 				return p, e, nil
 			}
 		}
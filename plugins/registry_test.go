@@ -3,6 +3,7 @@ package plugins
 import (
 	"testing"
 
+	"github.com/1Password/shell-plugins/sdk/plugintest"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -27,3 +28,9 @@ func TestAllPluginsHaveUniqueNames(t *testing.T) {
 
 	assert.True(t, schema.IsStringSliceASet(pluginNames))
 }
+
+func TestValueCompositions(t *testing.T) {
+	for _, p := range registry {
+		plugintest.TestValueCompositions(t, p)
+	}
+}
@@ -0,0 +1,138 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func APIKey() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIKey,
+		DocsURL:       sdk.URL("https://www.scaleway.com/en/docs/identity-and-access-management/iam/how-to/create-api-keys"),
+		ManagementURL: sdk.URL("https://console.scaleway.com/iam/api-keys"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.AccessKeyID,
+				MarkdownDescription: "The access key ID of the API key.",
+				Composition: &schema.ValueComposition{
+					Length: 20,
+					Prefix: "SCW",
+					Charset: schema.Charset{
+						Uppercase: true,
+						Digits:    true,
+					},
+				},
+			},
+			{
+				Name:                fieldname.SecretKey,
+				MarkdownDescription: "The secret key of the API key.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Length: 36,
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'-'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.ProjectID,
+				MarkdownDescription: "The ID of the project to use by default.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.OrgID,
+				MarkdownDescription: "The ID of the organization to use by default.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Region,
+				MarkdownDescription: "The region to use by default, e.g. 'fr-par'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Zone,
+				MarkdownDescription: "The zone to use by default, e.g. 'fr-par-1'.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryScalewayConfigFile(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"SCW_ACCESS_KEY":              fieldname.AccessKeyID,
+	"SCW_SECRET_KEY":              fieldname.SecretKey,
+	"SCW_DEFAULT_PROJECT_ID":      fieldname.ProjectID,
+	"SCW_DEFAULT_ORGANIZATION_ID": fieldname.OrgID,
+	"SCW_DEFAULT_REGION":          fieldname.Region,
+	"SCW_DEFAULT_ZONE":            fieldname.Zone,
+}
+
+// TryScalewayConfigFile imports the default credentials of the Scaleway CLI config file, as well as those of every
+// profile in it.
+func TryScalewayConfigFile() sdk.Importer {
+	return importer.TryFile("~/.config/scw/config.yaml", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config Config
+		if err := contents.ToYAML(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		addProfileCandidate(out, "", config.Profile)
+		for name, profile := range config.Profiles {
+			addProfileCandidate(out, name, profile)
+		}
+	})
+}
+
+func addProfileCandidate(out *sdk.ImportAttempt, name string, profile Profile) {
+	if profile.AccessKey == "" || profile.SecretKey == "" {
+		return
+	}
+
+	fields := map[sdk.FieldName]string{
+		fieldname.AccessKeyID: profile.AccessKey,
+		fieldname.SecretKey:   profile.SecretKey,
+	}
+	for fieldName, value := range map[sdk.FieldName]string{
+		fieldname.ProjectID: profile.DefaultProjectID,
+		fieldname.OrgID:     profile.DefaultOrganizationID,
+		fieldname.Region:    profile.DefaultRegion,
+		fieldname.Zone:      profile.DefaultZone,
+	} {
+		if value != "" {
+			fields[fieldName] = value
+		}
+	}
+
+	out.AddCandidate(sdk.ImportCandidate{
+		Fields:   fields,
+		NameHint: importer.SanitizeNameHint(name),
+	})
+}
+
+type Config struct {
+	Profile  `yaml:",inline"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+type Profile struct {
+	AccessKey             string `yaml:"access_key"`
+	SecretKey             string `yaml:"secret_key"`
+	DefaultProjectID      string `yaml:"default_project_id"`
+	DefaultOrganizationID string `yaml:"default_organization_id"`
+	DefaultRegion         string `yaml:"default_region"`
+	DefaultZone           string `yaml:"default_zone"`
+}
@@ -0,0 +1,75 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPIKeyProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, APIKey().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.AccessKeyID: "SCWXXXXXXXXXEXAMPLE1",
+				fieldname.SecretKey:   "1f2e3d4c-5b6a-4987-a6b5-c4d3eEXAMPLE",
+				fieldname.ProjectID:   "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+				fieldname.Region:      "fr-par",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"SCW_ACCESS_KEY":         "SCWXXXXXXXXXEXAMPLE1",
+					"SCW_SECRET_KEY":         "1f2e3d4c-5b6a-4987-a6b5-c4d3eEXAMPLE",
+					"SCW_DEFAULT_PROJECT_ID": "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+					"SCW_DEFAULT_REGION":     "fr-par",
+				},
+			},
+		},
+	})
+}
+
+func TestAPIKeyImporter(t *testing.T) {
+	plugintest.TestImporter(t, APIKey().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"SCW_ACCESS_KEY": "SCWXXXXXXXXXEXAMPLE1",
+				"SCW_SECRET_KEY": "1f2e3d4c-5b6a-4987-a6b5-c4d3eEXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.AccessKeyID: "SCWXXXXXXXXXEXAMPLE1",
+						fieldname.SecretKey:   "1f2e3d4c-5b6a-4987-a6b5-c4d3eEXAMPLE",
+					},
+				},
+			},
+		},
+		"config file": {
+			Files: map[string]string{
+				"~/.config/scw/config.yaml": plugintest.LoadFixture(t, "config.yaml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.AccessKeyID: "SCWXXXXXXXXXEXAMPLE1",
+						fieldname.SecretKey:   "1f2e3d4c-5b6a-4987-a6b5-c4d3eEXAMPLE",
+						fieldname.ProjectID:   "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+						fieldname.OrgID:       "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+						fieldname.Region:      "fr-par",
+						fieldname.Zone:        "fr-par-1",
+					},
+				},
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.AccessKeyID: "SCWYYYYYYYYYEXAMPLE2",
+						fieldname.SecretKey:   "9e8d7c6b-5a4f-4e3d-b2c1-a0f9eEXAMPLE",
+						fieldname.ProjectID:   "0d9c8b7a-6f5e-4d4c-9b3a-2f1e0d9c8b7a",
+						fieldname.Region:      "nl-ams",
+					},
+					NameHint: "staging",
+				},
+			},
+		},
+	})
+}
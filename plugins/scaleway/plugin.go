@@ -0,0 +1,22 @@
+package scaleway
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "scaleway",
+		Platform: schema.PlatformInfo{
+			Name:     "Scaleway",
+			Homepage: sdk.URL("https://www.scaleway.com"),
+		},
+		Credentials: []schema.CredentialType{
+			APIKey(),
+		},
+		Executables: []schema.Executable{
+			ScalewayCLI(),
+		},
+	}
+}
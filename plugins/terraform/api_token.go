@@ -0,0 +1,112 @@
+package terraform
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/1Password/shell-plugins/sdk/verify"
+)
+
+func APIToken() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIToken,
+		DocsURL:       sdk.URL("https://developer.hashicorp.com/terraform/cloud-docs/users-teams-organizations/api-tokens"),
+		ManagementURL: sdk.URL("https://app.terraform.io/app/settings/tokens"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "API token used to authenticate to Terraform Cloud or Terraform Enterprise.",
+				Secret:              true,
+			},
+			{
+				Name:                fieldname.Host,
+				MarkdownDescription: "The hostname of your Terraform Enterprise instance. Defaults to 'app.terraform.io' (Terraform Cloud).",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: hostProvisioner{},
+		Importer: importer.TryAll(
+			TryTokenEnvVars(),
+			TryTerraformCredentialsFile(),
+		),
+		Verifier: verify.HTTP(verifyRequest),
+	}
+}
+
+// TryTokenEnvVars imports the tokens set in TF_TOKEN_<host> environment variables.
+func TryTokenEnvVars() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		var envVarNames []string
+		for _, env := range os.Environ() {
+			name, value, found := strings.Cut(env, "=")
+			if !found || value == "" || !strings.HasPrefix(name, tokenEnvVarPrefix) {
+				continue
+			}
+			envVarNames = append(envVarNames, name)
+		}
+		sort.Strings(envVarNames)
+
+		for _, name := range envVarNames {
+			host := hostFromEnvVarName(name)
+			attempt := out.NewAttempt(importer.SourceEnvVars(name))
+			attempt.AddCandidate(sdk.ImportCandidate{
+				Fields:   credentialFields(host, os.Getenv(name)),
+				NameHint: nameHint(host),
+			})
+		}
+	}
+}
+
+// TryTerraformCredentialsFile imports the tokens for every host stored by 'terraform login'.
+func TryTerraformCredentialsFile() sdk.Importer {
+	return importer.TryFile("~/.terraform.d/credentials.tfrc.json", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config CredentialsFile
+		if err := contents.ToJSON(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for host, credentials := range config.Credentials {
+			if credentials.Token == "" {
+				continue
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields:   credentialFields(host, credentials.Token),
+				NameHint: nameHint(host),
+			})
+		}
+	})
+}
+
+func credentialFields(host string, token string) map[sdk.FieldName]string {
+	fields := map[sdk.FieldName]string{
+		fieldname.Token: token,
+	}
+	if normalizeHost(host) != defaultHost {
+		fields[fieldname.Host] = normalizeHost(host)
+	}
+	return fields
+}
+
+func nameHint(host string) string {
+	if normalizeHost(host) == defaultHost {
+		return ""
+	}
+	return importer.SanitizeNameHint(normalizeHost(host))
+}
+
+type CredentialsFile struct {
+	Credentials map[string]HostCredentials `json:"credentials"`
+}
+
+type HostCredentials struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,72 @@
+package terraform
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const (
+	defaultHost       = "app.terraform.io"
+	tokenEnvVarPrefix = "TF_TOKEN_"
+)
+
+// hostProvisioner provisions the token in the TF_TOKEN_<host> environment variable, which Terraform and OpenTofu
+// read to authenticate to the matching Terraform Cloud or Enterprise host.
+type hostProvisioner struct{}
+
+func (p hostProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	out.AddEnvVar(tokenEnvVarName(in.ItemFields[fieldname.Host]), in.ItemFields[fieldname.Token])
+}
+
+func (p hostProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p hostProvisioner) Description() string {
+	return "Provision environment variable TF_TOKEN_<host>, e.g. TF_TOKEN_app_terraform_io"
+}
+
+// normalizeHost turns the value of the host field into a bare hostname, so that both 'tfe.acme.com' and
+// 'https://tfe.acme.com/' are accepted. An empty host means Terraform Cloud.
+func normalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimSuffix(host, "/")
+	if host == "" {
+		return defaultHost
+	}
+	return strings.ToLower(host)
+}
+
+// tokenEnvVarName returns the name of the environment variable that holds the token for the specified host.
+// Periods are encoded as underscores and hyphens as double underscores, e.g. 'tfe.my-corp.com' becomes
+// 'TF_TOKEN_tfe_my__corp_com'.
+func tokenEnvVarName(host string) string {
+	host = normalizeHost(host)
+	host = strings.ReplaceAll(host, "-", "__")
+	host = strings.ReplaceAll(host, ".", "_")
+	return tokenEnvVarPrefix + host
+}
+
+// hostFromEnvVarName is the inverse of tokenEnvVarName.
+func hostFromEnvVarName(name string) string {
+	host := strings.TrimPrefix(name, tokenEnvVarPrefix)
+	host = strings.ReplaceAll(host, "__", "-")
+	host = strings.ReplaceAll(host, "_", ".")
+	return strings.ToLower(host)
+}
+
+// verifyRequest builds the request that checks the token against the account details endpoint of the item's host.
+func verifyRequest(ctx context.Context, in sdk.VerifyInput) (*http.Request, error) {
+	url := "https://" + normalizeHost(in.ItemFields[fieldname.Host]) + "/api/v2/account/details"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+in.ItemFields[fieldname.Token])
+	return req, nil
+}
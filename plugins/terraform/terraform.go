@@ -4,18 +4,38 @@ import (
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/needsauth"
 	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
 )
 
 func TerraformCLI() schema.Executable {
 	return schema.Executable{
 		Name:    "Terraform CLI",
 		Runs:    []string{"terraform"},
+		Aliases: []string{"tofu"},
 		DocsURL: sdk.URL("https://developer.hashicorp.com/terraform/cli"),
 		NeedsAuth: needsauth.IfAll(
 			needsauth.NotForHelpOrVersion(),
 			needsauth.NotWithoutArgs(),
 		),
 		Uses: []schema.CredentialUsage{
+			{
+				Name:        credname.APIToken,
+				Description: "API token to use for Terraform Cloud or Terraform Enterprise backends and private registries",
+				Optional:    true,
+				NeedsAuth: needsauth.IfAny(
+					needsauth.ForCommand("init"),
+					needsauth.ForCommand("plan"),
+					needsauth.ForCommand("apply"),
+					needsauth.ForCommand("destroy"),
+					needsauth.ForCommand("refresh"),
+					needsauth.ForCommand("import"),
+					needsauth.ForCommand("state"),
+					needsauth.ForCommand("output"),
+					needsauth.ForCommand("workspace"),
+					needsauth.ForCommand("force-unlock"),
+					needsauth.ForCommand("login"),
+				),
+			},
 			{
 				Description: "Credentials to use within the Terraform project",
 				SelectFrom: &schema.CredentialSelection{
@@ -0,0 +1,44 @@
+package tsh
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func Credentials() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.Credentials,
+		DocsURL:       sdk.URL("https://goteleport.com/docs/reference/cli/tsh/"),
+		ManagementURL: nil,
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Proxy,
+				MarkdownDescription: "The address of the Teleport proxy, e.g. 'teleport.acme.com:443'.",
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The Teleport user to log in as.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.IdentityFile,
+				MarkdownDescription: "The contents of an identity file, as exported with 'tsh login --out' or 'tctl auth sign'.",
+				Secret:              true,
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: tshProvisioner{},
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryTshProfiles(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"TELEPORT_PROXY": fieldname.Proxy,
+	"TELEPORT_USER":  fieldname.Username,
+}
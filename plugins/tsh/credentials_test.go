@@ -0,0 +1,79 @@
+package tsh
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestCredentialsProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, Credentials().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"proxy and user": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Proxy:    "teleport.acme.com:443",
+				fieldname.Username: "wendy@acme.com",
+			},
+			CommandLine: []string{"tsh", "login"},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"TELEPORT_PROXY": "teleport.acme.com:443",
+					"TELEPORT_USER":  "wendy@acme.com",
+				},
+				CommandLine: []string{"tsh", "login"},
+			},
+		},
+		"identity file": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Proxy:        "teleport.acme.com:443",
+				fieldname.IdentityFile: plugintest.LoadFixture(t, "identity"),
+			},
+			CommandLine: []string{"tsh", "ssh", "root@web", "ls", "-l"},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"TELEPORT_PROXY": "teleport.acme.com:443",
+				},
+				Files: map[string]sdk.OutputFile{
+					"/tmp/identity": {Contents: []byte(plugintest.LoadFixture(t, "identity"))},
+				},
+				CommandLine: []string{"tsh", "--identity=/tmp/identity", "ssh", "root@web", "ls", "-l"},
+			},
+		},
+	})
+}
+
+func TestCredentialsImporter(t *testing.T) {
+	plugintest.TestImporter(t, Credentials().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"TELEPORT_PROXY": "teleport.acme.com:443",
+				"TELEPORT_USER":  "wendy@acme.com",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Proxy:    "teleport.acme.com:443",
+						fieldname.Username: "wendy@acme.com",
+					},
+				},
+			},
+		},
+		"tsh profiles": {
+			Files: map[string]string{
+				"~/.tsh/teleport.acme.com.yaml":                plugintest.LoadFixture(t, "teleport.acme.com.yaml"),
+				"~/.tsh/current-profile":                       "teleport.acme.com",
+				"~/.tsh/keys/teleport.acme.com/wendy@acme.com": plugintest.LoadFixture(t, "identity"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Proxy:    "teleport.acme.com:443",
+						fieldname.Username: "wendy@acme.com",
+					},
+					NameHint: "teleport.acme.com",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package tsh
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "tsh",
+		Platform: schema.PlatformInfo{
+			Name:     "Teleport",
+			Homepage: sdk.URL("https://goteleport.com"),
+		},
+		Credentials: []schema.CredentialType{
+			Credentials(),
+		},
+		Executables: []schema.Executable{
+			TeleportCLI(),
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package tsh
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// Profile holds the parts of a tsh profile that are relevant for authentication. tsh stores a profile
+// for every proxy it logged in to as ~/.tsh/<proxy host>.yaml.
+type Profile struct {
+	WebProxyAddr string `yaml:"web_proxy_addr"`
+	User         string `yaml:"user"`
+}
+
+// TryTshProfiles imports the proxy and user of every profile created with 'tsh login'. The certificates that
+// tsh keeps next to the profiles are short-lived, so these are not imported.
+func TryTshProfiles() sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		entries, err := os.ReadDir(in.FromHomeDir(".tsh"))
+		if err != nil {
+			return
+		}
+
+		for _, entry := range entries {
+			profileName := strings.TrimSuffix(entry.Name(), ".yaml")
+			if entry.IsDir() || profileName == entry.Name() {
+				continue
+			}
+
+			importer.TryFile(filepath.Join("~/.tsh", entry.Name()), func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+				var profile Profile
+				if err := contents.ToYAML(&profile); err != nil {
+					out.AddError(err)
+					return
+				}
+
+				if profile.WebProxyAddr == "" {
+					return
+				}
+
+				fields := map[sdk.FieldName]string{
+					fieldname.Proxy: profile.WebProxyAddr,
+				}
+				if profile.User != "" {
+					fields[fieldname.Username] = profile.User
+				}
+
+				out.AddCandidate(sdk.ImportCandidate{
+					Fields:   fields,
+					NameHint: importer.SanitizeNameHint(profileName),
+				})
+			})(ctx, in, out)
+		}
+	}
+}
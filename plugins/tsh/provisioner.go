@@ -0,0 +1,46 @@
+package tsh
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// tshProvisioner provisions the proxy and user as environment variables and, if the item holds an identity file,
+// writes it to a temporary file and passes its path to tsh with the '--identity' flag.
+type tshProvisioner struct {
+}
+
+func (p tshProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	for envVarName, fieldName := range defaultEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
+
+	identity, ok := in.ItemFields[fieldname.IdentityFile]
+	if !ok {
+		return
+	}
+
+	path := filepath.Join(in.TempDir, "identity")
+	out.AddSecretFile(path, []byte(identity))
+
+	// The flag is inserted right after the executable, so that it doesn't end up in the command run by
+	// subcommands like 'tsh ssh', which pass all trailing args on to the remote host.
+	if len(out.CommandLine) == 0 {
+		return
+	}
+	commandLine := []string{out.CommandLine[0], "--identity=" + path}
+	out.CommandLine = append(commandLine, out.CommandLine[1:]...)
+}
+
+func (p tshProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: files get deleted automatically by 1Password CLI and environment variables get wiped when the process exits.
+}
+
+func (p tshProvisioner) Description() string {
+	return "Provision environment variables with the Teleport proxy and user, and an identity file passed with '--identity'"
+}
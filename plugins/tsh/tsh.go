@@ -0,0 +1,27 @@
+package tsh
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func TeleportCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Teleport CLI",
+		Runs:    []string{"tsh"},
+		DocsURL: sdk.URL("https://goteleport.com/docs/reference/cli/tsh/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.NotWithoutArgs(),
+			needsauth.Not(needsauth.ForCommand("logout")),
+			needsauth.Not(needsauth.ForCommand("config")),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.Credentials,
+			},
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package tsh
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestTeleportCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuth(t, TeleportCLI().NeedsAuth, map[string]plugintest.NeedsAuthCase{
+		"no for --help": {
+			Args:              []string{"--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for logout": {
+			Args:              []string{"logout"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for config": {
+			Args:              []string{"config"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for ssh": {
+			Args:              []string{"ssh", "root@web"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for ssh running config": {
+			Args:              []string{"ssh", "root@web", "cat", "/etc/app/config"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for ssh running logout": {
+			Args:              []string{"ssh", "root@web", "logout"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for ssh config": {
+			Args:              []string{"ssh", "root@web", "config"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for login": {
+			Args:              []string{"login"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package twine
+
+import (
+	"context"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"gopkg.in/ini.v1"
+)
+
+// tokenUsername is the username with which PyPI API tokens get passed to twine.
+const tokenUsername = "__token__"
+
+func APIToken() schema.CredentialType {
+	return schema.CredentialType{
+		Name:          credname.APIToken,
+		DocsURL:       sdk.URL("https://pypi.org/help/#apitoken"),
+		ManagementURL: sdk.URL("https://pypi.org/manage/account/token/"),
+		Fields: []schema.CredentialField{
+			{
+				Name:                fieldname.Token,
+				MarkdownDescription: "API token used to upload packages to PyPI, or the password for a private index.",
+				Secret:              true,
+				Composition: &schema.ValueComposition{
+					Prefix: "pypi-",
+					Charset: schema.Charset{
+						Uppercase: true,
+						Lowercase: true,
+						Digits:    true,
+						Specific:  []rune{'-', '_'},
+					},
+				},
+			},
+			{
+				Name:                fieldname.URL,
+				MarkdownDescription: "The upload URL of the repository, if not PyPI, e.g. 'https://pypi.acme.com/simple/'.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.Username,
+				MarkdownDescription: "The username for a private index that doesn't accept API tokens. Defaults to '__token__'.",
+				Optional:            true,
+			},
+		},
+		DefaultProvisioner: twineProvisioner{},
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			TryPypirc(),
+		),
+	}
+}
+
+var defaultEnvVarMapping = map[string]sdk.FieldName{
+	"TWINE_PASSWORD":       fieldname.Token,
+	"TWINE_REPOSITORY_URL": fieldname.URL,
+}
+
+// twineProvisioner provisions the token as TWINE_PASSWORD, along with the username that goes with it.
+type twineProvisioner struct {
+}
+
+func (p twineProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	for envVarName, fieldName := range defaultEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
+
+	username, ok := in.ItemFields[fieldname.Username]
+	if !ok {
+		username = tokenUsername
+	}
+	out.AddEnvVar("TWINE_USERNAME", username)
+}
+
+func (p twineProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p twineProvisioner) Description() string {
+	return "Provision environment variables TWINE_USERNAME, TWINE_PASSWORD and TWINE_REPOSITORY_URL"
+}
+
+// defaultRepositoryURLs are the upload URLs of PyPI, which don't need to be stored in the item.
+var defaultRepositoryURLs = []string{
+	"https://upload.pypi.org/legacy/",
+	"https://upload.pypi.org/legacy",
+}
+
+// TryPypirc imports the credentials of every repository in ~/.pypirc that has a password set.
+func TryPypirc() sdk.Importer {
+	return importer.TryFile("~/.pypirc", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		// .pypirc lists the index servers as a Python-style multiline value.
+		config, err := ini.LoadSources(ini.LoadOptions{AllowPythonMultilineValues: true}, []byte(contents))
+		if err != nil {
+			out.AddError(err)
+			return
+		}
+
+		for _, section := range config.Sections() {
+			password := section.Key("password").String()
+			if password == "" {
+				continue
+			}
+
+			fields := map[sdk.FieldName]string{
+				fieldname.Token: password,
+			}
+			if username := section.Key("username").String(); username != "" && username != tokenUsername {
+				fields[fieldname.Username] = username
+			}
+			if repository := strings.TrimSpace(section.Key("repository").String()); repository != "" && !isDefaultRepository(repository) {
+				fields[fieldname.URL] = repository
+			}
+
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields:   fields,
+				NameHint: importer.SanitizeNameHint(section.Name()),
+			})
+		}
+	})
+}
+
+func isDefaultRepository(url string) bool {
+	for _, defaultURL := range defaultRepositoryURLs {
+		if url == defaultURL {
+			return true
+		}
+	}
+	return false
+}
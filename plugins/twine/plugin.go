@@ -0,0 +1,22 @@
+package twine
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func New() schema.Plugin {
+	return schema.Plugin{
+		Name: "twine",
+		Platform: schema.PlatformInfo{
+			Name:     "PyPI",
+			Homepage: sdk.URL("https://pypi.org"),
+		},
+		Credentials: []schema.CredentialType{
+			APIToken(),
+		},
+		Executables: []schema.Executable{
+			TwineCLI(),
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package twine
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/1Password/shell-plugins/sdk/schema/credname"
+)
+
+func TwineCLI() schema.Executable {
+	return schema.Executable{
+		Name:    "Twine",
+		Runs:    []string{"twine"},
+		DocsURL: sdk.URL("https://twine.readthedocs.io/en/stable/"),
+		NeedsAuth: needsauth.IfAll(
+			needsauth.NotForHelpOrVersion(),
+			needsauth.IfAny(
+				needsauth.ForCommand("upload"),
+				needsauth.ForCommand("register"),
+			),
+		),
+		Uses: []schema.CredentialUsage{
+			{
+				Name: credname.APIToken,
+			},
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+const (
+	// loginTimeout limits how long the AppRole login can take, so that an unreachable server doesn't block the
+	// executable indefinitely.
+	loginTimeout = 30 * time.Second
+
+	// tokenExpiryMargin makes sure a cached token doesn't expire while the executable is using it.
+	tokenExpiryMargin = time.Minute
+
+	tokenCacheKey = "approle-token"
+
+	// dryRunToken is provisioned instead of a real token in dry runs, to skip the AppRole login.
+	dryRunToken = "<vault token>"
+)
+
+type cachedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// appRoleToken returns a cached token for the AppRole, or logs in with the role ID and secret ID to get a new one,
+// as described in https://developer.hashicorp.com/vault/api-docs/auth/approle#login-with-approle.
+func (p vaultProvisioner) appRoleToken(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) (string, error) {
+	if in.DryRun {
+		return dryRunToken, nil
+	}
+
+	cache := in.NamespacedCache(out, "vault", in.ItemFingerprint(fieldname.Address, fieldname.Namespace, fieldname.RoleID, fieldname.SecretID))
+	var cached cachedToken
+	if cache.Get(tokenCacheKey, &cached) && cached.Token != "" {
+		return cached.Token, nil
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"role_id":   in.ItemFields[fieldname.RoleID],
+		"secret_id": in.ItemFields[fieldname.SecretID],
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Unlike the Vault CLI, don't fall back to VAULT_ADDR or the local default address, since the secret ID would
+	// then get sent to whichever server happens to be configured in the environment.
+	address := strings.TrimSuffix(in.ItemFields[fieldname.Address], "/")
+	if address == "" {
+		return "", errors.New("an address is required to log in to Vault with an AppRole")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address+"/v1/auth/approle/login", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if namespace := in.ItemFields[fieldname.Namespace]; namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	client := p.client
+	if client == nil {
+		client, err = newLoginClient()
+		if err != nil {
+			return "", err
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("logging in to Vault with AppRole: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var login struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+		Errors []string `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("decoding AppRole login response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || login.Auth.ClientToken == "" {
+		if len(login.Errors) > 0 {
+			return "", fmt.Errorf("logging in to Vault with AppRole: %s (%s)", strings.Join(login.Errors, ", "), resp.Status)
+		}
+		return "", fmt.Errorf("logging in to Vault with AppRole: unexpected response: %s", resp.Status)
+	}
+
+	now := in.Now()
+	expiresAt := now.Add(time.Duration(login.Auth.LeaseDuration) * time.Second).Add(-tokenExpiryMargin)
+	if expiresAt.After(now) {
+		err = cache.PutUntil(tokenCacheKey, cachedToken{Token: login.Auth.ClientToken, ExpiresAt: expiresAt}, expiresAt)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return login.Auth.ClientToken, nil
+}
+
+// newLoginClient returns the HTTP client to log in with. Like the Vault CLI, it trusts the CA certificate in the file
+// set in VAULT_CACERT, if any, in addition to the system's trusted certificates.
+func newLoginClient() (*http.Client, error) {
+	client := &http.Client{Timeout: loginTimeout}
+
+	caCertPath := os.Getenv("VAULT_CACERT")
+	if caCertPath == "" {
+		return client, nil
+	}
+	caCert, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading VAULT_CACERT: %w", err)
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificates found in VAULT_CACERT file %s", caCertPath)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	client.Transport = transport
+	return client, nil
+}
@@ -1,9 +1,11 @@
 package vault
 
 import (
+	"context"
+	"strings"
+
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
-	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
@@ -17,12 +19,13 @@ func AuthToken() schema.CredentialType {
 		Fields: []schema.CredentialField{
 			{
 				Name:                fieldname.Token,
-				MarkdownDescription: "Token used to authenticate to HashiCorp Vault.",
+				MarkdownDescription: "Token used to authenticate to HashiCorp Vault. Not needed when an AppRole role ID and secret ID are set.",
 				Secret:              true,
+				Optional:            true,
 			},
 			{
 				Name:                fieldname.Address,
-				MarkdownDescription: "Default address of the Vault server to use for this auth token.",
+				MarkdownDescription: "Default address of the Vault server to use for this auth token. Required to log in with an AppRole.",
 				Optional:            true,
 			},
 			{
@@ -30,8 +33,19 @@ func AuthToken() schema.CredentialType {
 				MarkdownDescription: "Default namespace to use for this auth token.",
 				Optional:            true,
 			},
+			{
+				Name:                fieldname.RoleID,
+				MarkdownDescription: "Role ID of the AppRole to log in with, to get a short-lived token instead of storing one.",
+				Optional:            true,
+			},
+			{
+				Name:                fieldname.SecretID,
+				MarkdownDescription: "Secret ID of the AppRole to log in with.",
+				Secret:              true,
+				Optional:            true,
+			},
 		},
-		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
+		DefaultProvisioner: vaultProvisioner{},
 		Importer: importer.TryAll(
 			importer.TryEnvVarPair(defaultEnvVarMapping),
 			TryVaultTokenFile(),
@@ -45,7 +59,18 @@ var defaultEnvVarMapping = map[string]sdk.FieldName{
 	"VAULT_NAMESPACE": fieldname.Namespace,
 }
 
+// TryVaultTokenFile imports the token that 'vault login' stores in the default token helper file.
 func TryVaultTokenFile() sdk.Importer {
-	// TODO: Try importing from ~/.vault-token file
-	return importer.NoOp()
+	return importer.TryFile("~/.vault-token", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		token := strings.TrimSpace(string(contents))
+		if token == "" {
+			return
+		}
+
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				fieldname.Token: token,
+			},
+		})
+	})
 }
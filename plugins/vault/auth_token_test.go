@@ -1,11 +1,21 @@
 package vault
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/plugintest"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthTokenImporter(t *testing.T) {
@@ -26,6 +36,18 @@ func TestAuthTokenImporter(t *testing.T) {
 				},
 			},
 		},
+		"token file": {
+			Files: map[string]string{
+				"~/.vault-token": "hvs.CAESIJ5kQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE\n",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token: "hvs.CAESIJ5kQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+					},
+				},
+			},
+		},
 	})
 }
 
@@ -45,5 +67,130 @@ func TestAuthTokenProvisioner(t *testing.T) {
 				},
 			},
 		},
+		"AppRole without address": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.RoleID:   "db02de05-fa39-4855-059b-67221c5c2f63",
+				fieldname.SecretID: "6a174c20-f6de-a53c-74d2-6018fcceff64",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "an address is required to log in to Vault with an AppRole"}},
+				},
+			},
+		},
+		"AppRole without secret ID": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.Address: "https://vault.acme.com",
+				fieldname.RoleID:  "db02de05-fa39-4855-059b-67221c5c2f63",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"VAULT_ADDR": "https://vault.acme.com",
+				},
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "either a token, or an AppRole role ID and secret ID are required to authenticate to Vault"}},
+				},
+			},
+		},
 	})
 }
+
+func TestAuthTokenProvisionerAppRole(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/v1/auth/approle/login", r.URL.Path)
+		assert.Equal(t, "admin", r.Header.Get("X-Vault-Namespace"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "db02de05-fa39-4855-059b-67221c5c2f63", body["role_id"])
+		assert.Equal(t, "6a174c20-f6de-a53c-74d2-6018fcceff64", body["secret_id"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "hvs.CAESIJ5kQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE", "lease_duration": 1200}}`))
+	}))
+	defer server.Close()
+
+	itemFields := map[sdk.FieldName]string{
+		fieldname.Address:   server.URL,
+		fieldname.Namespace: "admin",
+		fieldname.RoleID:    "db02de05-fa39-4855-059b-67221c5c2f63",
+		fieldname.SecretID:  "6a174c20-f6de-a53c-74d2-6018fcceff64",
+	}
+
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(1200 * time.Second).Add(-tokenExpiryMargin)
+	in := sdk.ProvisionInput{ItemFields: itemFields}
+	cacheKey := "vault|" + in.ItemFingerprint(fieldname.Address, fieldname.Namespace, fieldname.RoleID, fieldname.SecretID) + "|" + tokenCacheKey
+	expectedCache := sdk.CacheOperations{Puts: map[string]sdk.CacheEntry{}}
+	require.NoError(t, expectedCache.Put(cacheKey, cachedToken{Token: "hvs.CAESIJ5kQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE", ExpiresAt: expiresAt}, expiresAt))
+
+	environment := map[string]string{
+		"VAULT_TOKEN":     "hvs.CAESIJ5kQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+		"VAULT_ADDR":      server.URL,
+		"VAULT_NAMESPACE": "admin",
+	}
+
+	plugintest.TestProvisioner(t, vaultProvisioner{client: server.Client()}, map[string]plugintest.ProvisionCase{
+		"login": {
+			ItemFields: itemFields,
+			Now:        now,
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: environment,
+				Cache:       expectedCache,
+			},
+		},
+	})
+	assert.Equal(t, 1, requests)
+
+	plugintest.TestProvisioner(t, vaultProvisioner{client: server.Client()}, map[string]plugintest.ProvisionCase{
+		"cached": {
+			ItemFields: itemFields,
+			Now:        now.Add(10 * time.Minute),
+			Cache: sdk.CacheState{
+				cacheKey: expectedCache.Puts[cacheKey],
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: environment,
+			},
+		},
+	})
+	assert.Equal(t, 1, requests, "the cached token should be used")
+}
+
+func TestAuthTokenProvisionerAppRoleTrustsVaultCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "hvs.CAESIJ5kQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE", "lease_duration": 0}}`))
+	}))
+	defer server.Close()
+
+	caCertPath := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertPath, caCert, 0600))
+	t.Setenv("VAULT_CACERT", caCertPath)
+
+	out := sdk.ProvisionOutput{Environment: make(map[string]string)}
+	vaultProvisioner{}.Provision(context.Background(), sdk.ProvisionInput{
+		ItemFields: map[sdk.FieldName]string{
+			fieldname.Address:  server.URL,
+			fieldname.RoleID:   "db02de05-fa39-4855-059b-67221c5c2f63",
+			fieldname.SecretID: "6a174c20-f6de-a53c-74d2-6018fcceff64",
+		},
+	}, &out)
+
+	assert.Empty(t, out.Diagnostics.Errors)
+	assert.Equal(t, "hvs.CAESIJ5kQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE", out.Environment["VAULT_TOKEN"])
+}
+
+func TestNewLoginClient(t *testing.T) {
+	t.Setenv("VAULT_CACERT", "")
+	client, err := newLoginClient()
+	require.NoError(t, err)
+	assert.Equal(t, loginTimeout, client.Timeout)
+
+	t.Setenv("VAULT_CACERT", filepath.Join(t.TempDir(), "missing.pem"))
+	_, err = newLoginClient()
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// vaultProvisioner provisions the token stored in the item, or, if the item holds AppRole credentials instead,
+// logs in with the AppRole and provisions the resulting token.
+type vaultProvisioner struct {
+	// client is the HTTP client used to log in with the AppRole. Defaults to a client with a timeout that trusts
+	// the CA certificate in VAULT_CACERT.
+	client *http.Client
+}
+
+func (p vaultProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	for envVarName, fieldName := range defaultEnvVarMapping {
+		if value, ok := in.ItemFields[fieldName]; ok {
+			out.AddEnvVar(envVarName, value)
+		}
+	}
+
+	if _, ok := in.ItemFields[fieldname.Token]; ok {
+		return
+	}
+
+	_, hasRoleID := in.ItemFields[fieldname.RoleID]
+	_, hasSecretID := in.ItemFields[fieldname.SecretID]
+	if !hasRoleID || !hasSecretID {
+		out.AddError(errors.New("either a token, or an AppRole role ID and secret ID are required to authenticate to Vault"))
+		return
+	}
+
+	token, err := p.appRoleToken(ctx, in, out)
+	if err != nil {
+		out.AddError(err)
+		return
+	}
+	out.AddEnvVar("VAULT_TOKEN", token)
+}
+
+func (p vaultProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p vaultProvisioner) Description() string {
+	return "Provision environment variables VAULT_TOKEN, VAULT_ADDR and VAULT_NAMESPACE, logging in with the AppRole if set"
+}
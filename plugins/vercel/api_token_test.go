@@ -23,6 +23,20 @@ func TestAPITokenProvisioner(t *testing.T) {
 	)
 }
 
+func TestAPITokenCommandLine(t *testing.T) {
+	plugintest.TestCommandLine(
+		t, APIToken().DefaultProvisioner, map[string]plugintest.CommandLineCase{
+			"token after subcommand": {
+				ItemFields: map[sdk.FieldName]string{
+					fieldname.Token: "tZk79pLyPLGgUVlkHbnLeXgl",
+				},
+				CommandLine:         []string{"vercel", "deploy", "--prod"},
+				ExpectedCommandLine: "vercel deploy --prod --token tZk79pLyPLGgUVlkHbnLeXgl",
+			},
+		},
+	)
+}
+
 func TestAPITokenImporter(t *testing.T) {
 	plugintest.TestImporter(
 		t, APIToken().Importer, map[string]plugintest.ImportCase{
@@ -1,9 +1,11 @@
 package wrangler
 
 import (
+	"context"
+	"time"
+
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/importer"
-	"github.com/1Password/shell-plugins/sdk/provision"
 	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/1Password/shell-plugins/sdk/schema/credname"
 	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
@@ -33,6 +35,7 @@ func APIToken() schema.CredentialType {
 				Name:                fieldname.Token,
 				MarkdownDescription: "The API token for your Cloudflare account, can be used for authentication for situations like CI/CD, and other automation.",
 				Secret:              true,
+				Optional:            true,
 				Composition: &schema.ValueComposition{
 					Charset: schema.Charset{
 						Uppercase: true,
@@ -42,9 +45,35 @@ func APIToken() schema.CredentialType {
 					Length: 37,
 				},
 			},
+			{
+				Name:                fieldname.APIKey,
+				MarkdownDescription: "The legacy Global API Key for your Cloudflare account. Only used when no API token is set.",
+				Secret:              true,
+				Optional:            true,
+				Composition: &schema.ValueComposition{
+					Charset: schema.Charset{
+						Lowercase: true,
+						Digits:    true,
+					},
+					Length: 37,
+				},
+			},
+			{
+				Name:                fieldname.Email,
+				MarkdownDescription: "The email address of your Cloudflare account. Required together with the Global API Key.",
+				Optional:            true,
+			},
 		},
-		DefaultProvisioner: provision.EnvVars(defaultEnvVarMapping),
-		Importer:           importer.TryEnvVarPair(defaultEnvVarMapping),
+		DefaultProvisioner: wranglerProvisioner{},
+		Importer: importer.TryAll(
+			importer.TryEnvVarPair(defaultEnvVarMapping),
+			importer.TryEnvVarPair(legacyEnvVarMapping),
+			importer.TryEnvVarPair(globalAPIKeyEnvVarMapping),
+			importer.TryEnvVarPair(legacyGlobalAPIKeyEnvVarMapping),
+			TryWranglerConfigFile("~/.wrangler/config/default.toml"),
+			importer.MacOnly(TryWranglerConfigFile("~/Library/Preferences/.wrangler/config/default.toml")),
+			importer.LinuxOnly(TryWranglerConfigFile("~/.config/.wrangler/config/default.toml")),
+		),
 	}
 }
 
@@ -52,3 +81,58 @@ var defaultEnvVarMapping = map[string]sdk.FieldName{
 	"CLOUDFLARE_ACCOUNT_ID": fieldname.AccountID,
 	"CLOUDFLARE_API_TOKEN":  fieldname.Token,
 }
+
+// legacyEnvVarMapping holds the env var names used by Wrangler 1.
+var legacyEnvVarMapping = map[string]sdk.FieldName{
+	"CF_ACCOUNT_ID": fieldname.AccountID,
+	"CF_API_TOKEN":  fieldname.Token,
+}
+
+var globalAPIKeyEnvVarMapping = map[string]sdk.FieldName{
+	"CLOUDFLARE_API_KEY": fieldname.APIKey,
+	"CLOUDFLARE_EMAIL":   fieldname.Email,
+}
+
+var legacyGlobalAPIKeyEnvVarMapping = map[string]sdk.FieldName{
+	"CF_API_KEY": fieldname.APIKey,
+	"CF_EMAIL":   fieldname.Email,
+}
+
+// TryWranglerConfigFile imports the credentials Wrangler stores after 'wrangler login' (OAuth) or, for
+// Wrangler 1, after 'wrangler config' (API token).
+func TryWranglerConfigFile(path string) sdk.Importer {
+	return importer.TryFile(path, func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		var config Config
+		if err := contents.ToTOML(&config); err != nil {
+			out.AddError(err)
+			return
+		}
+
+		if config.APIToken != "" {
+			out.AddCandidate(sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.Token: config.APIToken,
+				},
+			})
+		}
+
+		if config.OAuthToken != "" {
+			candidate := sdk.ImportCandidate{
+				Fields: map[sdk.FieldName]string{
+					fieldname.Token: config.OAuthToken,
+				},
+				NameHint: "oauth",
+			}
+			if expiresAt, err := time.Parse(time.RFC3339, config.ExpirationTime); err == nil {
+				candidate.ExpiresAt = &expiresAt
+			}
+			out.AddCandidate(candidate)
+		}
+	})
+}
+
+type Config struct {
+	OAuthToken     string `toml:"oauth_token"`
+	ExpirationTime string `toml:"expiration_time"`
+	APIToken       string `toml:"api_token"`
+}
@@ -0,0 +1,123 @@
+package wrangler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+func TestAPITokenProvisioner(t *testing.T) {
+	plugintest.TestProvisioner(t, APIToken().DefaultProvisioner, map[string]plugintest.ProvisionCase{
+		"token": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.AccountID: "3f9a1c7b2e8d4a6f0b5c9e1d7a3f2b8c",
+				fieldname.Token:     "bG3kM8pQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"CLOUDFLARE_ACCOUNT_ID": "3f9a1c7b2e8d4a6f0b5c9e1d7a3f2b8c",
+					"CLOUDFLARE_API_TOKEN":  "bG3kM8pQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+				},
+			},
+		},
+		"global API key": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.APIKey: "1e4f7a2c9b6d3e8f0a5c7b1d4e9f2a6c8EXAMPLE",
+				fieldname.Email:  "wendy@example.com",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{
+					"CLOUDFLARE_API_KEY": "1e4f7a2c9b6d3e8f0a5c7b1d4e9f2a6c8EXAMPLE",
+					"CLOUDFLARE_EMAIL":   "wendy@example.com",
+				},
+			},
+		},
+		"global API key without email": {
+			ItemFields: map[sdk.FieldName]string{
+				fieldname.APIKey: "1e4f7a2c9b6d3e8f0a5c7b1d4e9f2a6c8EXAMPLE",
+			},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Diagnostics: sdk.Diagnostics{
+					Errors: []sdk.Error{{Message: "either a token, or an API key together with an email address is required to authenticate Wrangler"}},
+				},
+			},
+		},
+	})
+}
+
+func TestAPITokenImporter(t *testing.T) {
+	expiresAt := time.Date(2023, 3, 21, 15, 4, 5, 0, time.UTC)
+
+	plugintest.TestImporter(t, APIToken().Importer, map[string]plugintest.ImportCase{
+		"environment": {
+			Environment: map[string]string{
+				"CLOUDFLARE_ACCOUNT_ID": "3f9a1c7b2e8d4a6f0b5c9e1d7a3f2b8c",
+				"CLOUDFLARE_API_TOKEN":  "bG3kM8pQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.AccountID: "3f9a1c7b2e8d4a6f0b5c9e1d7a3f2b8c",
+						fieldname.Token:     "bG3kM8pQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+					},
+				},
+			},
+		},
+		"legacy environment": {
+			Environment: map[string]string{
+				"CF_API_TOKEN": "bG3kM8pQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token: "bG3kM8pQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+					},
+				},
+			},
+		},
+		"legacy global API key environment": {
+			Environment: map[string]string{
+				"CF_API_KEY": "1e4f7a2c9b6d3e8f0a5c7b1d4e9f2a6c8EXAMPLE",
+				"CF_EMAIL":   "wendy@example.com",
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.APIKey: "1e4f7a2c9b6d3e8f0a5c7b1d4e9f2a6c8EXAMPLE",
+						fieldname.Email:  "wendy@example.com",
+					},
+				},
+			},
+		},
+		"OAuth config file": {
+			OS: "linux",
+			Files: map[string]string{
+				"~/.config/.wrangler/config/default.toml": plugintest.LoadFixture(t, "oauth.toml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token: "Vq8Lm3Xr7Tn2Wb5Yc9Jd4Hf6Gs1KaEXAMPLE",
+					},
+					NameHint:  "oauth",
+					ExpiresAt: &expiresAt,
+				},
+			},
+		},
+		"legacy config file": {
+			Files: map[string]string{
+				"~/.wrangler/config/default.toml": plugintest.LoadFixture(t, "legacy.toml"),
+			},
+			ExpectedCandidates: []sdk.ImportCandidate{
+				{
+					Fields: map[sdk.FieldName]string{
+						fieldname.Token: "bG3kM8pQ2vX7nR4tW9yC1jD6hF5sL0aEXAMPLE",
+					},
+				},
+			},
+		},
+	})
+}
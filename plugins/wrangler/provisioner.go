@@ -0,0 +1,42 @@
+package wrangler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema/fieldname"
+)
+
+// wranglerProvisioner provisions the API token if the item has one, and otherwise falls back to the
+// legacy Global API Key, which Wrangler only accepts together with the account's email address.
+type wranglerProvisioner struct{}
+
+func (p wranglerProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	if accountID, ok := in.ItemFields[fieldname.AccountID]; ok {
+		out.AddEnvVar("CLOUDFLARE_ACCOUNT_ID", accountID)
+	}
+
+	if token, ok := in.ItemFields[fieldname.Token]; ok {
+		out.AddEnvVar("CLOUDFLARE_API_TOKEN", token)
+		return
+	}
+
+	apiKey, hasAPIKey := in.ItemFields[fieldname.APIKey]
+	email, hasEmail := in.ItemFields[fieldname.Email]
+	if !hasAPIKey || !hasEmail {
+		out.AddError(errors.New("either a token, or an API key together with an email address is required to authenticate Wrangler"))
+		return
+	}
+
+	out.AddEnvVar("CLOUDFLARE_API_KEY", apiKey)
+	out.AddEnvVar("CLOUDFLARE_EMAIL", email)
+}
+
+func (p wranglerProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func (p wranglerProvisioner) Description() string {
+	return "Provision environment variables: CLOUDFLARE_API_TOKEN, or CLOUDFLARE_API_KEY and CLOUDFLARE_EMAIL, and CLOUDFLARE_ACCOUNT_ID"
+}
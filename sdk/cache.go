@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cacheKeySeparator separates the parts of namespaced cache keys.
+const cacheKeySeparator = "|"
+
+// NamespacedCache provides access to the encrypted cache, scoped to a namespace. This allows provisioners that
+// derive short-lived tokens, such as STS credentials or OAuth access tokens, to cache them without having to come
+// up with their own key scheme, and to invalidate them as a whole.
+type NamespacedCache struct {
+	namespace string
+	state     CacheState
+	ops       *CacheOperations
+	clock     Clock
+	onLookup  func(hit bool)
+}
+
+// NamespacedCache returns the cache scoped to the specified namespace. Entries that are put into the cache are
+// added to the cache operations of the specified provision output. To scope the cache to the current version of
+// the credential, ItemFingerprint can be used as part of the namespace.
+func (in *ProvisionInput) NamespacedCache(out *ProvisionOutput, namespace ...string) NamespacedCache {
+	return NamespacedCache{
+		namespace: strings.Join(namespace, cacheKeySeparator),
+		state:     in.Cache,
+		ops:       &out.Cache,
+		clock:     in,
+	}
+}
+
+// ItemFingerprint returns a short, non-reversible fingerprint of the values of the specified fields. When used as
+// part of a cache namespace, cached entries are automatically ignored when the credential gets updated.
+func (in *ProvisionInput) ItemFingerprint(fieldNames ...FieldName) string {
+	sorted := append([]FieldName{}, fieldNames...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	hash := sha256.New()
+	for _, fieldName := range sorted {
+		hash.Write([]byte(fieldName))
+		hash.Write([]byte{0})
+		hash.Write([]byte(in.ItemFields[fieldName]))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// WithLookupHook returns a copy of the cache that calls the specified hook on every lookup, with whether the
+// lookup was a hit. This can be used to track the hit rate of the cache.
+func (c NamespacedCache) WithLookupHook(hook func(hit bool)) NamespacedCache {
+	c.onLookup = hook
+	return c
+}
+
+// Key returns the full cache key for the specified key in this namespace.
+func (c NamespacedCache) Key(key string) string {
+	if c.namespace == "" {
+		return key
+	}
+	return c.namespace + cacheKeySeparator + key
+}
+
+// Get returns the cached value at the specified key in this namespace, if it exists and has not expired yet.
+// The data can be returned either as a []byte or unmarshaled as JSON.
+func (c NamespacedCache) Get(key string, out any) (ok bool) {
+	entry, ok := c.state[c.Key(key)]
+	ok = ok && !c.isExpired(entry) && c.state.Get(c.Key(key), out)
+	if c.onLookup != nil {
+		c.onLookup(ok)
+	}
+	return ok
+}
+
+// Has returns whether the specified key is present in this namespace and has not expired yet.
+func (c NamespacedCache) Has(key string) bool {
+	entry, ok := c.state[c.Key(key)]
+	return ok && !c.isExpired(entry)
+}
+
+// Put puts data into the cache at the specified key in this namespace, which expires after the specified TTL.
+func (c NamespacedCache) Put(key string, data any, ttl time.Duration) error {
+	return c.PutUntil(key, data, c.clock.Now().Add(ttl))
+}
+
+// PutUntil puts data into the cache at the specified key in this namespace, which expires at the specified time.
+// This is useful if the expiry is dictated by the data itself, like the expiry of an access token.
+func (c NamespacedCache) PutUntil(key string, data any, expiresAt time.Time) error {
+	if c.ops.Puts == nil {
+		c.ops.Puts = make(map[string]CacheEntry)
+	}
+	return c.ops.Put(c.Key(key), data, expiresAt)
+}
+
+// Invalidate removes the entry at the specified key in this namespace from the cache.
+func (c NamespacedCache) Invalidate(key string) {
+	c.ops.Remove(c.Key(key))
+}
+
+// InvalidateAll removes all entries in this namespace from the cache.
+func (c NamespacedCache) InvalidateAll() {
+	prefix := c.Key("")
+	var keys []string
+	for key := range c.state {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		c.ops.Remove(key)
+	}
+}
+
+func (c NamespacedCache) isExpired(entry CacheEntry) bool {
+	return !entry.ExpiresAt.IsZero() && !c.clock.Now().Before(entry.ExpiresAt)
+}
@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespacedCache(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	in := ProvisionInput{
+		Clock: FixedClock(now),
+		Cache: CacheState{
+			"oauth|abc|access-token": {Data: []byte(`"tkn_valid"`), ExpiresAt: now.Add(time.Minute)},
+			"oauth|abc|refresh":      {Data: []byte(`"tkn_expired"`), ExpiresAt: now},
+			"oauth|other|token":      {Data: []byte(`"tkn_other"`), ExpiresAt: now.Add(time.Minute)},
+		},
+	}
+	out := ProvisionOutput{}
+	cache := in.NamespacedCache(&out, "oauth", "abc")
+
+	var token string
+	assert.True(t, cache.Get("access-token", &token))
+	assert.Equal(t, "tkn_valid", token)
+	assert.False(t, cache.Has("refresh"), "expired entries should not be returned")
+	assert.False(t, cache.Has("token"), "entries in other namespaces should not be returned")
+
+	assert.NoError(t, cache.Put("id-token", "tkn_new", time.Hour))
+	assert.Equal(t, now.Add(time.Hour), out.Cache.Puts["oauth|abc|id-token"].ExpiresAt)
+
+	cache.InvalidateAll()
+	assert.Equal(t, []string{"oauth|abc|access-token", "oauth|abc|refresh"}, out.Cache.Removes)
+}
+
+func TestItemFingerprint(t *testing.T) {
+	in := ProvisionInput{ItemFields: map[FieldName]string{"Token": "tkn_example", "Host": "example.com"}}
+	fingerprint := in.ItemFingerprint("Token", "Host")
+
+	assert.Len(t, fingerprint, 16)
+	assert.Equal(t, fingerprint, in.ItemFingerprint("Host", "Token"), "fingerprint should not depend on field order")
+
+	in.ItemFields["Token"] = "tkn_rotated"
+	assert.NotEqual(t, fingerprint, in.ItemFingerprint("Token", "Host"))
+}
@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Capability is a feature that requires support from both the SDK and the 1Password CLI hosting the plugin. Plugins
+// declare the capabilities they require, so that hosts can produce a clear error, and provisioners can check which
+// capabilities the host supports to degrade gracefully.
+type Capability string
+
+const (
+	// CapabilityPrompts allows provisioners to prompt the user for input. See ProvisionInput.Prompt.
+	CapabilityPrompts Capability = "prompts"
+
+	// CapabilityRotation allows credentials to be rotated using the Rotator of the credential type.
+	CapabilityRotation Capability = "rotation"
+
+	// CapabilityVerification allows credentials to be verified using the Verifier of the credential type.
+	CapabilityVerification Capability = "verification"
+
+	// CapabilityEnvironments allows credential types to support multiple environments.
+	CapabilityEnvironments Capability = "environments"
+
+	// CapabilityProfileHints allows executables to pass a profile hint to provisioners.
+	CapabilityProfileHints Capability = "profile-hints"
+)
+
+// SupportedCapabilities lists all the capabilities this version of the SDK supports.
+var SupportedCapabilities = Capabilities{
+	CapabilityPrompts,
+	CapabilityRotation,
+	CapabilityVerification,
+	CapabilityEnvironments,
+	CapabilityProfileHints,
+}
+
+func (c Capability) String() string {
+	return string(c)
+}
+
+// Capabilities is a set of capabilities.
+type Capabilities []Capability
+
+// Has returns whether the specified capability is in the set.
+func (c Capabilities) Has(capability Capability) bool {
+	for _, supported := range c {
+		if supported == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the specified capabilities that are not in the set.
+func (c Capabilities) Missing(required ...Capability) Capabilities {
+	var missing Capabilities
+	for _, capability := range required {
+		if !c.Has(capability) {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
+}
+
+// MissingCapabilitiesError is returned when the host does not support one or more capabilities the plugin requires.
+type MissingCapabilitiesError struct {
+	Missing Capabilities
+}
+
+func (e MissingCapabilitiesError) Error() string {
+	var names []string
+	for _, capability := range e.Missing {
+		names = append(names, capability.String())
+	}
+	return fmt.Sprintf("this plugin requires a newer version of the 1Password CLI, missing support for: %s", strings.Join(names, ", "))
+}
+
+// NegotiateCapabilities checks that the host supports all the required capabilities. It returns a
+// MissingCapabilitiesError if it doesn't.
+func NegotiateCapabilities(host Capabilities, required ...Capability) error {
+	if missing := host.Missing(required...); len(missing) > 0 {
+		return MissingCapabilitiesError{Missing: missing}
+	}
+	return nil
+}
+
+// HostSupports returns whether the 1Password CLI hosting the plugin supports the specified capability. Provisioners
+// can use this to degrade gracefully on older hosts.
+func (in *ProvisionInput) HostSupports(capability Capability) bool {
+	return in.HostCapabilities.Has(capability)
+}
@@ -0,0 +1,23 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateCapabilities(t *testing.T) {
+	host := Capabilities{CapabilityPrompts, CapabilityEnvironments}
+
+	assert.NoError(t, NegotiateCapabilities(host, CapabilityPrompts))
+	assert.EqualError(t, NegotiateCapabilities(host, CapabilityPrompts, CapabilityRotation, CapabilityVerification),
+		"this plugin requires a newer version of the 1Password CLI, missing support for: rotation, verification")
+}
+
+func TestHostSupports(t *testing.T) {
+	in := ProvisionInput{HostCapabilities: Capabilities{CapabilityPrompts}}
+
+	assert.True(t, in.HostSupports(CapabilityPrompts))
+	assert.False(t, in.HostSupports(CapabilityRotation))
+	assert.False(t, (&ProvisionInput{}).HostSupports(CapabilityPrompts), "older hosts don't report any capabilities")
+}
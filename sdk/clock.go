@@ -0,0 +1,17 @@
+package sdk
+
+import "time"
+
+// Clock provides the current time. It can be set on ProvisionInput to run provisioners at a fixed point in time,
+// so that expiry-dependent logic, like generating TOTP codes or signing JWTs, can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// FixedClock is a Clock that always returns the same point in time.
+type FixedClock time.Time
+
+// Now returns the fixed point in time.
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
@@ -0,0 +1,106 @@
+// Package credentialprocess implements a helper that prints provisioned credentials in the format of the AWS
+// `credential_process` setting. Tools that read the AWS config file directly, instead of the environment, can then
+// get the credentials by invoking the plugin executable.
+package credentialprocess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Arg is the argument that makes the plugin executable act as credential process helper,
+// as in: <plugin executable> credential-process <credentials file>.
+const Arg = "credential-process"
+
+// Credentials is the output of a credential process, as specified in
+// https://docs.aws.amazon.com/cli/latest/userguide/cli-configure-sourcing-external.html.
+type Credentials struct {
+	Version         int        `json:"Version"`
+	AccessKeyID     string     `json:"AccessKeyId"`
+	SecretAccessKey string     `json:"SecretAccessKey"`
+	SessionToken    string     `json:"SessionToken,omitempty"`
+	Expiration      *time.Time `json:"Expiration,omitempty"`
+}
+
+// IsInvocation returns whether the executable got invoked as credential process helper, based on its arguments.
+func IsInvocation(args []string) bool {
+	return len(args) > 1 && args[1] == Arg
+}
+
+// Command returns the `credential_process` command that makes the current executable print the credentials stored
+// in the file at the specified path.
+func Command(credentialsPath string) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{executable, Arg, credentialsPath}
+	for i, arg := range args {
+		args[i], err = quote(arg)
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(args, " "), nil
+}
+
+// quote quotes the argument for a POSIX shell, which is what the AWS SDKs split the command with or run it in.
+// Newlines can't be quoted, since they would end the setting in the AWS config file.
+func quote(arg string) (string, error) {
+	if strings.ContainsAny(arg, "\n\r") {
+		return "", fmt.Errorf("%q can't be used in a credential process command, since it contains a newline", arg)
+	}
+	if arg != "" && strings.Trim(arg, safeChars) == "" {
+		return arg, nil
+	}
+	// A single quote can't be escaped within single quotes, so end the quoted string, add an escaped quote, and
+	// start a new quoted string.
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'", nil
+}
+
+// safeChars are the characters that don't need quoting in a POSIX shell.
+const safeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-"
+
+// Run reads the credentials file specified in args and prints it to stdout. It returns the exit code of the helper.
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	err := run(args, stdout, time.Now())
+	if err != nil {
+		fmt.Fprintf(stderr, "[ERROR] %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+func run(args []string, stdout io.Writer, now time.Time) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s <credentials file>", Arg)
+	}
+
+	contents, err := os.ReadFile(args[0])
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New("the provisioned credentials are not available anymore, run the command again through 1Password CLI")
+	}
+	if err != nil {
+		return err
+	}
+
+	var credentials Credentials
+	err = json.Unmarshal(contents, &credentials)
+	if err != nil {
+		return fmt.Errorf("invalid credentials file: %w", err)
+	}
+	if credentials.Version != 1 || credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return errors.New("invalid credentials file: the version, access key ID and secret access key must be set")
+	}
+	if credentials.Expiration != nil && !now.Before(*credentials.Expiration) {
+		return errors.New("the provisioned credentials have expired, run the command again through 1Password CLI")
+	}
+
+	return json.NewEncoder(stdout).Encode(credentials)
+}
@@ -2,8 +2,14 @@ package sdk
 
 type Diagnostics struct {
 	Errors []Error
+
+	// Logs contains the log entries added through a Logger, which can be surfaced to help debug a plugin.
+	Logs []LogEntry
 }
 
 type Error struct {
 	Message string
+
+	// Code identifies the kind of error, if it was added as a CodedError.
+	Code ErrorCode
 }
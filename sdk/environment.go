@@ -0,0 +1,15 @@
+package sdk
+
+// Environment represents the environment or stage a credential is scoped to, e.g. "production" for live keys
+// and "development" for test keys.
+type Environment string
+
+const (
+	EnvironmentProduction  Environment = "production"
+	EnvironmentStaging     Environment = "staging"
+	EnvironmentDevelopment Environment = "development"
+)
+
+func (e Environment) String() string {
+	return string(e)
+}
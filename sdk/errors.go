@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrorCode identifies the kind of an error, so that the 1Password CLI can show a targeted remediation message,
+// like suggesting to edit the item when a field is missing.
+type ErrorCode string
+
+const (
+	// ErrorCodeUnknown is used for errors that do not have a more specific code.
+	ErrorCodeUnknown ErrorCode = ""
+
+	// ErrorCodeFieldMissing means that a field that is required to provision the credential has no value.
+	ErrorCodeFieldMissing ErrorCode = "field-missing"
+
+	// ErrorCodeCredentialExpired means that the credential in the item has expired and needs to be replaced.
+	ErrorCodeCredentialExpired ErrorCode = "credential-expired"
+
+	// ErrorCodeUnsupportedPlatform means that the plugin does not support the current OS or version of the executable.
+	ErrorCodeUnsupportedPlatform ErrorCode = "unsupported-platform"
+
+	// ErrorCodeNeedsInteractive means that user input is required, but the command is not running interactively.
+	ErrorCodeNeedsInteractive ErrorCode = "needs-interactive"
+)
+
+// CodedError is an error that has an ErrorCode. When added to an output with AddError, the code is preserved
+// in the diagnostics, also when it's wrapped.
+type CodedError interface {
+	error
+	Code() ErrorCode
+}
+
+// FieldMissingError is returned when a field that is required to provision the credential has no value.
+type FieldMissingError struct {
+	Field FieldName
+}
+
+func (e FieldMissingError) Error() string {
+	return fmt.Sprintf("no value present in the item for field '%s'", e.Field)
+}
+
+func (e FieldMissingError) Code() ErrorCode {
+	return ErrorCodeFieldMissing
+}
+
+// CredentialExpiredError is returned when the credential in the item has expired.
+type CredentialExpiredError struct {
+	// (Optional) When the credential expired.
+	ExpiredAt time.Time
+}
+
+func (e CredentialExpiredError) Error() string {
+	if e.ExpiredAt.IsZero() {
+		return "the credential has expired"
+	}
+	return fmt.Sprintf("the credential expired at %s", e.ExpiredAt.Format(time.RFC3339))
+}
+
+func (e CredentialExpiredError) Code() ErrorCode {
+	return ErrorCodeCredentialExpired
+}
+
+// UnsupportedPlatformError is returned when the plugin does not support the current OS or version of the executable.
+type UnsupportedPlatformError struct {
+	// What is not supported, e.g. "windows" or "ngrok 2.x".
+	Platform string
+
+	// (Optional) What the user can do about it, e.g. "upgrade to ngrok 3.2.1 or higher".
+	Remediation string
+}
+
+func (e UnsupportedPlatformError) Error() string {
+	if e.Remediation == "" {
+		return fmt.Sprintf("%s is not supported", e.Platform)
+	}
+	return fmt.Sprintf("%s is not supported, %s", e.Platform, e.Remediation)
+}
+
+func (e UnsupportedPlatformError) Code() ErrorCode {
+	return ErrorCodeUnsupportedPlatform
+}
+
+// NeedsInteractiveError is returned when user input is required, but the command is not running interactively.
+type NeedsInteractiveError struct {
+	// What input is required, e.g. "Enter MFA code".
+	Input string
+}
+
+func (e NeedsInteractiveError) Error() string {
+	return fmt.Sprintf("%s: input is required, but the command is not running interactively", e.Input)
+}
+
+func (e NeedsInteractiveError) Code() ErrorCode {
+	return ErrorCodeNeedsInteractive
+}
+
+// ErrorCodeOf returns the code of the first CodedError in the chain of the specified error, or ErrorCodeUnknown.
+func ErrorCodeOf(err error) ErrorCode {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	return ErrorCodeUnknown
+}
+
+func newError(err error) Error {
+	return Error{Message: err.Error(), Code: ErrorCodeOf(err)}
+}
@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddErrorPreservesErrorCode(t *testing.T) {
+	out := ProvisionOutput{}
+	out.AddError(fmt.Errorf("exchanging token: %w", CredentialExpiredError{ExpiredAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)}))
+	out.AddError(errors.New("something else went wrong"))
+
+	assert.Equal(t, []Error{
+		{Message: "exchanging token: the credential expired at 2023-01-01T12:00:00Z", Code: ErrorCodeCredentialExpired},
+		{Message: "something else went wrong", Code: ErrorCodeUnknown},
+	}, out.Diagnostics.Errors)
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	assert.Equal(t, ErrorCodeFieldMissing, ErrorCodeOf(FieldMissingError{Field: "Token"}))
+	assert.Equal(t, ErrorCodeUnsupportedPlatform, ErrorCodeOf(fmt.Errorf("provisioning: %w", UnsupportedPlatformError{Platform: "windows"})))
+	assert.Equal(t, ErrorCodeUnknown, ErrorCodeOf(errors.New("opaque")))
+
+	assert.Equal(t, "ngrok 2.3 is not supported, upgrade to ngrok 3.2.1 or higher", UnsupportedPlatformError{
+		Platform:    "ngrok 2.3",
+		Remediation: "upgrade to ngrok 3.2.1 or higher",
+	}.Error())
+}
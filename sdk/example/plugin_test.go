@@ -3,6 +3,7 @@ package example
 import (
 	"testing"
 
+	"github.com/1Password/shell-plugins/sdk/plugintest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,3 +13,20 @@ func TestPlugin(t *testing.T) {
 		assert.True(t, c.Assertion)
 	}
 }
+
+func TestValueCompositions(t *testing.T) {
+	plugintest.TestValueCompositions(t, New())
+}
+
+func TestExampleCLINeedsAuth(t *testing.T) {
+	plugintest.TestNeedsAuthCorpus(t, ExampleCLI(), `
+		# Regular commands talk to the Example API
+		auth: example deploy --app "my app"
+		auth: example apps list
+
+		# Help and version output are available offline
+		skip: example --help
+		skip: example deploy --help
+		skip: example --version
+	`)
+}
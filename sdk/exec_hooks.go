@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"context"
+	"time"
+)
+
+// PreExecHook can optionally be implemented by a Provisioner to run right before the executable starts, after
+// everything has been provisioned. This can be used to, for example, warm up a token cache or record an audit event.
+type PreExecHook interface {
+	PreExec(ctx context.Context, input PreExecInput, output *PreExecOutput)
+}
+
+// PostExecHook can optionally be implemented by a Provisioner to run right after the executable exits, before
+// Deprovision gets called. In contrast to Deprovision, it knows how the executable finished, which can be used to,
+// for example, revoke a server-side session only if the command succeeded.
+type PostExecHook interface {
+	PostExec(ctx context.Context, input PostExecInput, output *PostExecOutput)
+}
+
+// PreExecInput contains info about the command that is about to run.
+type PreExecInput struct {
+	HomeDir string
+	TempDir string
+
+	// Environment is the environment selected for this run, if the credential type supports multiple environments.
+	Environment Environment
+
+	// ItemFields contains the field names and their corresponding (sensitive) values.
+	ItemFields map[FieldName]string
+
+	// CommandLine is the final command line that is about to be executed.
+	CommandLine []string
+}
+
+// PreExecOutput contains the outcome of the pre-exec hook.
+type PreExecOutput struct {
+	// Diagnostics can be used to report errors. If the output contains one or more errors, the executable
+	// does not run.
+	Diagnostics Diagnostics
+}
+
+// PostExecInput contains info about the command that just exited.
+type PostExecInput struct {
+	HomeDir string
+	TempDir string
+
+	// Environment is the environment selected for this run, if the credential type supports multiple environments.
+	Environment Environment
+
+	// ItemFields contains the field names and their corresponding (sensitive) values.
+	ItemFields map[FieldName]string
+
+	// CommandLine is the command line that was executed.
+	CommandLine []string
+
+	// ExitCode is the exit code of the executable, or -1 if it got killed by a signal.
+	ExitCode int
+
+	// Duration is how long the executable ran.
+	Duration time.Duration
+}
+
+// PostExecOutput contains the outcome of the post-exec hook.
+type PostExecOutput struct {
+	// Diagnostics can be used to report errors. Errors are shown to the user, but don't change the exit code.
+	Diagnostics Diagnostics
+}
+
+// AddError can be used to report an error to the pre-exec output, which prevents the executable from running.
+func (out *PreExecOutput) AddError(err error) {
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, newError(err))
+}
+
+// AddError can be used to report an error to the post-exec output.
+func (out *PostExecOutput) AddError(err error) {
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, newError(err))
+}
+
+// WithExecHooksOf returns the wrapper provisioner, extended with the PreExecHook and PostExecHook implementations of
+// the wrapped provisioner. Provisioners that wrap another provisioner can use this, so that wrapping doesn't drop its
+// hooks.
+func WithExecHooksOf(wrapper Provisioner, wrapped Provisioner) Provisioner {
+	preExec, hasPreExec := wrapped.(PreExecHook)
+	postExec, hasPostExec := wrapped.(PostExecHook)
+	switch {
+	case hasPreExec && hasPostExec:
+		return provisionerWithExecHooks{Provisioner: wrapper, PreExecHook: preExec, PostExecHook: postExec}
+	case hasPreExec:
+		return provisionerWithPreExecHook{Provisioner: wrapper, PreExecHook: preExec}
+	case hasPostExec:
+		return provisionerWithPostExecHook{Provisioner: wrapper, PostExecHook: postExec}
+	default:
+		return wrapper
+	}
+}
+
+type provisionerWithExecHooks struct {
+	Provisioner
+	PreExecHook
+	PostExecHook
+}
+
+type provisionerWithPreExecHook struct {
+	Provisioner
+	PreExecHook
+}
+
+type provisionerWithPostExecHook struct {
+	Provisioner
+	PostExecHook
+}
@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testProvisioner struct{}
+
+func (p testProvisioner) Provision(ctx context.Context, in ProvisionInput, out *ProvisionOutput) {}
+
+func (p testProvisioner) Deprovision(ctx context.Context, in DeprovisionInput, out *DeprovisionOutput) {
+}
+
+func (p testProvisioner) Description() string { return "test" }
+
+type testPreExecProvisioner struct {
+	testProvisioner
+	ran *bool
+}
+
+func (p testPreExecProvisioner) PreExec(ctx context.Context, in PreExecInput, out *PreExecOutput) {
+	*p.ran = true
+}
+
+type testPostExecProvisioner struct {
+	testProvisioner
+	ran *bool
+}
+
+func (p testPostExecProvisioner) PostExec(ctx context.Context, in PostExecInput, out *PostExecOutput) {
+	*p.ran = true
+}
+
+type testHookedProvisioner struct {
+	testPreExecProvisioner
+	postExecRan *bool
+}
+
+func (p testHookedProvisioner) PostExec(ctx context.Context, in PostExecInput, out *PostExecOutput) {
+	*p.postExecRan = true
+}
+
+func TestWithExecHooksOf(t *testing.T) {
+	var preExecRan, postExecRan bool
+	wrapper := testProvisioner{}
+
+	cases := map[string]struct {
+		wrapped          Provisioner
+		expectedPreExec  bool
+		expectedPostExec bool
+	}{
+		"no hooks":  {wrapped: testProvisioner{}},
+		"pre-exec":  {wrapped: testPreExecProvisioner{ran: &preExecRan}, expectedPreExec: true},
+		"post-exec": {wrapped: testPostExecProvisioner{ran: &postExecRan}, expectedPostExec: true},
+		"both": {
+			wrapped: testHookedProvisioner{
+				testPreExecProvisioner: testPreExecProvisioner{ran: &preExecRan},
+				postExecRan:            &postExecRan,
+			},
+			expectedPreExec:  true,
+			expectedPostExec: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			preExecRan, postExecRan = false, false
+			provisioner := WithExecHooksOf(wrapper, c.wrapped)
+
+			preExec, ok := provisioner.(PreExecHook)
+			assert.Equal(t, c.expectedPreExec, ok)
+			if ok {
+				preExec.PreExec(context.Background(), PreExecInput{}, &PreExecOutput{})
+			}
+			postExec, ok := provisioner.(PostExecHook)
+			assert.Equal(t, c.expectedPostExec, ok)
+			if ok {
+				postExec.PostExec(context.Background(), PostExecInput{}, &PostExecOutput{})
+			}
+
+			assert.Equal(t, c.expectedPreExec, preExecRan)
+			assert.Equal(t, c.expectedPostExec, postExecRan)
+			assert.Equal(t, "test", provisioner.Description())
+		})
+	}
+}
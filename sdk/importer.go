@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"time"
 )
@@ -47,7 +48,7 @@ type ImportInput struct {
 	HomeDir string
 	RootDir string
 
-	// Supported values: "darwin", "linux"
+	// Supported values: "darwin", "linux", "windows"
 	OS string
 }
 
@@ -88,7 +89,7 @@ func (out *ImportAttempt) AddCandidate(candidate ImportCandidate) {
 }
 
 func (out *ImportAttempt) AddError(err error) {
-	out.Diagnostics.Errors = append(out.Diagnostics.Errors, Error{err.Error()})
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, newError(err))
 }
 
 func (in *ImportInput) FromHomeDir(path ...string) string {
@@ -98,3 +99,26 @@ func (in *ImportInput) FromHomeDir(path ...string) string {
 func (in *ImportInput) FromRootDir(path ...string) string {
 	return filepath.Join(append([]string{in.RootDir}, path...)...)
 }
+
+// FromConfigDir returns a path with the user's config dir for the current OS prepended:
+// * Linux: $XDG_CONFIG_HOME, or ~/.config if not set.
+// * macOS: ~/Library/Application Support.
+// * Windows: %APPDATA%, or ~/AppData/Roaming if not set.
+func (in *ImportInput) FromConfigDir(path ...string) string {
+	var configDir string
+	switch in.OS {
+	case "darwin":
+		configDir = in.FromHomeDir("Library", "Application Support")
+	case "windows":
+		configDir = os.Getenv("APPDATA")
+		if configDir == "" {
+			configDir = in.FromHomeDir("AppData", "Roaming")
+		}
+	default:
+		configDir = os.Getenv("XDG_CONFIG_HOME")
+		if configDir == "" {
+			configDir = in.FromHomeDir(".config")
+		}
+	}
+	return filepath.Join(append([]string{configDir}, path...)...)
+}
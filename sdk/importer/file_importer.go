@@ -23,17 +23,30 @@ func TryFile(path string, result func(ctx context.Context, contents FileContents
 			abspath = filepath.Join(in.RootDir, path)
 		}
 
-		attempt := out.NewAttempt(SourceFile(path))
-		contents, err := os.ReadFile(abspath)
-		if os.IsNotExist(err) {
-			return
-		} else if err != nil {
-			attempt.AddError(err)
-			return
-		}
+		tryFileAt(ctx, path, abspath, in, out, result)
+	}
+}
+
+// TryConfigDirFile tries the file at the specified path relative to the user's config dir for the current OS,
+// e.g. "gh/hosts.yml" resolves to "$XDG_CONFIG_HOME/gh/hosts.yml" on Linux and "%APPDATA%\gh\hosts.yml" on Windows.
+func TryConfigDirFile(path string, result func(ctx context.Context, contents FileContents, in sdk.ImportInput, out *sdk.ImportAttempt)) sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		abspath := in.FromConfigDir(path)
+		tryFileAt(ctx, abspath, abspath, in, out, result)
+	}
+}
 
-		result(ctx, contents, in, attempt)
+func tryFileAt(ctx context.Context, path string, abspath string, in sdk.ImportInput, out *sdk.ImportOutput, result func(ctx context.Context, contents FileContents, in sdk.ImportInput, out *sdk.ImportAttempt)) {
+	attempt := out.NewAttempt(SourceFile(path))
+	contents, err := os.ReadFile(abspath)
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		attempt.AddError(err)
+		return
 	}
+
+	result(ctx, contents, in, attempt)
 }
 
 type FileContents []byte
@@ -86,3 +99,84 @@ func (fc FileContents) ToINI() (*ini.File, error) {
 
 	return result, nil
 }
+
+// ToEnv parses the contents as a dotenv file, in which each line contains a KEY=value pair. Empty lines,
+// comments and lines without a '=' are skipped, an optional "export " prefix is stripped and values
+// wrapped in matching single or double quotes are unquoted.
+func (fc FileContents) ToEnv() map[string]string {
+	result := make(map[string]string)
+	for _, line := range strings.Split(string(fc), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		i := strings.Index(line, "=")
+		if i <= 0 {
+			continue
+		}
+
+		key := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		result[key] = value
+	}
+
+	return result
+}
+
+// NetrcMachine is a single entry of a netrc file. The "default" entry has an empty Machine.
+type NetrcMachine struct {
+	Machine  string
+	Login    string
+	Password string
+	Account  string
+}
+
+// ToNetrc parses the contents as a netrc file and returns its entries in the order they appear in.
+// Macro definitions are not supported and are ignored up until the next entry.
+func (fc FileContents) ToNetrc() []NetrcMachine {
+	var result []NetrcMachine
+	var current *NetrcMachine
+
+	tokens := strings.Fields(string(fc))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "default" {
+			result = append(result, NetrcMachine{})
+			current = &result[len(result)-1]
+			continue
+		}
+
+		if i+1 >= len(tokens) {
+			break
+		}
+
+		switch token {
+		case "machine":
+			i++
+			result = append(result, NetrcMachine{Machine: tokens[i]})
+			current = &result[len(result)-1]
+		case "macdef":
+			current = nil
+		case "login", "password", "account":
+			i++
+			if current == nil {
+				continue
+			}
+			switch token {
+			case "login":
+				current.Login = tokens[i]
+			case "password":
+				current.Password = tokens[i]
+			case "account":
+				current.Account = tokens[i]
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,39 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileContentsToEnv(t *testing.T) {
+	contents := FileContents(`# comment
+EXAMPLE_TOKEN=abc123
+export EXAMPLE_HOST = "example.com"
+EXAMPLE_QUOTED='with spaces'
+not a pair
+`)
+
+	assert.Equal(t, map[string]string{
+		"EXAMPLE_TOKEN":  "abc123",
+		"EXAMPLE_HOST":   "example.com",
+		"EXAMPLE_QUOTED": "with spaces",
+	}, contents.ToEnv())
+}
+
+func TestFileContentsToNetrc(t *testing.T) {
+	contents := FileContents(`machine api.example.com
+  login wendy@example.com
+  password abc123
+machine git.example.com login wendy password def456 account acme
+macdef init
+  cd /pub
+default login anonymous password guest
+`)
+
+	assert.Equal(t, []NetrcMachine{
+		{Machine: "api.example.com", Login: "wendy@example.com", Password: "abc123"},
+		{Machine: "git.example.com", Login: "wendy", Password: "def456", Account: "acme"},
+		{Login: "anonymous", Password: "guest"},
+	}, contents.ToNetrc())
+}
@@ -0,0 +1,189 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// pollInterval is how often Acquire checks whether a held lease has been released.
+const pollInterval = 50 * time.Millisecond
+
+// ErrNotHeld is returned when renewing or releasing a lease that has expired and has been taken over since.
+var ErrNotHeld = errors.New("lease is no longer held")
+
+// Lease is an exclusive, time-limited lock on a path, shared by all invocations of the plugin on the machine. It can
+// be used to coordinate concurrent invocations, for example around a credential file at a fixed path, or a token
+// exchange of which the result can only be used once. Leases expire after their TTL, so a crashed invocation does
+// not block others indefinitely.
+type Lease struct {
+	path  string
+	token string
+}
+
+type leaseFile struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Acquire acquires the lease on the specified path, waiting until it's released by other invocations or it
+// expires, or until the context is done. The lease is stored in a lock file next to the specified path, which
+// does not have to exist.
+func Acquire(ctx context.Context, path string, ttl time.Duration) (*Lease, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	lease := &Lease{path: lockPath(path), token: token}
+
+	if err := os.MkdirAll(filepath.Dir(lease.path), 0700); err != nil {
+		return nil, err
+	}
+
+	for {
+		acquired, err := lease.tryAcquire(ttl)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return lease, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("acquiring lease on %s: %w", path, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// WithLease runs the specified function while holding the lease on the specified path.
+func WithLease(ctx context.Context, path string, ttl time.Duration, fn func() error) error {
+	lease, err := Acquire(ctx, path, ttl)
+	if err != nil {
+		return err
+	}
+	defer lease.Release()
+
+	return fn()
+}
+
+// Renew extends the lease by the specified TTL, counting from now. It returns ErrNotHeld if the lease has expired
+// and was taken over by another invocation in the meantime.
+func (l *Lease) Renew(ttl time.Duration) error {
+	return withGuard(l.path, func() error {
+		if !l.isHeld() {
+			return ErrNotHeld
+		}
+		return l.write(ttl)
+	})
+}
+
+// Release releases the lease, so other invocations can acquire it. It returns ErrNotHeld if the lease has expired
+// and was taken over by another invocation in the meantime, in which case the lock file is left untouched.
+func (l *Lease) Release() error {
+	return withGuard(l.path, func() error {
+		if !l.isHeld() {
+			return ErrNotHeld
+		}
+		return os.Remove(l.path)
+	})
+}
+
+func (l *Lease) tryAcquire(ttl time.Duration) (bool, error) {
+	acquired := false
+	err := withGuard(l.path, func() error {
+		// Take over the lease if nobody holds it, or if it has expired.
+		current, err := readLeaseFile(l.path)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			// A lock file that can't be parsed was left behind by an invocation that crashed while writing it, so
+			// only take it over once it's been around for longer than the TTL.
+			info, statErr := os.Stat(l.path)
+			if statErr != nil || time.Since(info.ModTime()) < ttl {
+				return nil
+			}
+		case time.Now().Before(current.ExpiresAt):
+			return nil
+		}
+
+		if err := l.write(ttl); err != nil {
+			return err
+		}
+		acquired = true
+		return nil
+	})
+	return acquired, err
+}
+
+func (l *Lease) isHeld() bool {
+	current, err := readLeaseFile(l.path)
+	return err == nil && current.Token == l.token
+}
+
+// write writes the lock file through a temporary file that's renamed over it, so that a crash never leaves a
+// partially written lock file behind.
+func (l *Lease) write(ttl time.Duration) error {
+	contents, err := json.Marshal(leaseFile{Token: l.token, ExpiresAt: time.Now().Add(ttl)})
+	if err != nil {
+		return err
+	}
+
+	tempPath := l.path + "." + l.token
+	if err := os.WriteFile(tempPath, contents, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, l.path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+// withGuard runs the specified function while holding an exclusive OS-level lock on the guard file of the lock file,
+// so that reading the lock file and acting on what's in there happens atomically across invocations. The OS
+// releases the lock when the process exits, also after a crash. The guard file itself is never removed, since
+// another invocation could be waiting for the lock on it.
+func withGuard(lockPath string, fn func() error) error {
+	file, err := os.OpenFile(lockPath+".guard", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("locking %s: %w", file.Name(), err)
+	}
+	defer unlockFile(file)
+
+	return fn()
+}
+
+func readLeaseFile(path string) (leaseFile, error) {
+	var current leaseFile
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return current, err
+	}
+	err = json.Unmarshal(contents, &current)
+	return current, err
+}
+
+func lockPath(path string) string {
+	return path + ".lock"
+}
+
+func randomToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
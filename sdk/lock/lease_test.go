@@ -0,0 +1,119 @@
+package lock
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaseIsExclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	var mu sync.Mutex
+	holders, maxHolders := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := WithLease(context.Background(), path, time.Minute, func() error {
+				mu.Lock()
+				holders++
+				if holders > maxHolders {
+					maxHolders = holders
+				}
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				holders--
+				mu.Unlock()
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxHolders, "the lease was held by multiple invocations at once")
+}
+
+func TestAcquireWaitsUntilContextIsDone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	lease, err := Acquire(context.Background(), path, time.Minute)
+	require.NoError(t, err)
+	defer lease.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = Acquire(ctx, path, time.Minute)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestExpiredLeaseIsTakenOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	expired, err := Acquire(context.Background(), path, -time.Second)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	lease, err := Acquire(ctx, path, time.Minute)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, expired.Release(), ErrNotHeld, "releasing an expired lease should not release the new holder's lease")
+	assert.NoError(t, lease.Renew(time.Minute))
+	assert.NoError(t, lease.Release())
+}
+
+func TestExpiredLeaseIsTakenOverByOneWaiter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	for round := 0; round < 5; round++ {
+		expired, err := Acquire(context.Background(), path, -time.Second)
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		holders, maxHolders := 0, 0
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				lease, err := Acquire(context.Background(), path, time.Minute)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				mu.Lock()
+				holders++
+				if holders > maxHolders {
+					maxHolders = holders
+				}
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				holders--
+				mu.Unlock()
+				assert.NoError(t, lease.Release(), "the lease was released or taken over by another waiter")
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, maxHolders, "the expired lease was taken over by multiple waiters at once")
+		assert.ErrorIs(t, expired.Release(), ErrNotHeld)
+	}
+}
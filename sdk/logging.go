@@ -0,0 +1,101 @@
+package sdk
+
+import "fmt"
+
+// LogLevel indicates how important a log entry is. The 1Password CLI only surfaces debug and info entries when
+// running in verbose mode.
+type LogLevel string
+
+const (
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+)
+
+// LogEntry is a single message logged by a provisioner or importer.
+type LogEntry struct {
+	Level   LogLevel
+	Message string
+}
+
+// Logger can be used by provisioners and importers to leave breadcrumbs for debugging, instead of failing silently.
+// Log entries are added to the diagnostics of the output, so that the 1Password CLI can surface them. Known secret
+// values are redacted from the messages before they are stored.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+}
+
+// NewLogger returns a Logger that adds its log entries to the specified diagnostics, redacting the specified secrets.
+func NewLogger(diagnostics *Diagnostics, secrets ...string) Logger {
+	var redactions Redactions
+	redactions.Add(secrets...)
+	return diagnosticsLogger{
+		diagnostics: diagnostics,
+		redactions: func() Redactions {
+			return redactions
+		},
+	}
+}
+
+type diagnosticsLogger struct {
+	diagnostics *Diagnostics
+	redactions  func() Redactions
+}
+
+func (l diagnosticsLogger) Debugf(format string, args ...any) {
+	l.log(LogLevelDebug, format, args...)
+}
+
+func (l diagnosticsLogger) Infof(format string, args ...any) {
+	l.log(LogLevelInfo, format, args...)
+}
+
+func (l diagnosticsLogger) Warnf(format string, args ...any) {
+	l.log(LogLevelWarn, format, args...)
+}
+
+func (l diagnosticsLogger) log(level LogLevel, format string, args ...any) {
+	l.diagnostics.Logs = append(l.diagnostics.Logs, LogEntry{
+		Level:   level,
+		Message: l.redactions().Redact(fmt.Sprintf(format, args...)),
+	})
+}
+
+// Logger returns a Logger that adds log entries to the provision output, redacting all values of the item fields
+// and all values registered in the redactions of the output at the time of logging.
+func (out *ProvisionOutput) Logger(in ProvisionInput) Logger {
+	return diagnosticsLogger{
+		diagnostics: &out.Diagnostics,
+		redactions: func() Redactions {
+			redactions := append(Redactions{}, out.Redactions...)
+			for _, value := range in.ItemFields {
+				redactions.Add(value)
+			}
+			return redactions
+		},
+	}
+}
+
+// Logger returns a Logger that adds log entries to the deprovision output.
+func (out *DeprovisionOutput) Logger() Logger {
+	return NewLogger(&out.Diagnostics)
+}
+
+// Logger returns a Logger that adds log entries to the import attempt, redacting all values of the candidates
+// that have been added to the attempt at the time of logging.
+func (out *ImportAttempt) Logger() Logger {
+	return diagnosticsLogger{
+		diagnostics: &out.Diagnostics,
+		redactions: func() Redactions {
+			var redactions Redactions
+			for _, candidate := range out.Candidates {
+				for _, value := range candidate.Fields {
+					redactions.Add(value)
+				}
+			}
+			return redactions
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionOutputLoggerRedactsItemFields(t *testing.T) {
+	in := ProvisionInput{
+		ItemFields: map[FieldName]string{
+			"Token":  "tkn_example",
+			"Prefix": "tkn",
+		},
+	}
+	out := ProvisionOutput{}
+
+	logger := out.Logger(in)
+	logger.Debugf("exchanging token %s", "tkn_example")
+	logger.Warnf("token is about to expire")
+
+	assert.Equal(t, []LogEntry{
+		{Level: LogLevelDebug, Message: "exchanging token [REDACTED]"},
+		{Level: LogLevelWarn, Message: "token is about to expire"},
+	}, out.Diagnostics.Logs)
+}
+
+func TestImportAttemptLoggerRedactsCandidates(t *testing.T) {
+	attempt := ImportAttempt{}
+	logger := attempt.Logger()
+
+	attempt.AddCandidate(ImportCandidate{Fields: map[FieldName]string{"Token": "tkn_example"}})
+	logger.Infof("found token tkn_example in config file")
+
+	assert.Equal(t, []LogEntry{
+		{Level: LogLevelInfo, Message: "found token [REDACTED] in config file"},
+	}, attempt.Diagnostics.Logs)
+}
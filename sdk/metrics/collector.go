@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Collector is a Recorder that aggregates the recorded metrics in memory.
+type Collector struct {
+	mu      sync.Mutex
+	summary Summary
+}
+
+// Summary contains the metrics aggregated by a Collector.
+type Summary struct {
+	Provisions   DurationStats
+	Deprovisions DurationStats
+	Imports      map[string]ImportStats
+	CacheHits    int
+	CacheMisses  int
+}
+
+// DurationStats aggregates the durations of a recurring step.
+type DurationStats struct {
+	Count    int
+	Failures int
+	Total    time.Duration
+	Max      time.Duration
+}
+
+// ImportStats aggregates the metrics of a single importer.
+type ImportStats struct {
+	DurationStats
+	Candidates int
+}
+
+// Average returns the average duration of the step, or 0 if it was never recorded.
+func (s DurationStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// CacheHitRate returns the fraction of cache lookups that were hits, or 0 if there were no lookups.
+func (s Summary) CacheHitRate() float64 {
+	lookups := s.CacheHits + s.CacheMisses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.CacheHits) / float64(lookups)
+}
+
+func (s *DurationStats) add(duration time.Duration, failed bool) {
+	s.Count++
+	s.Total += duration
+	if duration > s.Max {
+		s.Max = duration
+	}
+	if failed {
+		s.Failures++
+	}
+}
+
+// RecordProvision adds the duration of a provision step to the summary.
+func (c *Collector) RecordProvision(description string, duration time.Duration, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.summary.Provisions.add(duration, failed)
+}
+
+// RecordDeprovision adds the duration of a deprovision step to the summary.
+func (c *Collector) RecordDeprovision(description string, duration time.Duration, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.summary.Deprovisions.add(duration, failed)
+}
+
+// RecordCacheLookup counts the cache lookup as a hit or a miss.
+func (c *Collector) RecordCacheLookup(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hit {
+		c.summary.CacheHits++
+	} else {
+		c.summary.CacheMisses++
+	}
+}
+
+// RecordImport adds the duration and the number of candidates of an import to the summary of the importer.
+func (c *Collector) RecordImport(name string, duration time.Duration, candidates int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.summary.Imports == nil {
+		c.summary.Imports = make(map[string]ImportStats)
+	}
+	stats := c.summary.Imports[name]
+	stats.add(duration, false)
+	stats.Candidates += candidates
+	c.summary.Imports[name] = stats
+}
+
+// Summary returns the metrics aggregated so far.
+func (c *Collector) Summary() Summary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	summary := c.summary
+	summary.Imports = make(map[string]ImportStats)
+	for name, stats := range c.summary.Imports {
+		summary.Imports[name] = stats
+	}
+	return summary
+}
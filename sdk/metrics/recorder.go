@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// Recorder records performance metrics of a plugin, which can help diagnose slow startup of executables. Recorders
+// only ever receive descriptions, durations, and counts, never secret material.
+type Recorder interface {
+	// RecordProvision records how long a provisioner took to provision, and whether it failed.
+	RecordProvision(description string, duration time.Duration, failed bool)
+
+	// RecordDeprovision records how long a provisioner took to deprovision, and whether it failed.
+	RecordDeprovision(description string, duration time.Duration, failed bool)
+
+	// RecordCacheLookup records whether a cache lookup was a hit or a miss.
+	RecordCacheLookup(hit bool)
+
+	// RecordImport records how long an importer took, and how many candidates it found.
+	RecordImport(name string, duration time.Duration, candidates int)
+}
+
+// InstrumentProvisioner returns a provisioner that records the duration of each provision and deprovision step
+// of the specified provisioner. The pre-exec and post-exec hooks of the provisioner, if any, are kept.
+func InstrumentProvisioner(provisioner sdk.Provisioner, recorder Recorder) sdk.Provisioner {
+	return sdk.WithExecHooksOf(instrumentedProvisioner{provisioner: provisioner, recorder: recorder}, provisioner)
+}
+
+type instrumentedProvisioner struct {
+	provisioner sdk.Provisioner
+	recorder    Recorder
+}
+
+func (p instrumentedProvisioner) Description() string {
+	return p.provisioner.Description()
+}
+
+func (p instrumentedProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	start := time.Now()
+	p.provisioner.Provision(ctx, in, out)
+	p.recorder.RecordProvision(p.Description(), time.Since(start), len(out.Diagnostics.Errors) > 0)
+}
+
+func (p instrumentedProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	start := time.Now()
+	p.provisioner.Deprovision(ctx, in, out)
+	p.recorder.RecordDeprovision(p.Description(), time.Since(start), len(out.Diagnostics.Errors) > 0)
+}
+
+// InstrumentImporter returns an importer that records the duration of the specified importer and the number of
+// candidates it found, under the specified name. To get timings per import source, instrument the individual
+// importers passed to importer.TryAll.
+func InstrumentImporter(name string, importer sdk.Importer, recorder Recorder) sdk.Importer {
+	return func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		candidatesBefore := len(out.AllCandidates())
+		start := time.Now()
+		importer(ctx, in, out)
+		recorder.RecordImport(name, time.Since(start), len(out.AllCandidates())-candidatesBefore)
+	}
+}
+
+// TrackCache returns the specified cache, with each lookup recorded as a hit or a miss.
+func TrackCache(cache sdk.NamespacedCache, recorder Recorder) sdk.NamespacedCache {
+	return cache.WithLookupHook(recorder.RecordCacheLookup)
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentProvisioner(t *testing.T) {
+	collector := &Collector{}
+	provisioner := InstrumentProvisioner(provision.TempFile(func(in sdk.ProvisionInput) ([]byte, error) {
+		if in.ItemFields["Token"] == "" {
+			return nil, errors.New("no token")
+		}
+		return []byte(in.ItemFields["Token"]), nil
+	}), collector)
+
+	for _, token := range []string{"tkn_example", ""} {
+		out := sdk.ProvisionOutput{Environment: map[string]string{}, Files: map[string]sdk.OutputFile{}}
+		provisioner.Provision(context.Background(), sdk.ProvisionInput{ItemFields: map[sdk.FieldName]string{"Token": token}}, &out)
+		provisioner.Deprovision(context.Background(), sdk.DeprovisionInput{}, &sdk.DeprovisionOutput{})
+	}
+
+	summary := collector.Summary()
+	assert.Equal(t, 2, summary.Provisions.Count)
+	assert.Equal(t, 1, summary.Provisions.Failures)
+	assert.Equal(t, 2, summary.Deprovisions.Count)
+	assert.Equal(t, 0, summary.Deprovisions.Failures)
+}
+
+func TestInstrumentProvisionerKeepsExecHooks(t *testing.T) {
+	var preExecRan, postExecRan bool
+	hooked := provision.WithHooks(provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}),
+		func(ctx context.Context, in sdk.PreExecInput) error {
+			preExecRan = true
+			return nil
+		},
+		func(ctx context.Context, in sdk.PostExecInput) error {
+			postExecRan = true
+			return nil
+		},
+	)
+	provisioner := InstrumentProvisioner(hooked, &Collector{})
+
+	preExec, ok := provisioner.(sdk.PreExecHook)
+	if assert.True(t, ok, "expected instrumented provisioner to implement the pre-exec hook") {
+		preExec.PreExec(context.Background(), sdk.PreExecInput{}, &sdk.PreExecOutput{})
+	}
+	postExec, ok := provisioner.(sdk.PostExecHook)
+	if assert.True(t, ok, "expected instrumented provisioner to implement the post-exec hook") {
+		postExec.PostExec(context.Background(), sdk.PostExecInput{}, &sdk.PostExecOutput{})
+	}
+	assert.True(t, preExecRan)
+	assert.True(t, postExecRan)
+
+	_, ok = InstrumentProvisioner(provision.EnvVars(nil), &Collector{}).(sdk.PreExecHook)
+	assert.False(t, ok, "provisioners without hooks should not get any")
+}
+
+func TestInstrumentImporter(t *testing.T) {
+	collector := &Collector{}
+	importer := InstrumentImporter("config file", func(ctx context.Context, in sdk.ImportInput, out *sdk.ImportOutput) {
+		attempt := out.NewAttempt(sdk.ImportSource{Files: []string{"~/.example/config"}})
+		attempt.AddCandidate(sdk.ImportCandidate{Fields: map[sdk.FieldName]string{"Token": "tkn_example"}})
+	}, collector)
+
+	importer(context.Background(), sdk.ImportInput{}, &sdk.ImportOutput{})
+
+	stats := collector.Summary().Imports["config file"]
+	assert.Equal(t, 1, stats.Count)
+	assert.Equal(t, 1, stats.Candidates)
+}
+
+func TestTrackCache(t *testing.T) {
+	collector := &Collector{}
+	in := sdk.ProvisionInput{
+		Cache: sdk.CacheState{"oauth|access-token": {Data: []byte(`"tkn_example"`), ExpiresAt: time.Now().Add(time.Hour)}},
+	}
+	cache := TrackCache(in.NamespacedCache(&sdk.ProvisionOutput{}, "oauth"), collector)
+
+	var token string
+	cache.Get("access-token", &token)
+	cache.Get("refresh-token", &token)
+	cache.Get("refresh-token", &token)
+
+	summary := collector.Summary()
+	assert.Equal(t, 1, summary.CacheHits)
+	assert.Equal(t, 2, summary.CacheMisses)
+	assert.InDelta(t, 1.0/3, summary.CacheHitRate(), 0.001)
+}
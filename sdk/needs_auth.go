@@ -6,4 +6,20 @@ type NeedsAuthentication func(in NeedsAuthenticationInput) (needsAuthentication
 type NeedsAuthenticationInput struct {
 	CredentialType string
 	CommandArgs    []string
+
+	// WorkingDir is the directory the executable gets run from.
+	WorkingDir string
+
+	// StdinIsTTY is set to true if the stdin of the executable is attached to a terminal. It's nil if the
+	// CLI running the executable doesn't report this.
+	StdinIsTTY *bool
+
+	// StdoutIsTTY is set to true if the stdout of the executable is attached to a terminal, and
+	// false if the output is piped or captured, e.g. by `eval "$(tool init)"`. It's nil if the CLI
+	// running the executable doesn't report this.
+	StdoutIsTTY *bool
+
+	// Environment contains the environment variables the executable gets run with. It's nil if the CLI running
+	// the executable doesn't report this.
+	Environment map[string]string
 }
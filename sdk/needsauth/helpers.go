@@ -1,6 +1,13 @@
 package needsauth
 
 import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
 	"github.com/1Password/shell-plugins/sdk"
 )
 
@@ -30,6 +37,24 @@ func IfAny(rules ...sdk.NeedsAuthentication) sdk.NeedsAuthentication {
 	}
 }
 
+// Not returns a NeedsAuthentication rule that inverts the specified rule: it opts in to the
+// authentication requirement only if the specified rule opts out.
+func Not(rule sdk.NeedsAuthentication) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		return !rule(in)
+	}
+}
+
+// And is an alias of IfAll, to read naturally when combined with Not and Or.
+func And(rules ...sdk.NeedsAuthentication) sdk.NeedsAuthentication {
+	return IfAll(rules...)
+}
+
+// Or is an alias of IfAny, to read naturally when combined with Not and And.
+func Or(rules ...sdk.NeedsAuthentication) sdk.NeedsAuthentication {
+	return IfAny(rules...)
+}
+
 // ForCommand returns a NeedsAuthentication rule to require authentication for
 // certain (sub)command, e.g. ["account"] or ["account", "list"].
 func ForCommand(command ...string) sdk.NeedsAuthentication {
@@ -51,6 +76,192 @@ func ForCommand(command ...string) sdk.NeedsAuthentication {
 	}
 }
 
+// IfArgsMatch returns a NeedsAuthentication rule to require authentication if at least one of
+// the command-line args matches the specified regular expression, e.g. `^https://git\.example\.com/`.
+func IfArgsMatch(pattern *regexp.Regexp) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		for _, arg := range in.CommandArgs {
+			if pattern.MatchString(arg) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ForCommandPattern returns a NeedsAuthentication rule to require authentication for (sub)commands
+// matching the specified pattern. Like ForCommand, the pattern gets matched against the start of the
+// command-line args. Each element is matched against a single arg using shell pattern syntax, so "*"
+// matches any single arg and "get-*" matches "get-object". The special element "**" matches zero
+// or more args. For example:
+// * `ForCommandPattern("s3", "*")` matches `s3 ls` and `s3 cp`, but not `s3` or `configure list`.
+// * `ForCommandPattern("**", "delete")` matches `delete` and `compute instances delete`.
+func ForCommandPattern(pattern ...string) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		if len(pattern) == 0 {
+			return false
+		}
+		return matchCommandPattern(pattern, in.CommandArgs)
+	}
+}
+
+func matchCommandPattern(pattern []string, args []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(args); i++ {
+			if matchCommandPattern(pattern[1:], args[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(args) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(pattern[0], args[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchCommandPattern(pattern[1:], args[1:])
+}
+
+// IfFlagValue returns a NeedsAuthentication rule to require authentication if the specified flag is
+// set to one of the specified values, either as `--flag value` or `--flag=value`. For example:
+// `IfFlagValue("--registry", "https://registry.npmjs.org/")`.
+func IfFlagValue(flag string, values ...string) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		for _, flagValue := range FlagValues(in.CommandArgs, flag) {
+			for _, value := range values {
+				if flagValue == value {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// FlagValues returns all values of the specified flag in the command-line args, either passed as
+// `--flag value` or `--flag=value`.
+func FlagValues(args []string, flag string) []string {
+	var values []string
+	for i, arg := range args {
+		if arg == flag && i+1 < len(args) {
+			values = append(values, args[i+1])
+		} else if strings.HasPrefix(arg, flag+"=") {
+			values = append(values, strings.TrimPrefix(arg, flag+"="))
+		}
+	}
+	return values
+}
+
+// IfURLHost returns a NeedsAuthentication rule to require authentication if at least one of the command-line
+// args is a URL pointing to one of the specified hosts. URLs passed as inline flag values, e.g.
+// `--url=https://api.example.com`, are also considered. A host can include a port, e.g. "localhost:8080",
+// in which case the port has to match as well, and can start with "*." to match all subdomains.
+func IfURLHost(hosts ...string) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		for _, arg := range in.CommandArgs {
+			u, ok := parseURLArg(arg)
+			if !ok {
+				continue
+			}
+
+			for _, host := range hosts {
+				if matchURLHost(u, host) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func parseURLArg(arg string) (*url.URL, bool) {
+	if i := strings.Index(arg, "="); i >= 0 && strings.HasPrefix(arg, "-") {
+		arg = arg[i+1:]
+	}
+
+	if !strings.Contains(arg, "://") {
+		return nil, false
+	}
+
+	u, err := url.Parse(arg)
+	if err != nil || u.Host == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+func matchURLHost(u *url.URL, host string) bool {
+	actual := u.Hostname()
+	if strings.Contains(host, ":") {
+		actual = u.Host
+	}
+
+	if strings.HasPrefix(host, "*.") {
+		return strings.HasSuffix(strings.ToLower(actual), strings.ToLower(host[1:]))
+	}
+	return strings.EqualFold(actual, host)
+}
+
+// IfInteractive returns a NeedsAuthentication rule to require authentication only if both stdin and
+// stdout of the executable are attached to a terminal. If that's unknown, authentication is required.
+func IfInteractive() sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		return isTTYOrUnknown(in.StdinIsTTY) && isTTYOrUnknown(in.StdoutIsTTY)
+	}
+}
+
+// NotWhenOutputPiped returns a NeedsAuthentication rule to opt out of authentication when the stdout
+// of the executable is not attached to a terminal, e.g. for shell completion or shell init scripts.
+// If that's unknown, authentication is required.
+func NotWhenOutputPiped() sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		return isTTYOrUnknown(in.StdoutIsTTY)
+	}
+}
+
+func isTTYOrUnknown(isTTY *bool) bool {
+	return isTTY == nil || *isTTY
+}
+
+// IfWorkingDir returns a NeedsAuthentication rule to require authentication based on the directory
+// the executable gets run from, e.g. by checking whether a git remote in that directory points to
+// the platform. If the working directory is unknown, the current directory of the process is used.
+func IfWorkingDir(needsAuth func(dir string) bool) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		dir := in.WorkingDir
+		if dir == "" {
+			wd, err := os.Getwd()
+			if err != nil {
+				return false
+			}
+			dir = wd
+		}
+		return needsAuth(dir)
+	}
+}
+
+// IfWorkingDirContains returns a NeedsAuthentication rule to require authentication if at least
+// one of the specified files or directories exists in the working directory, e.g. ".terraform".
+func IfWorkingDirContains(paths ...string) sdk.NeedsAuthentication {
+	return IfWorkingDir(func(dir string) bool {
+		for _, p := range paths {
+			if _, err := os.Stat(filepath.Join(dir, p)); err == nil {
+				return true
+			}
+		}
+		return false
+	})
+}
+
 // Always returns a NeedsAuthentication rule to always require authentication.
 func Always() sdk.NeedsAuthentication {
 	return func(in sdk.NeedsAuthenticationInput) bool {
@@ -110,6 +321,19 @@ func NotWhenContainsArgs(argsSequence ...string) sdk.NeedsAuthentication {
 	}
 }
 
+// NotWhenEnvVarPresent returns a NeedsAuthentication rule to opt out of authentication when at least one
+// of the specified environment variables is set, e.g. because the user or CI already provided credentials.
+func NotWhenEnvVarPresent(envVarNames ...string) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		for _, envVarName := range envVarNames {
+			if os.Getenv(envVarName) != "" {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func NotForHelp() sdk.NeedsAuthentication {
 	return IfAll(
 		NotWhenContainsArgs("-h"),
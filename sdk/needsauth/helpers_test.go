@@ -1,6 +1,9 @@
 package needsauth
 
 import (
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/1Password/shell-plugins/sdk/plugintest"
@@ -107,6 +110,238 @@ func TestForCommand(t *testing.T) {
 	})
 }
 
+func TestIfArgsMatch(t *testing.T) {
+	plugintest.TestNeedsAuth(t, IfArgsMatch(regexp.MustCompile(`^https://git\.example\.com/`)), map[string]plugintest.NeedsAuthCase{
+		"yes when an arg matches": {
+			Args:              []string{"clone", "https://git.example.com/org/repo"},
+			ExpectedNeedsAuth: true,
+		},
+		"no when no arg matches": {
+			Args:              []string{"clone", "https://github.com/org/repo"},
+			ExpectedNeedsAuth: false,
+		},
+		"no when the pattern is only part of an arg": {
+			Args:              []string{"clone", "--mirror=https://git.example.com/org/repo"},
+			ExpectedNeedsAuth: false,
+		},
+		"no without args": {
+			Args:              []string{},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
+
+func TestLogicalCombinators(t *testing.T) {
+	// Authenticate unless the "--local" flag is present, and not when running "tool help",
+	// except for the "sync" command, which always requires authentication.
+	needsAuth := Or(
+		ForCommand("sync"),
+		And(
+			Not(ForCommand("help")),
+			Not(IfArgsMatch(regexp.MustCompile(`^--local$`))),
+		),
+	)
+
+	plugintest.TestNeedsAuth(t, needsAuth, map[string]plugintest.NeedsAuthCase{
+		"yes by default": {
+			Args:              []string{"deploy"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for help command": {
+			Args:              []string{"help", "deploy"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for local flag": {
+			Args:              []string{"deploy", "--local"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for sync command with local flag": {
+			Args:              []string{"sync", "--local"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
+
+func TestNotWhenEnvVarPresent(t *testing.T) {
+	rule := NotWhenEnvVarPresent("EXAMPLE_TOKEN", "EXAMPLE_API_KEY")
+
+	t.Run("yes when no env var is set", func(t *testing.T) {
+		t.Setenv("EXAMPLE_TOKEN", "")
+		t.Setenv("EXAMPLE_API_KEY", "")
+		plugintest.TestNeedsAuth(t, rule, map[string]plugintest.NeedsAuthCase{
+			"deploy": {Args: []string{"deploy"}, ExpectedNeedsAuth: true},
+		})
+	})
+
+	t.Run("no when one env var is set", func(t *testing.T) {
+		t.Setenv("EXAMPLE_TOKEN", "")
+		t.Setenv("EXAMPLE_API_KEY", "abc123")
+		plugintest.TestNeedsAuth(t, rule, map[string]plugintest.NeedsAuthCase{
+			"deploy": {Args: []string{"deploy"}, ExpectedNeedsAuth: false},
+		})
+	})
+}
+
+func TestForCommandPattern(t *testing.T) {
+	plugintest.TestNeedsAuth(t, ForCommandPattern("s3", "*"), map[string]plugintest.NeedsAuthCase{
+		"yes for subcommand": {
+			Args:              []string{"s3", "ls"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for subcommand with args": {
+			Args:              []string{"s3", "cp", "file.txt", "s3://bucket"},
+			ExpectedNeedsAuth: true,
+		},
+		"no without subcommand": {
+			Args:              []string{"s3"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for other command": {
+			Args:              []string{"configure", "list"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+
+	plugintest.TestNeedsAuth(t, ForCommandPattern("**", "get-*"), map[string]plugintest.NeedsAuthCase{
+		"yes for top-level command": {
+			Args:              []string{"get-object"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for nested command": {
+			Args:              []string{"s3api", "get-object", "--bucket", "foo"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for other command": {
+			Args:              []string{"s3api", "put-object"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
+
+func TestIfWorkingDirContains(t *testing.T) {
+	projectDir := t.TempDir()
+	err := os.Mkdir(filepath.Join(projectDir, ".terraform"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plugintest.TestNeedsAuth(t, IfWorkingDirContains(".terraform"), map[string]plugintest.NeedsAuthCase{
+		"yes when the working dir contains the path": {
+			Args:              []string{"plan"},
+			WorkingDir:        projectDir,
+			ExpectedNeedsAuth: true,
+		},
+		"no when the working dir does not contain the path": {
+			Args:              []string{"plan"},
+			WorkingDir:        t.TempDir(),
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
+
+func TestIfFlagValue(t *testing.T) {
+	plugintest.TestNeedsAuth(t, IfFlagValue("--registry", "https://registry.example.com/"), map[string]plugintest.NeedsAuthCase{
+		"yes for flag with separate value": {
+			Args:              []string{"publish", "--registry", "https://registry.example.com/"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for flag with inline value": {
+			Args:              []string{"publish", "--registry=https://registry.example.com/"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for flag with other value": {
+			Args:              []string{"publish", "--registry", "https://registry.npmjs.org/"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for flag without value": {
+			Args:              []string{"publish", "--registry"},
+			ExpectedNeedsAuth: false,
+		},
+		"no without flag": {
+			Args:              []string{"publish", "https://registry.example.com/"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
+
+func TestTTYAwareRules(t *testing.T) {
+	tty, piped := true, false
+
+	plugintest.TestNeedsAuth(t, IfInteractive(), map[string]plugintest.NeedsAuthCase{
+		"yes when attached to a terminal": {
+			Args:              []string{"deploy"},
+			StdinIsTTY:        &tty,
+			StdoutIsTTY:       &tty,
+			ExpectedNeedsAuth: true,
+		},
+		"no when stdin is piped": {
+			Args:              []string{"deploy"},
+			StdinIsTTY:        &piped,
+			StdoutIsTTY:       &tty,
+			ExpectedNeedsAuth: false,
+		},
+		"yes when unknown": {
+			Args:              []string{"deploy"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+
+	plugintest.TestNeedsAuth(t, NotWhenOutputPiped(), map[string]plugintest.NeedsAuthCase{
+		"yes when stdout is a terminal": {
+			Args:              []string{"deploy"},
+			StdoutIsTTY:       &tty,
+			ExpectedNeedsAuth: true,
+		},
+		"no when stdout is piped": {
+			Args:              []string{"completion", "zsh"},
+			StdinIsTTY:        &tty,
+			StdoutIsTTY:       &piped,
+			ExpectedNeedsAuth: false,
+		},
+		"yes when unknown": {
+			Args:              []string{"completion", "zsh"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
+
+func TestIfURLHost(t *testing.T) {
+	plugintest.TestNeedsAuth(t, IfURLHost("api.example.com", "*.internal.example.com", "localhost:8080"), map[string]plugintest.NeedsAuthCase{
+		"yes for URL on host": {
+			Args:              []string{"-X", "POST", "https://api.example.com/v1/users"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for URL on host with different casing": {
+			Args:              []string{"https://API.example.com/v1/users"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for URL as inline flag value": {
+			Args:              []string{"--url=https://api.example.com/v1/users"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for URL on subdomain": {
+			Args:              []string{"https://billing.internal.example.com"},
+			ExpectedNeedsAuth: true,
+		},
+		"yes for URL on host with matching port": {
+			Args:              []string{"http://localhost:8080/health"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for URL on host with other port": {
+			Args:              []string{"http://localhost:9090/health"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for URL on other host": {
+			Args:              []string{"https://api.example.com.evil.com/v1/users"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for host without scheme": {
+			Args:              []string{"api.example.com"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
+
 func TestComplexChain(t *testing.T) {
 	// Example of a fictitious package manager that requires authentication for:
 	// * The "publish" command, unless the "--dry-run" flag is present
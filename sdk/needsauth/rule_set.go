@@ -0,0 +1,45 @@
+package needsauth
+
+import (
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// Rule pairs a condition with the outcome to use if the condition matches, to be used in FirstMatch.
+type Rule struct {
+	// Condition is checked against the command-line args. Conditions should be positive matchers, such as
+	// ForCommand or IfArgsMatch, where returning true means that the rule applies.
+	Condition sdk.NeedsAuthentication
+
+	// NeedsAuth is the outcome to use if the condition matches.
+	NeedsAuth bool
+}
+
+// AuthenticateWhen returns a Rule that requires authentication if the condition matches.
+func AuthenticateWhen(condition sdk.NeedsAuthentication) Rule {
+	return Rule{Condition: condition, NeedsAuth: true}
+}
+
+// SkipWhen returns a Rule that opts out of authentication if the condition matches.
+func SkipWhen(condition sdk.NeedsAuthentication) Rule {
+	return Rule{Condition: condition, NeedsAuth: false}
+}
+
+// FirstMatch returns a NeedsAuthentication rule that evaluates the specified rules in order and uses the
+// outcome of the first rule of which the condition matches. Rules after the first match are not evaluated.
+// If none of the rules match, the outcome is defaultNeedsAuth. For example:
+//
+//	needsauth.FirstMatch(false,
+//		needsauth.SkipWhen(needsauth.ForCommand("publish", "--dry-run")),
+//		needsauth.AuthenticateWhen(needsauth.ForCommand("publish")),
+//		needsauth.AuthenticateWhen(needsauth.ForCommand("install")),
+//	)
+func FirstMatch(defaultNeedsAuth bool, rules ...Rule) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		for _, rule := range rules {
+			if rule.Condition(in) {
+				return rule.NeedsAuth
+			}
+		}
+		return defaultNeedsAuth
+	}
+}
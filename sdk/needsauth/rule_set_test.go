@@ -0,0 +1,68 @@
+package needsauth
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirstMatch(t *testing.T) {
+	needsAuth := FirstMatch(false,
+		SkipWhen(ForCommandPattern("**", "--local")),
+		SkipWhen(ForCommand("auth", "--help")),
+		AuthenticateWhen(ForCommand("auth")),
+		AuthenticateWhen(ForCommand("publish")),
+	)
+
+	plugintest.TestNeedsAuth(t, needsAuth, map[string]plugintest.NeedsAuthCase{
+		"no by default": {
+			Args:              []string{"config"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for first matching rule": {
+			Args:              []string{"publish", "my-app"},
+			ExpectedNeedsAuth: true,
+		},
+		"no when an earlier rule skips": {
+			Args:              []string{"publish", "--local"},
+			ExpectedNeedsAuth: false,
+		},
+		"no for auth help": {
+			Args:              []string{"auth", "--help"},
+			ExpectedNeedsAuth: false,
+		},
+		"yes for auth subcommand": {
+			Args:              []string{"auth", "whoami"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
+
+func TestFirstMatchShortCircuits(t *testing.T) {
+	evaluated := false
+	needsAuth := FirstMatch(true,
+		SkipWhen(Always()),
+		AuthenticateWhen(func(in sdk.NeedsAuthenticationInput) bool {
+			evaluated = true
+			return true
+		}),
+	)
+
+	assert.False(t, needsAuth(sdk.NeedsAuthenticationInput{}))
+	assert.False(t, evaluated, "rules after the first match should not be evaluated")
+}
+
+func TestFirstMatchDefault(t *testing.T) {
+	plugintest.TestNeedsAuth(t, FirstMatch(true, SkipWhen(ForCommand("help"))), map[string]plugintest.NeedsAuthCase{
+		"yes by default": {
+			Args:              []string{"deploy"},
+			ExpectedNeedsAuth: true,
+		},
+		"no for matching rule": {
+			Args:              []string{"help"},
+			ExpectedNeedsAuth: false,
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package needsauth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// SessionCheck returns whether the executable already has a valid session of its own, e.g. an unexpired
+// token in its cache on disk.
+type SessionCheck func(in sdk.NeedsAuthenticationInput) (active bool)
+
+// NotWhenSessionActive returns a NeedsAuthentication rule to opt out of authentication when the specified check
+// detects that the executable already has a valid session. This avoids exposing secrets or doing (rate-limited)
+// token exchanges when they are not needed.
+func NotWhenSessionActive(check SessionCheck) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		return !check(in)
+	}
+}
+
+// SessionFileModifiedWithin returns a SessionCheck that considers the session active if the file at the specified
+// path exists and was modified less than maxAge ago. Paths starting with "~/" are resolved relative to the home dir.
+func SessionFileModifiedWithin(path string, maxAge time.Duration) SessionCheck {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		sessionFile := path
+		if strings.HasPrefix(sessionFile, "~/") {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return false
+			}
+			sessionFile = filepath.Join(homeDir, strings.TrimPrefix(sessionFile, "~/"))
+		}
+
+		info, err := os.Stat(sessionFile)
+		if err != nil {
+			return false
+		}
+		return time.Since(info.ModTime()) < maxAge
+	}
+}
@@ -0,0 +1,71 @@
+package needsauth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotWhenSessionActive(t *testing.T) {
+	cacheDir := t.TempDir()
+	freshPath := filepath.Join(cacheDir, "fresh.json")
+	stalePath := filepath.Join(cacheDir, "stale.json")
+
+	for _, path := range []string{freshPath, stalePath} {
+		err := os.WriteFile(path, []byte(`{"token":"abc"}`), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	staleTime := time.Now().Add(-2 * time.Hour)
+	err := os.Chtimes(stalePath, staleTime, staleTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		path     string
+		expected bool
+	}{
+		"no when the session file is fresh":  {path: freshPath, expected: false},
+		"yes when the session file is stale": {path: stalePath, expected: true},
+		"yes when there is no session file":  {path: filepath.Join(cacheDir, "missing.json"), expected: true},
+	}
+
+	for name, tc := range cases {
+		plugintest.TestNeedsAuth(t, NotWhenSessionActive(SessionFileModifiedWithin(tc.path, time.Hour)), map[string]plugintest.NeedsAuthCase{
+			name: {
+				Args:              []string{"deploy"},
+				ExpectedNeedsAuth: tc.expected,
+			},
+		})
+	}
+}
+
+func TestSessionFileModifiedWithinResolvesHomeDirOnEveryCheck(t *testing.T) {
+	check := SessionFileModifiedWithin("~/.example/session.json", time.Hour)
+
+	homeWithSession := t.TempDir()
+	err := os.MkdirAll(filepath.Join(homeWithSession, ".example"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(homeWithSession, ".example", "session.json"), []byte(`{"token":"abc"}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("HOME", homeWithSession)
+	t.Setenv("USERPROFILE", homeWithSession)
+	assert.True(t, check(sdk.NeedsAuthenticationInput{}), "session file in home dir should be found")
+
+	homeWithoutSession := t.TempDir()
+	t.Setenv("HOME", homeWithoutSession)
+	t.Setenv("USERPROFILE", homeWithoutSession)
+	assert.False(t, check(sdk.NeedsAuthenticationInput{}), "session file should be looked up in the new home dir")
+}
@@ -0,0 +1,44 @@
+package needsauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// DefaultToolConfigBudget is the time budget that is recommended for ToolConfigCheck functions. Checking
+// whether authentication is needed happens before every run, so it should not noticeably delay the executable.
+const DefaultToolConfigBudget = 100 * time.Millisecond
+
+// ToolConfigCheck checks the executable's own configuration, such as `.git/config` or the configured
+// backend in `.terraform`, to determine whether authentication is required. The check should return as soon as
+// possible when the context is done.
+type ToolConfigCheck func(ctx context.Context, in sdk.NeedsAuthenticationInput) bool
+
+// IfToolConfig returns a NeedsAuthentication rule that uses the specified check to determine whether authentication
+// is required, for cases where the command-line args alone are not enough. If the check does not finish within the
+// specified time budget or panics, the fallback outcome is used instead.
+func IfToolConfig(budget time.Duration, fallback bool, check ToolConfigCheck) sdk.NeedsAuthentication {
+	return func(in sdk.NeedsAuthenticationInput) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), budget)
+		defer cancel()
+
+		result := make(chan bool, 1)
+		go func() {
+			defer func() {
+				if err := recover(); err != nil {
+					result <- fallback
+				}
+			}()
+			result <- check(ctx, in)
+		}()
+
+		select {
+		case needsAuth := <-result:
+			return needsAuth
+		case <-ctx.Done():
+			return fallback
+		}
+	}
+}
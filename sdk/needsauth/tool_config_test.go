@@ -0,0 +1,72 @@
+package needsauth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/plugintest"
+)
+
+func TestIfToolConfig(t *testing.T) {
+	withRemote := t.TempDir()
+	err := os.MkdirAll(filepath.Join(withRemote, ".git"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(withRemote, ".git", "config"), []byte("[remote \"origin\"]\n\turl = https://git.example.com/org/repo.git\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remoteOnExampleHost := func(ctx context.Context, in sdk.NeedsAuthenticationInput) bool {
+		contents, err := os.ReadFile(filepath.Join(in.WorkingDir, ".git", "config"))
+		if err != nil {
+			return false
+		}
+		return strings.Contains(string(contents), "git.example.com")
+	}
+
+	plugintest.TestNeedsAuth(t, IfToolConfig(DefaultToolConfigBudget, false, remoteOnExampleHost), map[string]plugintest.NeedsAuthCase{
+		"yes when the config matches": {
+			Args:              []string{"push"},
+			WorkingDir:        withRemote,
+			ExpectedNeedsAuth: true,
+		},
+		"no when the config does not exist": {
+			Args:              []string{"push"},
+			WorkingDir:        t.TempDir(),
+			ExpectedNeedsAuth: false,
+		},
+	})
+
+	slowCheck := func(ctx context.Context, in sdk.NeedsAuthenticationInput) bool {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+		return false
+	}
+
+	plugintest.TestNeedsAuth(t, IfToolConfig(10*time.Millisecond, true, slowCheck), map[string]plugintest.NeedsAuthCase{
+		"fallback when the check exceeds the time budget": {
+			Args:              []string{"push"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+
+	panickingCheck := func(ctx context.Context, in sdk.NeedsAuthenticationInput) bool {
+		panic("unexpected config format")
+	}
+
+	plugintest.TestNeedsAuth(t, IfToolConfig(DefaultToolConfigBudget, true, panickingCheck), map[string]plugintest.NeedsAuthCase{
+		"fallback when the check panics": {
+			Args:              []string{"push"},
+			ExpectedNeedsAuth: true,
+		},
+	})
+}
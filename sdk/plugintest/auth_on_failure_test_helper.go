@@ -0,0 +1,44 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type AuthOnFailureCase struct {
+	// Args can be used to set the command-line args of the run.
+	Args []string
+
+	// ExitCode and Stderr simulate the result of the first run of the executable, without credentials provisioned.
+	ExitCode int
+	Stderr   string
+
+	// ExpectedRetry is whether the executable should be run again with credentials provisioned.
+	ExpectedRetry bool
+}
+
+// TestAuthOnFailure checks, for the simulated result of running the executable without credentials, whether the
+// executable gets retried with credentials provisioned. This is the case if the executable needs authentication for
+// the args and the result matches the AuthOnFailure of the executable.
+func TestAuthOnFailure(t *testing.T, executable schema.Executable, cases map[string]AuthOnFailureCase) {
+	t.Helper()
+
+	if executable.AuthOnFailure == nil {
+		t.Fatalf("executable %s has no AuthOnFailure set", executable.Name)
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			retry := executable.AuthOnFailure.ShouldRetry(c.ExitCode, []byte(c.Stderr))
+			if executable.NeedsAuth != nil && !executable.NeedsAuth(sdk.NeedsAuthenticationInput{CommandArgs: c.Args}) {
+				retry = false
+			}
+			assert.Equal(t, c.ExpectedRetry, retry, name)
+		})
+	}
+}
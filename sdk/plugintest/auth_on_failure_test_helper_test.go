@@ -0,0 +1,45 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/needsauth"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func TestTestAuthOnFailure(t *testing.T) {
+	executable := schema.Executable{
+		Name:      "Example CLI",
+		Runs:      []string{"example"},
+		NeedsAuth: needsauth.NotForHelpOrVersion(),
+		AuthOnFailure: &schema.AuthOnFailure{
+			ExitCodes:      []int{128},
+			StderrPatterns: []string{`(?i)authentication failed`},
+		},
+	}
+
+	TestAuthOnFailure(t, executable, map[string]AuthOnFailureCase{
+		"retry on authentication error": {
+			Args:          []string{"clone", "https://example.com/repo.git"},
+			ExitCode:      128,
+			Stderr:        "fatal: Authentication failed for 'https://example.com/repo.git'",
+			ExpectedRetry: true,
+		},
+		"no retry on success": {
+			Args:          []string{"clone", "https://example.com/repo.git"},
+			ExpectedRetry: false,
+		},
+		"no retry on other errors": {
+			Args:          []string{"clone", "https://example.com/repo.git"},
+			ExitCode:      128,
+			Stderr:        "fatal: repository not found",
+			ExpectedRetry: false,
+		},
+		"no retry when authentication is not needed": {
+			Args:          []string{"--help"},
+			ExitCode:      128,
+			Stderr:        "fatal: Authentication failed",
+			ExpectedRetry: false,
+		},
+	})
+}
@@ -0,0 +1,81 @@
+package plugintest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCommandLine will invoke the specified provisioner with the item fields and command line specified in each
+// test case, and compares the final command line, rendered as it would be typed in a shell, with the expected one.
+// Comparing the command line as a whole catches quoting and placement errors in arg templates that are easy to
+// miss when comparing the args one by one. For example:
+//
+//	plugintest.TestCommandLine(t, provisioner, map[string]plugintest.CommandLineCase{
+//		"default": {
+//			ItemFields:          map[sdk.FieldName]string{fieldname.Token: "tkn_example"},
+//			CommandLine:         []string{"example", "deploy"},
+//			ExpectedCommandLine: "example deploy --config=/tmp/config.json",
+//		},
+//	})
+func TestCommandLine(t *testing.T, provisioner sdk.Provisioner, cases map[string]CommandLineCase) {
+	t.Helper()
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			in := sdk.ProvisionInput{
+				ItemFields: c.ItemFields,
+				HomeDir:    "~",
+				TempDir:    "/tmp",
+			}
+			out := sdk.ProvisionOutput{
+				Environment: make(map[string]string),
+				Files:       make(map[string]sdk.OutputFile),
+				CommandLine: append([]string{}, c.CommandLine...),
+			}
+			provisioner.Provision(context.Background(), in, &out)
+
+			if len(out.Diagnostics.Errors) > 0 {
+				t.Fatalf("provisioning failed: %v", out.Diagnostics.Errors)
+			}
+
+			description := fmt.Sprintf("Command line: %s", name)
+			assert.Equal(t, c.ExpectedCommandLine, RenderCommandLine(out.CommandLine), description)
+		})
+	}
+}
+
+type CommandLineCase struct {
+	// ItemFields can be used to populate the item fields to pass to the provisioner.
+	ItemFields map[sdk.FieldName]string
+
+	// CommandLine can be used to populate the command line to pass to the provisioner, as the user typed it.
+	CommandLine []string
+
+	// ExpectedCommandLine is the expected final command line, rendered as it would be typed in a shell.
+	// Args that contain whitespace or other characters with a special meaning in the shell are single-quoted.
+	ExpectedCommandLine string
+}
+
+var shellSafeArg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./~-]+$`)
+
+// RenderCommandLine renders the args as a single command line, like it would be typed in a POSIX shell.
+// Args that contain whitespace or other characters with a special meaning in the shell are single-quoted.
+func RenderCommandLine(args []string) string {
+	rendered := make([]string, len(args))
+	for i, arg := range args {
+		if shellSafeArg.MatchString(arg) {
+			rendered[i] = arg
+		} else {
+			rendered[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(rendered, " ")
+}
@@ -0,0 +1,30 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCommandLine(t *testing.T) {
+	assert.Equal(t, `example --name 'John Doe' --quote 'it'\''s' '' --path=~/.example`, RenderCommandLine([]string{
+		"example", "--name", "John Doe", "--quote", "it's", "", "--path=~/.example",
+	}))
+}
+
+func TestCommandLineWithArgTemplates(t *testing.T) {
+	provisioner := provision.TempFile(provision.FieldAsFile("Token"),
+		provision.Filename("token file"),
+		provision.AddArgs("--token-file={{ .Path }}"),
+	)
+
+	TestCommandLine(t, provisioner, map[string]CommandLineCase{
+		"path with a space": {
+			ItemFields:          map[sdk.FieldName]string{"Token": "tkn_example"},
+			CommandLine:         []string{"example", "deploy"},
+			ExpectedCommandLine: "example deploy '--token-file=/tmp/token file'",
+		},
+	})
+}
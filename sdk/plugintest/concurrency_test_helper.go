@@ -0,0 +1,116 @@
+package plugintest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultConcurrentInvocations is the number of concurrent invocations used if not specified in a ConcurrencyCase.
+const defaultConcurrentInvocations = 16
+
+// TestProvisionerConcurrency provisions each specified case concurrently a number of times, each invocation with
+// its own temp dir, like parallel runs of the same executable would. It asserts that the invocations don't
+// write to the same file paths and that all invocations provision the same environment variables and file contents,
+// which would otherwise indicate state shared between invocations. Run the tests with the `-race` flag to also
+// detect data races.
+func TestProvisionerConcurrency(t *testing.T, provisioner sdk.Provisioner, cases map[string]ConcurrencyCase) {
+	t.Helper()
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			invocations := c.Invocations
+			if invocations == 0 {
+				invocations = defaultConcurrentInvocations
+			}
+
+			fsRoot := t.TempDir()
+			outputs := make([]sdk.ProvisionOutput, invocations)
+
+			var wg sync.WaitGroup
+			for i := 0; i < invocations; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					in := sdk.ProvisionInput{
+						ItemFields: copyItemFields(c.ItemFields),
+						HomeDir:    filepath.Join(fsRoot, "~"),
+						TempDir:    filepath.Join(fsRoot, fmt.Sprintf("tmp-%d", i)),
+					}
+					outputs[i] = sdk.ProvisionOutput{
+						Environment: make(map[string]string),
+						Files:       make(map[string]sdk.OutputFile),
+						CommandLine: append([]string{}, c.CommandLine...),
+					}
+					provisioner.Provision(context.Background(), in, &outputs[i])
+				}(i)
+			}
+			wg.Wait()
+
+			description := fmt.Sprintf("Concurrent provision: %s", name)
+			pathOwners := make(map[string]int)
+			for i, out := range outputs {
+				assert.Empty(t, out.Diagnostics.Errors, "%s: invocation %d reported errors", description, i)
+
+				for path := range out.Files {
+					if owner, ok := pathOwners[path]; ok && !c.AllowSharedPaths {
+						t.Errorf("%s: invocations %d and %d both provision a file at %s", description, owner, i, path)
+					}
+					pathOwners[path] = i
+				}
+
+				assert.Equal(t, sortedEnvVarNames(outputs[0]), sortedEnvVarNames(out), "%s: invocation %d provisioned different environment variables", description, i)
+				assert.ElementsMatch(t, fileContents(outputs[0]), fileContents(out), "%s: invocation %d provisioned different file contents", description, i)
+			}
+		})
+	}
+}
+
+type ConcurrencyCase struct {
+	// ItemFields can be used to populate the item fields to pass to the provisioner.
+	ItemFields map[sdk.FieldName]string
+
+	// CommandLine can be used to populate the command line to pass to the provisioner.
+	CommandLine []string
+
+	// Invocations is the number of concurrent invocations. Defaults to 16.
+	Invocations int
+
+	// AllowSharedPaths can be set to true for provisioners that intentionally write to a fixed path, and coordinate
+	// access to that path themselves.
+	AllowSharedPaths bool
+}
+
+func copyItemFields(fields map[sdk.FieldName]string) map[sdk.FieldName]string {
+	result := make(map[sdk.FieldName]string, len(fields))
+	for name, value := range fields {
+		result[name] = value
+	}
+	return result
+}
+
+func sortedEnvVarNames(out sdk.ProvisionOutput) []string {
+	var names []string
+	for name := range out.Environment {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func fileContents(out sdk.ProvisionOutput) []string {
+	var contents []string
+	for _, file := range out.Files {
+		contents = append(contents, string(file.Contents))
+	}
+	return contents
+}
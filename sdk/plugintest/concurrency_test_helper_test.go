@@ -0,0 +1,28 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+)
+
+func TestProvisionerConcurrencyWithTempFiles(t *testing.T) {
+	TestProvisionerConcurrency(t, provision.TempFile(provision.FieldAsFile("Token"), provision.SetPathAsEnvVar("EXAMPLE_TOKEN_FILE")), map[string]ConcurrencyCase{
+		"random filenames": {
+			ItemFields: map[sdk.FieldName]string{
+				"Token": "tkn_example",
+			},
+		},
+	})
+
+	TestProvisionerConcurrency(t, provision.TempFile(provision.FieldAsFile("Token"), provision.AtFixedPath("/etc/example/token")), map[string]ConcurrencyCase{
+		"fixed path": {
+			ItemFields: map[sdk.FieldName]string{
+				"Token": "tkn_example",
+			},
+			Invocations:      4,
+			AllowSharedPaths: true,
+		},
+	})
+}
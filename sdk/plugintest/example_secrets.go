@@ -5,9 +5,12 @@ import (
 	"log"
 	"math/rand"
 	"strings"
+	"testing"
 	"time"
 
+	"github.com/1Password/shell-plugins/sdk"
 	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/stretchr/testify/assert"
 )
 
 const (
@@ -16,12 +19,21 @@ const (
 	digits              = "0123456789"
 	symbols             = "~!@#$%^&*()-_+={}[]\\|<,>.?/\"';:`"
 	secretExampleSuffix = "EXAMPLE"
+
+	// defaultExampleLength is used for value compositions without a fixed length.
+	defaultExampleLength = 32
 )
 
 var seededRand = rand.New(
 	rand.NewSource(time.Now().UnixNano()))
 
+// ExampleSecretFromComposition generates an example value that matches the specified value composition,
+// i.e. it has the same prefix, length, and charset.
 func ExampleSecretFromComposition(v schema.ValueComposition) string {
+	if v.Length == 0 {
+		v.Length = len(v.Prefix) + defaultExampleLength
+	}
+
 	prefix := getPrefix(v)
 	suffix := getSuffix(v)
 	base := generateBase(v, v.Length-len(prefix)-len(suffix))
@@ -29,6 +41,35 @@ func ExampleSecretFromComposition(v schema.ValueComposition) string {
 	return prefix + base + suffix
 }
 
+// ExampleItemFields generates example values for all fields of the credential type that have a value
+// composition set. Fields without a value composition are omitted.
+func ExampleItemFields(credential schema.CredentialType) map[sdk.FieldName]string {
+	fields := make(map[sdk.FieldName]string)
+	for _, field := range credential.Fields {
+		if field.Composition != nil {
+			fields[field.Name] = ExampleSecretFromComposition(*field.Composition)
+		}
+	}
+	return fields
+}
+
+// TestValueCompositions checks that each value composition in the plugin generates example values that
+// match the composition, so that examples used in tests and docs can't drift from the schema.
+func TestValueCompositions(t *testing.T, plugin schema.Plugin) {
+	t.Helper()
+
+	for _, credential := range plugin.Credentials {
+		for _, field := range credential.Fields {
+			if field.Composition == nil {
+				continue
+			}
+
+			example := ExampleSecretFromComposition(*field.Composition)
+			assert.True(t, field.Composition.Matches(example), "%s: example value %q does not match the value composition of field %q", credential.Name, example, field.Name)
+		}
+	}
+}
+
 func getPrefix(v schema.ValueComposition) string {
 	if v.Prefix != "" {
 		return v.Prefix
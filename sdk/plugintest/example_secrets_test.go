@@ -163,3 +163,42 @@ func containsOnlyLowercaseLetters(str string) (bool, error) {
 func containsOnlyDigits(str string) (bool, error) {
 	return regexp.Match("^[0-9]+$", []byte(str))
 }
+
+func TestSecretWithoutFixedLength(t *testing.T) {
+	v := schema.ValueComposition{
+		Prefix: "tkn_",
+		Charset: schema.Charset{
+			Lowercase: true,
+			Digits:    true,
+		},
+	}
+	result := ExampleSecretFromComposition(v)
+
+	assert.Equal(t, len(v.Prefix)+defaultExampleLength, len(result))
+	assert.True(t, v.Matches(result), "should match the value composition")
+}
+
+func TestSecretMatchesComposition(t *testing.T) {
+	cases := map[string]schema.ValueComposition{
+		"with prefix": {
+			Length:  40,
+			Prefix:  "ghp_",
+			Charset: schema.Charset{Uppercase: true, Lowercase: true, Digits: true},
+		},
+		"with symbols": {
+			Length:  30,
+			Charset: schema.Charset{Lowercase: true, Symbols: true},
+		},
+		"with specific chars": {
+			Length:  20,
+			Charset: schema.Charset{Uppercase: true, Specific: []rune{'-', '_'}},
+		},
+	}
+
+	for name, v := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := ExampleSecretFromComposition(v)
+			assert.True(t, v.Matches(result), fmt.Sprintf("%q should match the value composition", result))
+		})
+	}
+}
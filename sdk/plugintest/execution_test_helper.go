@@ -0,0 +1,141 @@
+package plugintest
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// ExecutionTestsEnvVar is the environment variable that opts in to running execution tests, e.g.
+// `PLUGINTEST_E2E=1 go test ./plugins/...`. Execution tests are skipped by default, because they
+// depend on the executable being installed on the machine running the tests.
+const ExecutionTestsEnvVar = "PLUGINTEST_E2E"
+
+// defaultExecutionTimeout is the time an executable is allowed to run in an execution test.
+const defaultExecutionTimeout = 30 * time.Second
+
+// TestExecution runs the executable for each specified case in a sandbox with dummy credentials provisioned, and
+// checks the output of the executable. This can be used to assert that the executable at least reaches
+// its authentication error, which proves it picked up the provisioned (dummy) credentials. The home dir
+// and temp dir are replaced with temporary directories and only PATH is passed on from the environment.
+func TestExecution(t *testing.T, plugin schema.Plugin, executable schema.Executable, cases map[string]ExecutionCase) {
+	t.Helper()
+
+	if os.Getenv(ExecutionTestsEnvVar) == "" {
+		t.Skipf("skipping execution tests, set %s=1 to run them", ExecutionTestsEnvVar)
+	}
+
+	if len(executable.Runs) == 0 {
+		t.Fatalf("executable %s has no command set", executable.Name)
+	}
+
+	if _, err := exec.LookPath(executable.Runs[0]); err != nil {
+		t.Skipf("skipping execution tests, %s is not installed", executable.Runs[0])
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			sandbox := t.TempDir()
+			homeDir := filepath.Join(sandbox, "home")
+			tempDir := filepath.Join(sandbox, "tmp")
+			for _, dir := range []string{homeDir, tempDir} {
+				if err := os.MkdirAll(dir, 0700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ctx := context.Background()
+			out := sdk.ProvisionOutput{
+				Environment: make(map[string]string),
+				Files:       make(map[string]sdk.OutputFile),
+				CommandLine: append(append([]string{}, executable.Runs...), c.Args...),
+			}
+
+			for _, usage := range executable.Uses {
+				credential, provisioner := plugin.CredentialForUsage(usage)
+				if provisioner == nil {
+					continue
+				}
+
+				itemFields := c.ItemFields
+				if itemFields == nil {
+					itemFields = ExampleItemFields(*credential)
+				}
+
+				in := sdk.ProvisionInput{
+					HomeDir:    homeDir,
+					TempDir:    tempDir,
+					ItemFields: itemFields,
+				}
+				provisioner.Provision(ctx, in, &out)
+				defer provisioner.Deprovision(ctx, sdk.DeprovisionInput{HomeDir: homeDir, TempDir: tempDir}, &sdk.DeprovisionOutput{})
+			}
+
+			if len(out.Diagnostics.Errors) > 0 {
+				t.Fatalf("provisioning failed: %v", out.Diagnostics.Errors)
+			}
+
+			for path, file := range out.Files {
+				if !strings.HasPrefix(path, sandbox) {
+					t.Fatalf("provisioned file %s is outside of the sandbox", path)
+				}
+				if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, file.Contents, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			timeout := c.Timeout
+			if timeout == 0 {
+				timeout = defaultExecutionTimeout
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			cmd := exec.CommandContext(ctx, out.CommandLine[0], out.CommandLine[1:]...)
+			cmd.Dir = sandbox
+			cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "HOME=" + homeDir, "TMPDIR=" + tempDir}
+			for envVarName, value := range out.Environment {
+				cmd.Env = append(cmd.Env, envVarName+"="+value)
+			}
+
+			output, _ := cmd.CombinedOutput()
+			if ctx.Err() != nil {
+				t.Fatalf("executable did not exit within %s", timeout)
+			}
+
+			if c.ExpectedOutput != "" {
+				assert.Regexp(t, regexp.MustCompile(c.ExpectedOutput), string(output), "Execution: %s", name)
+			}
+		})
+	}
+}
+
+type ExecutionCase struct {
+	// Args can be used to set the command-line args to pass to the executable.
+	Args []string
+
+	// ItemFields can be used to populate the item fields to provision. Defaults to example values
+	// generated from the value compositions of the credential fields.
+	ItemFields map[sdk.FieldName]string
+
+	// ExpectedOutput is a regular expression that the combined stdout and stderr of the executable should match,
+	// e.g. the authentication error the executable returns for the dummy credentials.
+	ExpectedOutput string
+
+	// Timeout can be used to override the time the executable is allowed to run.
+	Timeout time.Duration
+}
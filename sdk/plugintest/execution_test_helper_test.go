@@ -0,0 +1,46 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func TestExecutionWithShell(t *testing.T) {
+	t.Setenv(ExecutionTestsEnvVar, "1")
+
+	credential := schema.CredentialType{
+		Name: "API Token",
+		Fields: []schema.CredentialField{
+			{
+				Name:        "Token",
+				Secret:      true,
+				Composition: &schema.ValueComposition{Length: 20, Prefix: "tkn_", Charset: schema.Charset{Digits: true}},
+			},
+		},
+		DefaultProvisioner: provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}),
+	}
+	executable := schema.Executable{
+		Name: "Shell",
+		Runs: []string{"sh"},
+		Uses: []schema.CredentialUsage{{Name: credential.Name}},
+	}
+	plugin := schema.Plugin{
+		Credentials: []schema.CredentialType{credential},
+		Executables: []schema.Executable{executable},
+	}
+
+	TestExecution(t, plugin, executable, map[string]ExecutionCase{
+		"example credential": {
+			Args:           []string{"-c", "echo token=$EXAMPLE_TOKEN"},
+			ExpectedOutput: `token=tkn_\d{16}`,
+		},
+		"custom credential": {
+			Args:           []string{"-c", "echo token=$EXAMPLE_TOKEN"},
+			ItemFields:     map[sdk.FieldName]string{"Token": "tkn_custom"},
+			ExpectedOutput: `token=tkn_custom`,
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package plugintest
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+// TestFieldCoverage runs the default provisioner and the importer of the credential type for the specified cases, and
+// checks that every field of the credential type is used by at least one of them. A field counts as provisioned if
+// its value shows up in the provisioned environment, files or command line, and as imported if the importer returns
+// it in a candidate. Uncovered required fields fail the test, while uncovered optional fields only log a warning.
+// The cases can be shared with TestProvisioner and TestImporter:
+//
+//	func TestAPITokenFieldCoverage(t *testing.T) {
+//		plugintest.TestFieldCoverage(t, APIToken(), provisionCases, importCases)
+//	}
+func TestFieldCoverage(t *testing.T, credential schema.CredentialType, provisionCases map[string]ProvisionCase, importCases map[string]ImportCase) {
+	t.Helper()
+
+	provisioned := make(map[sdk.FieldName]bool)
+	if credential.DefaultProvisioner != nil {
+		for _, c := range provisionCases {
+			_, out := runProvisioner(context.Background(), credential.DefaultProvisioner, c)
+			for name := range provisionedFields(c.ItemFields, out) {
+				provisioned[name] = true
+			}
+		}
+	}
+
+	imported := make(map[sdk.FieldName]bool)
+	if credential.Importer != nil {
+		for name, c := range importCases {
+			t.Run(name, func(t *testing.T) {
+				out := runImporter(t, credential.Importer, c)
+				for _, candidate := range out.AllCandidates() {
+					for name, value := range candidate.Fields {
+						if value != "" {
+							imported[name] = true
+						}
+					}
+				}
+			})
+		}
+	}
+
+	for _, field := range credential.Fields {
+		var missing string
+		switch {
+		case !provisioned[field.Name] && !imported[field.Name]:
+			missing = "provisioner and importer tests"
+		case !provisioned[field.Name]:
+			missing = "provisioner tests"
+		case !imported[field.Name]:
+			missing = "importer tests"
+		default:
+			continue
+		}
+
+		if field.Optional || provisioned[field.Name] || imported[field.Name] {
+			t.Logf("⚠ %s: field %q is not covered by %s", credential.Name, field.Name, missing)
+		} else {
+			t.Errorf("✘ %s: field %q is not covered by %s", credential.Name, field.Name, missing)
+		}
+	}
+}
+
+// provisionedFields returns the item fields whose values show up in the provision output.
+func provisionedFields(itemFields map[sdk.FieldName]string, out sdk.ProvisionOutput) map[sdk.FieldName]bool {
+	used := make(map[sdk.FieldName]bool)
+	for name, value := range itemFields {
+		if value == "" {
+			continue
+		}
+
+		for _, envVarValue := range out.Environment {
+			if strings.Contains(envVarValue, value) {
+				used[name] = true
+			}
+		}
+		for _, file := range out.Files {
+			if bytes.Contains(file.Contents, []byte(value)) {
+				used[name] = true
+			}
+		}
+		for _, arg := range out.CommandLine {
+			if strings.Contains(arg, value) {
+				used[name] = true
+			}
+		}
+	}
+	return used
+}
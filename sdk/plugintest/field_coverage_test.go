@@ -0,0 +1,48 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionedFields(t *testing.T) {
+	itemFields := map[sdk.FieldName]string{
+		"Token":    "tkn_example",
+		"Host":     "example.com",
+		"Username": "wendy",
+	}
+	out := sdk.ProvisionOutput{
+		Environment: map[string]string{"EXAMPLE_TOKEN": "tkn_example"},
+		Files:       map[string]sdk.OutputFile{"/tmp/config": {Contents: []byte("host = example.com\n")}},
+		CommandLine: []string{"example", "deploy"},
+	}
+
+	assert.Equal(t, map[sdk.FieldName]bool{"Token": true, "Host": true}, provisionedFields(itemFields, out))
+}
+
+func TestFieldCoverageOfCredentialType(t *testing.T) {
+	credential := schema.CredentialType{
+		Name: "API Token",
+		Fields: []schema.CredentialField{
+			{Name: "Token", Secret: true},
+			{Name: "Host", Optional: true},
+		},
+		DefaultProvisioner: provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}),
+		Importer:           importer.TryEnvVarPair(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token", "EXAMPLE_HOST": "Host"}),
+	}
+
+	TestFieldCoverage(t, credential, map[string]ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{"Token": "tkn_example", "Host": "example.com"},
+		},
+	}, map[string]ImportCase{
+		"environment": {
+			Environment: map[string]string{"EXAMPLE_TOKEN": "tkn_example"},
+		},
+	})
+}
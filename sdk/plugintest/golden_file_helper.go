@@ -0,0 +1,52 @@
+package plugintest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// updateGoldenFilesEnvVar can be set to 1 to update the golden files in the test-fixtures dir instead of comparing
+// against them. This is an env var rather than a flag, so that it doesn't clash with flags of the test packages.
+const updateGoldenFilesEnvVar = "UPDATE_GOLDEN"
+
+// AssertGoldenFile compares the specified contents with the golden file in the "test-fixtures" dir in the plugin
+// directory. When running the tests with `UPDATE_GOLDEN=1`, the golden file gets (re)written with the contents instead.
+func AssertGoldenFile(t *testing.T, filename string, actual []byte) {
+	t.Helper()
+
+	_, testFilename, _, ok := runtime.Caller(1)
+	if !ok {
+		t.Fatal()
+	}
+
+	assertGoldenFile(t, filepath.Dir(testFilename), filename, actual)
+}
+
+func assertGoldenFile(t *testing.T, testDir string, filename string, actual []byte) {
+	t.Helper()
+
+	goldenPath := filepath.Join(testDir, "test-fixtures", filename)
+	if os.Getenv(updateGoldenFilesEnvVar) == "1" {
+		err := os.MkdirAll(filepath.Dir(goldenPath), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(goldenPath, actual, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("reading golden file (run the tests with UPDATE_GOLDEN=1 to create it): %s", err)
+	}
+
+	assert.Equal(t, string(expected), string(actual), "contents do not match golden file %s", filename)
+}
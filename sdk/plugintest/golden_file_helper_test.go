@@ -0,0 +1,46 @@
+package plugintest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionerGoldenFiles(t *testing.T) {
+	provisioner := provision.TempFile(func(in sdk.ProvisionInput) ([]byte, error) {
+		return []byte("[default]\ntoken = " + in.ItemFields["Token"] + "\n"), nil
+	}, provision.Filename("config.ini"))
+
+	TestProvisioner(t, provisioner, map[string]ProvisionCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				"Token": "tkn_example",
+			},
+			GoldenFiles: map[string]string{
+				"/tmp/config.ini": "golden-config.ini",
+			},
+		},
+	})
+}
+
+func TestAssertGoldenFile(t *testing.T) {
+	AssertGoldenFile(t, "golden-config.ini", []byte("[default]\ntoken = tkn_example\n"))
+}
+
+func TestUpdateGoldenFile(t *testing.T) {
+	testDir := t.TempDir()
+	t.Setenv("UPDATE_GOLDEN", "1")
+	assertGoldenFile(t, testDir, "golden-config.ini", []byte("[default]\ntoken = tkn_example\n"))
+
+	contents, err := os.ReadFile(filepath.Join(testDir, "test-fixtures", "golden-config.ini"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "[default]\ntoken = tkn_example\n", string(contents))
+	assert.Nil(t, flag.Lookup("update"), "plugintest should not register flags that test packages might define themselves")
+}
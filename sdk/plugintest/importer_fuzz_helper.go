@@ -0,0 +1,61 @@
+package plugintest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// maxFuzzImportDuration is the time a single import run is allowed to take when fuzzing.
+const maxFuzzImportDuration = time.Second
+
+// FuzzImporter feeds malformed, truncated, and random file contents to the importer, mounted at the specified
+// path, and fails if the importer panics or doesn't return in time. The specified seeds, such as the contents of
+// test fixtures, are used as the starting point for the fuzzer, together with truncated versions of the seeds.
+// For example:
+//
+//	func FuzzConfigFileImporter(f *testing.F) {
+//		plugintest.FuzzImporter(f, TryConfigFile(), "~/.config/tool/config.yml", plugintest.LoadFixture(f, "config.yml"))
+//	}
+func FuzzImporter(f *testing.F, importer sdk.Importer, path string, seeds ...string) {
+	f.Helper()
+
+	f.Add([]byte{})
+	f.Add([]byte{0x00, 0xff, 0xfe})
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+		if len(seed) > 1 {
+			f.Add([]byte(seed[:len(seed)/2]))
+			f.Add([]byte(seed[:len(seed)-1]))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, contents []byte) {
+		fsRoot := t.TempDir()
+		in := sdk.ImportInput{
+			HomeDir: filepath.Join(fsRoot, "~"),
+			RootDir: fsRoot,
+		}
+		writeFiles(t, fsRoot, map[string]string{path: string(contents)})
+
+		done := make(chan any, 1)
+		go func() {
+			defer func() {
+				done <- recover()
+			}()
+			importer(context.Background(), in, &sdk.ImportOutput{})
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("importer panicked on file contents %q: %s", contents, err)
+			}
+		case <-time.After(maxFuzzImportDuration):
+			t.Fatalf("importer did not return within %s on file contents %q", maxFuzzImportDuration, contents)
+		}
+	})
+}
@@ -24,33 +24,7 @@ func TestImporter(t *testing.T, importer sdk.Importer, cases map[string]ImportCa
 				t.Fatal("ExpectedOutput and ExpectedCandidates can't both be set in the same test case")
 			}
 
-			for envVarName, value := range c.Environment {
-				t.Setenv(envVarName, value)
-			}
-
-			fsRoot := t.TempDir()
-			in := sdk.ImportInput{
-				HomeDir: filepath.Join(fsRoot, "~"),
-				RootDir: fsRoot,
-				OS:      c.OS,
-			}
-
-			for path, contents := range c.Files {
-				path = filepath.Join(fsRoot, path)
-				err := os.MkdirAll(filepath.Dir(path), 0700)
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				err = os.WriteFile(path, []byte(contents), 0600)
-				if err != nil {
-					t.Fatal(err)
-				}
-			}
-
-			ctx := context.Background()
-			out := sdk.ImportOutput{}
-			importer(ctx, in, &out)
+			out := runImporter(t, importer, c)
 
 			description := fmt.Sprintf("Import: %s", name)
 
@@ -59,14 +33,99 @@ func TestImporter(t *testing.T, importer sdk.Importer, cases map[string]ImportCa
 			} else {
 				assert.ElementsMatch(t, c.ExpectedCandidates, out.AllCandidates(), description)
 			}
-
-			for envVarName := range c.Environment {
-				t.Setenv(envVarName, "")
-			}
 		})
 	}
 }
 
+// runImporter runs the importer in a simulated filesystem with the files and environment of the case.
+func runImporter(t *testing.T, importer sdk.Importer, c ImportCase) sdk.ImportOutput {
+	t.Helper()
+
+	for envVarName, value := range c.Environment {
+		t.Setenv(envVarName, value)
+	}
+
+	fsRoot := t.TempDir()
+	in := sdk.ImportInput{
+		HomeDir: filepath.Join(fsRoot, "~"),
+		RootDir: fsRoot,
+		OS:      c.OS,
+	}
+
+	for envVarName, path := range simulatedOSEnvironment(c.OS) {
+		if _, ok := c.RootedEnvironment[envVarName]; !ok {
+			t.Setenv(envVarName, rootedPath(fsRoot, path))
+		}
+	}
+
+	for envVarName, path := range c.RootedEnvironment {
+		t.Setenv(envVarName, rootedPath(fsRoot, path))
+	}
+
+	files := c.Files
+	if c.OS == "windows" {
+		files = make(map[string]string)
+		for path, contents := range c.Files {
+			files[fromWindowsPath(path)] = contents
+		}
+	}
+	writeFiles(t, fsRoot, files)
+
+	ctx := context.Background()
+	out := sdk.ImportOutput{}
+	importer(ctx, in, &out)
+
+	for envVarName := range c.Environment {
+		t.Setenv(envVarName, "")
+	}
+	for envVarName := range c.RootedEnvironment {
+		t.Setenv(envVarName, "")
+	}
+
+	return out
+}
+
+// writeFiles mounts the specified files in the specified root dir, using the format: path -> contents.
+func writeFiles(t *testing.T, fsRoot string, files map[string]string) {
+	t.Helper()
+
+	for path, contents := range files {
+		path = filepath.Join(fsRoot, path)
+		err := os.MkdirAll(filepath.Dir(path), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(path, []byte(contents), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// simulatedOSEnvironment returns the environment variables an OS sets by default that point to locations in the
+// user's home dir, using the format: env var name -> path in the simulated filesystem.
+func simulatedOSEnvironment(os string) map[string]string {
+	switch os {
+	case "windows":
+		return map[string]string{
+			"USERPROFILE":  "~",
+			"APPDATA":      "~/AppData/Roaming",
+			"LOCALAPPDATA": "~/AppData/Local",
+		}
+	default:
+		return map[string]string{
+			"XDG_CONFIG_HOME": "~/.config",
+		}
+	}
+}
+
+// rootedPath maps a path in the simulated filesystem, such as "~/.config" or "/etc/config", to the location of that
+// path inside the specified root dir.
+func rootedPath(fsRoot string, path string) string {
+	return filepath.Join(fsRoot, path)
+}
+
 type ImportCase struct {
 	// Environment can be used to set environment variables for the importer test.
 	Environment map[string]string
@@ -76,9 +135,17 @@ type ImportCase struct {
 	// LoadFixture helper.
 	Files map[string]string
 
-	// OS can be used to test OS-specific importers. Supported values: "darwin", "linux"
+	// OS can be used to test OS-specific importers. Supported values: "darwin", "linux", "windows".
+	// The environment variables the OS sets to point to the user's home dir and config dir, such as
+	// XDG_CONFIG_HOME or APPDATA, are set to the corresponding locations in the simulated filesystem.
+	// When set to "windows", the paths in Files can also be specified as Windows paths, such as
+	// `%APPDATA%\my-plugin\config.json` or `C:\Users\plugintest\.my-plugin`.
 	OS string
 
+	// RootedEnvironment can be used to set environment variables that contain a path in the simulated filesystem,
+	// such as XDG_CONFIG_HOME -> "~/.custom-config". These paths get mapped to the location inside the temp dir.
+	RootedEnvironment map[string]string
+
 	// ExpectedCandidates is a shorthand to set the expected import candidates. Mutually exclusive with ExpectedOutput.
 	ExpectedCandidates []sdk.ImportCandidate
 
@@ -0,0 +1,71 @@
+package plugintest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/importer"
+)
+
+func TestImporterWithSimulatedOS(t *testing.T) {
+	configDirImporter := importer.TryConfigDirFile("example/token", func(ctx context.Context, contents importer.FileContents, in sdk.ImportInput, out *sdk.ImportAttempt) {
+		out.AddCandidate(sdk.ImportCandidate{
+			Fields: map[sdk.FieldName]string{
+				"Token": contents.ToString(),
+			},
+		})
+	})
+
+	expectedCandidates := []sdk.ImportCandidate{
+		{Fields: map[sdk.FieldName]string{"Token": "tkn_example"}},
+	}
+
+	TestImporter(t, configDirImporter, map[string]ImportCase{
+		"linux": {
+			OS: "linux",
+			Files: map[string]string{
+				"~/.config/example/token": "tkn_example",
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"linux with XDG_CONFIG_HOME override": {
+			OS: "linux",
+			RootedEnvironment: map[string]string{
+				"XDG_CONFIG_HOME": "~/.custom-config",
+			},
+			Files: map[string]string{
+				"~/.custom-config/example/token": "tkn_example",
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"macOS": {
+			OS: "darwin",
+			Files: map[string]string{
+				"~/Library/Application Support/example/token": "tkn_example",
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"windows": {
+			OS: "windows",
+			Files: map[string]string{
+				"~/AppData/Roaming/example/token": "tkn_example",
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"windows with file specified as Windows path": {
+			OS: "windows",
+			Files: map[string]string{
+				`%APPDATA%\example\token`: "tkn_example",
+			},
+			ExpectedCandidates: expectedCandidates,
+		},
+		"windows without file in APPDATA": {
+			OS: "windows",
+			Files: map[string]string{
+				"~/.config/example/token": "tkn_example",
+			},
+			ExpectedCandidates: nil,
+		},
+	})
+}
@@ -0,0 +1,217 @@
+package plugintest
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProvisionLifecycle runs the full provision and deprovision cycle for each specified case, simulating what
+// the 1Password CLI does around it: provisioned files are written to disk before the executable runs, and deleted
+// again after Deprovision. It then asserts that the cleanup actually happened: the deprovision step reported
+// no errors, no files were left behind or modified in the home dir and temp dir, and the environment of the
+// process was left untouched.
+func TestProvisionLifecycle(t *testing.T, provisioner sdk.Provisioner, cases map[string]LifecycleCase) {
+	t.Helper()
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+			runLifecycle(t, provisioner, fmt.Sprintf("Lifecycle: %s", name), c, NoInterruption)
+		})
+	}
+}
+
+// Interruption describes how the lifecycle is interrupted before it can complete normally.
+type Interruption string
+
+const (
+	// NoInterruption runs the lifecycle to completion.
+	NoInterruption Interruption = "none"
+
+	// CancelDuringProvision cancels the context while the provisioner is still running, for example because the
+	// user pressed Ctrl+C while waiting for a credential. Deprovision is still expected to clean up.
+	CancelDuringProvision Interruption = "cancel during provision"
+
+	// KillCommand simulates the wrapped command being killed, so it had no chance to clean up after itself.
+	// Deprovision is still expected to clean up.
+	KillCommand Interruption = "kill command"
+
+	// DeprovisionTimedOut simulates Deprovision being called when its deadline has already passed. Deprovision is
+	// expected to return right away, instead of waiting on anything.
+	DeprovisionTimedOut Interruption = "deprovision timed out"
+)
+
+// timedOutDeprovisionBudget is how long Deprovision may take to return once its context is done.
+const timedOutDeprovisionBudget = time.Second
+
+// AllInterruptions lists all the interruptions that TestProvisionLifecycleInterruptions simulates.
+var AllInterruptions = []Interruption{CancelDuringProvision, KillCommand, DeprovisionTimedOut}
+
+// TestProvisionLifecycleInterruptions runs the provision and deprovision cycle for each specified case once for
+// every interruption in AllInterruptions, and asserts that the provisioner cleans up after itself just like it
+// does when the lifecycle runs to completion.
+func TestProvisionLifecycleInterruptions(t *testing.T, provisioner sdk.Provisioner, cases map[string]LifecycleCase) {
+	t.Helper()
+
+	for name, c := range cases {
+		for _, interruption := range AllInterruptions {
+			t.Run(fmt.Sprintf("%s/%s", name, interruption), func(t *testing.T) {
+				t.Helper()
+				runLifecycle(t, provisioner, fmt.Sprintf("Lifecycle: %s (%s)", name, interruption), c, interruption)
+			})
+		}
+	}
+}
+
+func runLifecycle(t *testing.T, provisioner sdk.Provisioner, description string, c LifecycleCase, interruption Interruption) {
+	t.Helper()
+
+	fsRoot := t.TempDir()
+	homeDir := filepath.Join(fsRoot, "~")
+	tempDir := filepath.Join(fsRoot, "tmp")
+	if err := os.MkdirAll(tempDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, fsRoot, c.Files)
+
+	filesBefore := snapshotFiles(t, fsRoot)
+	envBefore := os.Environ()
+
+	provisionCtx, cancelProvision := context.WithCancel(context.Background())
+	defer cancelProvision()
+	if interruption == CancelDuringProvision {
+		// The provisioner runs synchronously, so an already canceled context is what a provisioner that checks
+		// the context mid-flight would observe.
+		cancelProvision()
+	}
+	state := provisionAndWriteFiles(t, provisionCtx, provisioner, c, homeDir, tempDir)
+
+	reason := sdk.DeprovisionReasonExited
+	deprovisionTimeout := sdk.DeprovisionTimeout
+	switch interruption {
+	case CancelDuringProvision:
+		reason = sdk.DeprovisionReasonInterrupted
+	case KillCommand:
+		reason = sdk.DeprovisionReasonKilled
+	case DeprovisionTimedOut:
+		deprovisionTimeout = 0
+	}
+	if len(state.output.Diagnostics.Errors) > 0 {
+		reason = sdk.DeprovisionReasonProvisionFailed
+	}
+
+	deprovisionCtx, cancelDeprovision := context.WithTimeout(context.Background(), deprovisionTimeout)
+	defer cancelDeprovision()
+	start := time.Now()
+	deprovisionAndCleanUp(t, deprovisionCtx, provisioner, state, reason, homeDir, tempDir)
+	if interruption == DeprovisionTimedOut && time.Since(start) > timedOutDeprovisionBudget {
+		t.Errorf("%s: deprovisioning took %s after its deadline had passed", description, time.Since(start))
+	}
+
+	assert.Equal(t, filesBefore, snapshotFiles(t, fsRoot), "%s: files in the home dir or temp dir were left behind or modified", description)
+	assert.ElementsMatch(t, envBefore, os.Environ(), "%s: the environment of the process was modified", description)
+}
+
+type LifecycleCase struct {
+	// ItemFields can be used to populate the item fields to pass to the provisioner.
+	ItemFields map[sdk.FieldName]string
+
+	// CommandLine can be used to populate the command line to pass to the provisioner.
+	CommandLine []string
+
+	// Files can be used to set files that already exist before provisioning, using the format: path -> contents.
+	// For example: ~/.aws/config -> '[default]'. These files should be restored after deprovisioning.
+	Files map[string]string
+}
+
+// lifecycleState keeps track of what was provisioned, so it can be cleaned up again.
+type lifecycleState struct {
+	output       sdk.ProvisionOutput
+	writtenFiles []string
+}
+
+// provisionAndWriteFiles runs the provision step and writes the provisioned files to disk, like the 1Password CLI does.
+func provisionAndWriteFiles(t *testing.T, ctx context.Context, provisioner sdk.Provisioner, c LifecycleCase, homeDir string, tempDir string) lifecycleState {
+	t.Helper()
+
+	in := sdk.ProvisionInput{
+		HomeDir:    homeDir,
+		TempDir:    tempDir,
+		ItemFields: c.ItemFields,
+	}
+	out := sdk.ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]sdk.OutputFile),
+		CommandLine: c.CommandLine,
+	}
+	provisioner.Provision(ctx, in, &out)
+
+	state := lifecycleState{output: out}
+	for path, file := range out.Files {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, file.Contents, 0600); err != nil {
+			t.Fatal(err)
+		}
+		state.writtenFiles = append(state.writtenFiles, path)
+	}
+	return state
+}
+
+// deprovisionAndCleanUp runs the deprovision step and deletes the provisioned files, like the 1Password CLI does.
+func deprovisionAndCleanUp(t *testing.T, ctx context.Context, provisioner sdk.Provisioner, state lifecycleState, reason sdk.DeprovisionReason, homeDir string, tempDir string) {
+	t.Helper()
+
+	out := sdk.DeprovisionOutput{}
+	provisioner.Deprovision(ctx, sdk.DeprovisionInput{HomeDir: homeDir, TempDir: tempDir, Reason: reason}, &out)
+	assert.Empty(t, out.Diagnostics.Errors, "deprovisioning reported errors")
+
+	for _, path := range state.writtenFiles {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(tempDir, entry.Name())); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// snapshotFiles returns the contents of all files in the specified dir, using the format: relative path -> contents.
+func snapshotFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[strings.TrimPrefix(path, dir)] = string(contents)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
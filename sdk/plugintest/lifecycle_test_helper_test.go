@@ -0,0 +1,31 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+)
+
+func TestProvisionLifecycleCleansUp(t *testing.T) {
+	cases := map[string]LifecycleCase{
+		"default": {
+			ItemFields: map[sdk.FieldName]string{
+				"Token": "tkn_example",
+			},
+			Files: map[string]string{
+				"~/.config/example/settings.yml": "color: true",
+			},
+		},
+	}
+
+	t.Run("env var provisioner", func(t *testing.T) {
+		TestProvisionLifecycle(t, provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}), cases)
+		TestProvisionLifecycleInterruptions(t, provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}), cases)
+	})
+
+	t.Run("temp file provisioner", func(t *testing.T) {
+		TestProvisionLifecycle(t, provision.TempFile(provision.FieldAsFile("Token"), provision.SetPathAsEnvVar("EXAMPLE_TOKEN_FILE")), cases)
+		TestProvisionLifecycleInterruptions(t, provision.TempFile(provision.FieldAsFile("Token"), provision.SetPathAsEnvVar("EXAMPLE_TOKEN_FILE")), cases)
+	})
+}
@@ -1,15 +1,29 @@
 package plugintest
 
 import (
+	"bufio"
+	"strings"
 	"testing"
 
 	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
 	"github.com/stretchr/testify/assert"
 )
 
 type NeedsAuthCase struct {
 	Args              []string
 	ExpectedNeedsAuth bool
+
+	// WorkingDir can be used to set the directory the executable gets run from.
+	WorkingDir string
+
+	// StdinIsTTY and StdoutIsTTY can be used to simulate whether the executable is attached to a terminal.
+	// Leave them nil to simulate a CLI that doesn't report this.
+	StdinIsTTY  *bool
+	StdoutIsTTY *bool
+
+	// Environment can be used to set the environment variables the executable gets run with.
+	Environment map[string]string
 }
 
 func TestNeedsAuth(t *testing.T, rule sdk.NeedsAuthentication, cases map[string]NeedsAuthCase) {
@@ -19,8 +33,119 @@ func TestNeedsAuth(t *testing.T, rule sdk.NeedsAuthentication, cases map[string]
 			t.Helper()
 			in := sdk.NeedsAuthenticationInput{
 				CommandArgs: c.Args,
+				WorkingDir:  c.WorkingDir,
+				StdinIsTTY:  c.StdinIsTTY,
+				StdoutIsTTY: c.StdoutIsTTY,
+				Environment: c.Environment,
 			}
 			assert.Equal(t, c.ExpectedNeedsAuth, rule(in), name)
 		})
 	}
 }
+
+// TestNeedsAuthCorpus evaluates the NeedsAuth rules of the executable against a corpus of realistic command lines.
+// Each line of the corpus starts with "auth:" if the command line should require authentication, or "skip:" if it
+// should not, followed by the full command line including the executable (or one of its aliases). Empty lines and
+// lines starting with "#" are ignored. The corpus can be loaded from the "test-fixtures" dir using LoadFixture.
+// For example:
+//
+//	# Deployments talk to the backend
+//	auth: example deploy --app "my app"
+//	skip: example deploy --help
+func TestNeedsAuthCorpus(t *testing.T, executable schema.Executable, corpus string) {
+	t.Helper()
+
+	if executable.NeedsAuth == nil {
+		t.Fatalf("executable %s has no NeedsAuth rules set", executable.Name)
+	}
+
+	cases := make(map[string]NeedsAuthCase)
+	scanner := bufio.NewScanner(strings.NewReader(corpus))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var expectedNeedsAuth bool
+		var commandLine string
+		if strings.HasPrefix(line, "auth:") {
+			expectedNeedsAuth = true
+			commandLine = strings.TrimPrefix(line, "auth:")
+		} else if strings.HasPrefix(line, "skip:") {
+			commandLine = strings.TrimPrefix(line, "skip:")
+		} else {
+			t.Fatalf("line %d of the corpus should start with \"auth:\" or \"skip:\": %s", lineNumber, line)
+		}
+
+		args := splitCommandLine(commandLine)
+		commandArgs, ok := stripEntrypoint(executable, args)
+		if !ok {
+			t.Fatalf("line %d of the corpus does not run %s: %s", lineNumber, executable.Command(), line)
+		}
+
+		cases[strings.TrimSpace(commandLine)] = NeedsAuthCase{
+			Args:              commandArgs,
+			ExpectedNeedsAuth: expectedNeedsAuth,
+		}
+	}
+
+	TestNeedsAuth(t, executable.NeedsAuth, cases)
+}
+
+// stripEntrypoint removes the entrypoint of the executable, or one of its aliases, from the start of the args.
+func stripEntrypoint(executable schema.Executable, args []string) ([]string, bool) {
+	for _, entrypoint := range executable.Entrypoints() {
+		if len(entrypoint) == 0 || len(args) < len(entrypoint) {
+			continue
+		}
+
+		matches := true
+		for i := range entrypoint {
+			if entrypoint[i] != args[i] {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return args[len(entrypoint):], true
+		}
+	}
+	return nil, false
+}
+
+// splitCommandLine splits a command line into args on whitespace, keeping single-quoted and double-quoted
+// strings together.
+func splitCommandLine(commandLine string) []string {
+	args := []string{}
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range commandLine {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
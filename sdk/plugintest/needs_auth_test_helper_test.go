@@ -0,0 +1,22 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	cases := map[string][]string{
+		"aws s3 ls":                         {"aws", "s3", "ls"},
+		"  aws   s3\tls ":                   {"aws", "s3", "ls"},
+		`example deploy --app "my app"`:     {"example", "deploy", "--app", "my app"},
+		`example run 'echo "hi"' --verbose`: {"example", "run", `echo "hi"`, "--verbose"},
+		`example --name ""`:                 {"example", "--name", ""},
+		"":                                  {},
+	}
+
+	for commandLine, expected := range cases {
+		assert.Equal(t, expected, splitCommandLine(commandLine), commandLine)
+	}
+}
@@ -0,0 +1,190 @@
+package plugintest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"gopkg.in/ini.v1"
+)
+
+// defaultPropertyRuns is the number of runs used if not specified in a PropertyCase.
+const defaultPropertyRuns = 50
+
+// ProvisionInvariant checks a property that should hold for the output of a provisioner, given the item fields
+// that were provisioned. It returns an error if the property does not hold.
+type ProvisionInvariant func(fields map[sdk.FieldName]string, out sdk.ProvisionOutput) error
+
+// TestProvisionerProperties runs the provisioner a number of times with randomized item fields generated from the
+// value compositions of the credential type, and checks that all specified invariants hold for each output. This
+// catches escaping and truncation bugs that only show up with unusual characters. Fields without a value
+// composition can be set in the item fields of the PropertyCase.
+func TestProvisionerProperties(t *testing.T, credential schema.CredentialType, provisioner sdk.Provisioner, cases map[string]PropertyCase) {
+	t.Helper()
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			runs := c.Runs
+			if runs == 0 {
+				runs = defaultPropertyRuns
+			}
+
+			for i := 0; i < runs; i++ {
+				fields := ExampleItemFields(credential)
+				for fieldName, value := range c.ItemFields {
+					fields[fieldName] = value
+				}
+
+				in := sdk.ProvisionInput{
+					ItemFields: fields,
+					HomeDir:    "~",
+					TempDir:    "/tmp",
+				}
+				out := sdk.ProvisionOutput{
+					Environment: make(map[string]string),
+					Files:       make(map[string]sdk.OutputFile),
+					CommandLine: append([]string{}, c.CommandLine...),
+				}
+				provisioner.Provision(context.Background(), in, &out)
+
+				if len(out.Diagnostics.Errors) > 0 {
+					t.Fatalf("provisioning failed for item fields %q: %v", fields, out.Diagnostics.Errors)
+				}
+
+				for _, invariant := range c.Invariants {
+					if err := invariant(fields, out); err != nil {
+						t.Fatalf("invariant does not hold for item fields %q: %s", fields, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+type PropertyCase struct {
+	// ItemFields can be used to set fixed values for item fields, e.g. for fields without a value composition.
+	ItemFields map[sdk.FieldName]string
+
+	// CommandLine can be used to populate the command line to pass to the provisioner.
+	CommandLine []string
+
+	// Invariants are checked against the output of every run.
+	Invariants []ProvisionInvariant
+
+	// Runs is the number of runs with different randomized item fields. Defaults to 50.
+	Runs int
+}
+
+// NoTruncation returns an invariant that checks that the values of the specified fields are provisioned in full,
+// either as the value of an environment variable, or as part of a command-line arg or file.
+func NoTruncation(fieldNames ...sdk.FieldName) ProvisionInvariant {
+	return func(fields map[sdk.FieldName]string, out sdk.ProvisionOutput) error {
+		for _, fieldName := range fieldNames {
+			value := fields[fieldName]
+			if !outputContains(out, value) {
+				return fmt.Errorf("the value of field %q is not provisioned in full", fieldName)
+			}
+		}
+		return nil
+	}
+}
+
+func outputContains(out sdk.ProvisionOutput, value string) bool {
+	for _, envValue := range out.Environment {
+		if envValue == value {
+			return true
+		}
+	}
+	for _, arg := range out.CommandLine {
+		if strings.Contains(arg, value) {
+			return true
+		}
+	}
+	for _, file := range out.Files {
+		if strings.Contains(string(file.Contents), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidJSONFiles returns an invariant that checks that all provisioned files are valid JSON, and that the values of
+// the specified fields are present as strings in the decoded JSON.
+func ValidJSONFiles(fieldNames ...sdk.FieldName) ProvisionInvariant {
+	return func(fields map[sdk.FieldName]string, out sdk.ProvisionOutput) error {
+		var decodedStrings []string
+		for path, file := range out.Files {
+			var decoded any
+			if err := json.Unmarshal(file.Contents, &decoded); err != nil {
+				return fmt.Errorf("file %s is not valid JSON: %s", path, err)
+			}
+			decodedStrings = append(decodedStrings, jsonStrings(decoded)...)
+		}
+
+		return containsFieldValues(fields, fieldNames, decodedStrings, "JSON")
+	}
+}
+
+func jsonStrings(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var result []string
+		for _, v := range value {
+			result = append(result, jsonStrings(v)...)
+		}
+		return result
+	case map[string]any:
+		var result []string
+		for _, v := range value {
+			result = append(result, jsonStrings(v)...)
+		}
+		return result
+	}
+	return nil
+}
+
+// ValidINIFiles returns an invariant that checks that all provisioned files are valid INI, and that the values of
+// the specified fields are present as values in the parsed INI, e.g. not mangled by unescaped quotes or comment chars.
+func ValidINIFiles(fieldNames ...sdk.FieldName) ProvisionInvariant {
+	return func(fields map[sdk.FieldName]string, out sdk.ProvisionOutput) error {
+		var values []string
+		for path, file := range out.Files {
+			parsed, err := ini.Load(file.Contents)
+			if err != nil {
+				return fmt.Errorf("file %s is not valid INI: %s", path, err)
+			}
+
+			for _, section := range parsed.Sections() {
+				for _, key := range section.Keys() {
+					values = append(values, key.Value())
+				}
+			}
+		}
+
+		return containsFieldValues(fields, fieldNames, values, "INI")
+	}
+}
+
+func containsFieldValues(fields map[sdk.FieldName]string, fieldNames []sdk.FieldName, values []string, format string) error {
+	for _, fieldName := range fieldNames {
+		found := false
+		for _, value := range values {
+			if value == fields[fieldName] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("the value of field %q is not present in the parsed %s", fieldName, format)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package plugintest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+func TestProvisionerPropertiesHold(t *testing.T) {
+	credential := schema.CredentialType{
+		Fields: []schema.CredentialField{
+			{
+				Name:        "Token",
+				Composition: &schema.ValueComposition{Length: 40, Charset: schema.Charset{Lowercase: true, Symbols: true}},
+			},
+		},
+	}
+
+	jsonProvisioner := provision.TempFile(func(in sdk.ProvisionInput) ([]byte, error) {
+		return json.Marshal(map[string]string{"token": in.ItemFields["Token"]})
+	})
+
+	TestProvisionerProperties(t, credential, jsonProvisioner, map[string]PropertyCase{
+		"json file": {
+			Invariants: []ProvisionInvariant{ValidJSONFiles("Token")},
+		},
+	})
+
+	TestProvisionerProperties(t, credential, provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}), map[string]PropertyCase{
+		"env var": {
+			Invariants: []ProvisionInvariant{NoTruncation("Token")},
+		},
+	})
+}
+
+func TestINIInvariantCatchesUnescapedValues(t *testing.T) {
+	out := sdk.ProvisionOutput{
+		Files: map[string]sdk.OutputFile{
+			"/tmp/config": {Contents: []byte("[default]\ntoken = abc;def\n")},
+		},
+	}
+
+	err := ValidINIFiles("Token")(map[sdk.FieldName]string{"Token": "abc;def"}, out)
+	if err == nil {
+		t.Fatal("expected the unescaped comment char to be caught")
+	}
+}
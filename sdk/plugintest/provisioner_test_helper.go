@@ -3,7 +3,10 @@ package plugintest
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/1Password/shell-plugins/sdk"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +17,11 @@ import (
 func TestProvisioner(t *testing.T, provisioner sdk.Provisioner, cases map[string]ProvisionCase) {
 	t.Helper()
 
+	_, testFilename, _, ok := runtime.Caller(1)
+	if !ok {
+		t.Fatal()
+	}
+
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
 			t.Helper()
@@ -26,21 +34,22 @@ func TestProvisioner(t *testing.T, provisioner sdk.Provisioner, cases map[string
 				c.ExpectedOutput.Files = make(map[string]sdk.OutputFile)
 			}
 
-			ctx := context.Background()
+			in, out := runProvisioner(context.Background(), provisioner, c)
 
-			in := sdk.ProvisionInput{
-				ItemFields: c.ItemFields,
-				HomeDir:    "~",
-				TempDir:    "/tmp",
+			if c.OS == "windows" {
+				assertWindowsACLs(t, in, out)
 			}
 
-			out := sdk.ProvisionOutput{
-				Environment: make(map[string]string),
-				Files:       make(map[string]sdk.OutputFile),
-				CommandLine: c.CommandLine,
-			}
+			for path, goldenFilename := range c.GoldenFiles {
+				file, ok := out.Files[path]
+				if !ok {
+					t.Errorf("expected a file to be provisioned at %s", path)
+					continue
+				}
 
-			provisioner.Provision(ctx, in, &out)
+				assertGoldenFile(t, filepath.Dir(testFilename), goldenFilename, file.Contents)
+				c.ExpectedOutput.Files[path] = file
+			}
 
 			description := fmt.Sprintf("Provision: %s", name)
 			assert.Equal(t, c.ExpectedOutput, out, description)
@@ -48,6 +57,33 @@ func TestProvisioner(t *testing.T, provisioner sdk.Provisioner, cases map[string
 	}
 }
 
+// runProvisioner invokes the provisioner with the input of the case, and returns the input and the output.
+func runProvisioner(ctx context.Context, provisioner sdk.Provisioner, c ProvisionCase) (sdk.ProvisionInput, sdk.ProvisionOutput) {
+	in := sdk.ProvisionInput{
+		ItemFields: c.ItemFields,
+		HomeDir:    "~",
+		TempDir:    "/tmp",
+		Cache:      c.Cache,
+		Profile:    c.Profile,
+		Settings:   c.Settings,
+
+		Interactive:     c.Interactive,
+		PromptResponses: c.PromptResponses,
+	}
+	if !c.Now.IsZero() {
+		in.Clock = sdk.FixedClock(c.Now)
+	}
+
+	out := sdk.ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]sdk.OutputFile),
+		CommandLine: c.CommandLine,
+	}
+
+	provisioner.Provision(ctx, in, &out)
+	return in, out
+}
+
 type ProvisionCase struct {
 	// ItemFields can be used to populate the item fields to pass to the provisioner.
 	ItemFields map[sdk.FieldName]string
@@ -55,7 +91,35 @@ type ProvisionCase struct {
 	// CommandLine can be used to populate the command line to pass to the provisioner.
 	CommandLine []string
 
+	// Settings can be used to simulate the user preferences of the plugin.
+	Settings sdk.Settings
+
+	// Profile can be used to simulate the profile that the invocation targets, as selected by the profile hint.
+	Profile string
+
+	// Cache can be used to populate the cache state from previous runs. Cache operations are part of ExpectedOutput.
+	Cache sdk.CacheState
+
+	// Interactive can be used to simulate a terminal being attached, so that the provisioner can prompt for input.
+	Interactive bool
+
+	// PromptResponses can be used to simulate the user's responses to prompts, using the format: prompt ID -> response.
+	PromptResponses map[string]string
+
+	// OS can be set to "windows" to additionally assert that all provisioned files end up inside the user
+	// profile, which Windows restricts access to by default.
+	OS string
+
+	// Now can be used to run the provisioner at a fixed point in time, e.g. to test TOTP codes, token expiry,
+	// or the boundaries of it. Defaults to the wall clock.
+	Now time.Time
+
 	// ExpectedOutput can be used to set the exact expected provision output, which contains the
 	// environment, files, and command line.
 	ExpectedOutput sdk.ProvisionOutput
+
+	// GoldenFiles can be used to compare the contents of provisioned files with golden files in the "test-fixtures"
+	// dir, using the format: provisioned path -> golden filename. Files listed here don't have to be included in
+	// ExpectedOutput. Run the tests with `UPDATE_GOLDEN=1` to update the golden files.
+	GoldenFiles map[string]string
 }
@@ -0,0 +1,52 @@
+package plugintest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// expiringProvisioner provisions a token only while it has not expired yet.
+type expiringProvisioner struct {
+	expiresAt time.Time
+}
+
+func (p expiringProvisioner) Description() string {
+	return "Provision a token until it expires"
+}
+
+func (p expiringProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	if !in.Now().Before(p.expiresAt) {
+		out.AddError(errors.New("token expired"))
+		return
+	}
+	out.AddEnvVar("EXAMPLE_TOKEN", in.ItemFields["Token"])
+}
+
+func (p expiringProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	// Nothing to do here: environment variables get wiped automatically when the process exits.
+}
+
+func TestProvisionerAtFixedTime(t *testing.T) {
+	expiresAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	TestProvisioner(t, expiringProvisioner{expiresAt: expiresAt}, map[string]ProvisionCase{
+		"just before expiry": {
+			ItemFields: map[sdk.FieldName]string{"Token": "tkn_example"},
+			Now:        expiresAt.Add(-time.Nanosecond),
+			ExpectedOutput: sdk.ProvisionOutput{
+				Environment: map[string]string{"EXAMPLE_TOKEN": "tkn_example"},
+			},
+		},
+		"at expiry": {
+			ItemFields: map[sdk.FieldName]string{"Token": "tkn_example"},
+			Now:        expiresAt,
+			ExpectedOutput: sdk.ProvisionOutput{
+				Diagnostics: sdk.Diagnostics{Errors: []sdk.Error{{Message: "token expired"}}},
+			},
+		},
+	})
+}
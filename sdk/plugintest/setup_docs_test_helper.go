@@ -0,0 +1,134 @@
+package plugintest
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk/schema"
+)
+
+// TestSetupDocs asserts that the plugin has the setup metadata users need to get started, and that all documentation
+// and setup links are well-formed and reachable. Instead of hitting the network, reachability is checked against the
+// responses recorded in the specified fixture file in the "test-fixtures" dir in the plugin directory. Every line of
+// that file contains the recorded status code and the URL, for example:
+//
+//	# Recorded responses of the setup links
+//	200 https://console.hetzner.cloud
+//
+// Lines starting with # are ignored. Links that are missing from the fixture or that were recorded with a status
+// code other than 2xx or 3xx fail the test, as do recorded links that the plugin no longer uses.
+func TestSetupDocs(t *testing.T, plugin schema.Plugin, linksFixture string) {
+	t.Helper()
+
+	_, testFilename, _, ok := runtime.Caller(1)
+	if !ok {
+		t.Fatal()
+	}
+
+	recorded, err := parseRecordedLinks(loadFixture(t, filepath.Dir(testFilename), linksFixture))
+	if err != nil {
+		t.Fatalf("parsing %s: %s", linksFixture, err)
+	}
+
+	for _, problem := range setupDocsProblems(plugin, recorded) {
+		t.Error(problem)
+	}
+}
+
+// setupLink is a link used in the plugin, together with a description of where it is used.
+type setupLink struct {
+	description string
+	url         *url.URL
+}
+
+func setupLinks(plugin schema.Plugin) []setupLink {
+	var links []setupLink
+	if plugin.Platform.Homepage != nil {
+		links = append(links, setupLink{"platform homepage", plugin.Platform.Homepage})
+	}
+	for _, credential := range plugin.Credentials {
+		if credential.DocsURL != nil {
+			links = append(links, setupLink{fmt.Sprintf("docs URL of credential %q", credential.Name), credential.DocsURL})
+		}
+		if credential.ManagementURL != nil {
+			links = append(links, setupLink{fmt.Sprintf("management URL of credential %q", credential.Name), credential.ManagementURL})
+		}
+	}
+	for _, executable := range plugin.Executables {
+		if executable.DocsURL != nil {
+			links = append(links, setupLink{fmt.Sprintf("docs URL of executable %q", executable.Name), executable.DocsURL})
+		}
+	}
+	return links
+}
+
+func setupDocsProblems(plugin schema.Plugin, recorded map[string]int) []string {
+	var problems []string
+
+	if plugin.Platform.Name == "" {
+		problems = append(problems, "platform name is not set")
+	}
+	if plugin.Platform.Homepage == nil {
+		problems = append(problems, "platform homepage is not set")
+	}
+	for _, credential := range plugin.Credentials {
+		if credential.DocsURL == nil {
+			problems = append(problems, fmt.Sprintf("credential %q has no docs URL to point users to setup instructions", credential.Name))
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, link := range setupLinks(plugin) {
+		if link.url.Scheme != "https" || link.url.Host == "" {
+			problems = append(problems, fmt.Sprintf("%s is not a valid HTTPS URL: %s", link.description, link.url))
+			continue
+		}
+
+		key := link.url.String()
+		used[key] = true
+
+		status, ok := recorded[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s has no recorded response, add it to the links fixture: %s", link.description, key))
+			continue
+		}
+		if status < 200 || status >= 400 {
+			problems = append(problems, fmt.Sprintf("%s is broken, recorded status %d: %s", link.description, status, key))
+		}
+	}
+
+	for link := range recorded {
+		if !used[link] {
+			problems = append(problems, fmt.Sprintf("recorded link is no longer used by the plugin: %s", link))
+		}
+	}
+
+	return problems
+}
+
+func parseRecordedLinks(contents string) (map[string]int, error) {
+	recorded := make(map[string]int)
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a status code and a URL", i+1)
+		}
+
+		status, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid status code %q", i+1, fields[0])
+		}
+		recorded[fields[1]] = status
+	}
+	return recorded, nil
+}
@@ -0,0 +1,53 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func examplePluginWithLinks() schema.Plugin {
+	return schema.Plugin{
+		Name: "example",
+		Platform: schema.PlatformInfo{
+			Name:     "Example",
+			Homepage: sdk.URL("https://example.com"),
+		},
+		Credentials: []schema.CredentialType{
+			{
+				Name:          "API Token",
+				DocsURL:       sdk.URL("https://example.com/docs/tokens"),
+				ManagementURL: sdk.URL("https://example.com/settings/tokens"),
+			},
+		},
+	}
+}
+
+func TestSetupDocsWithRecordedLinks(t *testing.T) {
+	TestSetupDocs(t, examplePluginWithLinks(), "links.txt")
+}
+
+func TestSetupDocsProblems(t *testing.T) {
+	plugin := examplePluginWithLinks()
+	plugin.Credentials[0].DocsURL = nil
+	plugin.Credentials[0].ManagementURL = sdk.URL("http://example.com/settings/tokens")
+
+	problems := setupDocsProblems(plugin, map[string]int{
+		"https://example.com":         404,
+		"https://example.com/removed": 200,
+	})
+
+	assert.ElementsMatch(t, []string{
+		`credential "API Token" has no docs URL to point users to setup instructions`,
+		"platform homepage is broken, recorded status 404: https://example.com",
+		`management URL of credential "API Token" is not a valid HTTPS URL: http://example.com/settings/tokens`,
+		"recorded link is no longer used by the plugin: https://example.com/removed",
+	}, problems)
+}
+
+func TestParseRecordedLinks(t *testing.T) {
+	_, err := parseRecordedLinks("200 https://example.com\nnot-a-status https://example.com/docs")
+	assert.EqualError(t, err, `line 2: invalid status code "not-a-status"`)
+}
@@ -1,15 +1,39 @@
 package plugintest
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"text/template"
+
+	"github.com/1Password/shell-plugins/sdk"
 )
 
 // LoadFixture loads the test fixture file from the "test-fixtures" dir in the plugin directory.
 // It fails the test if the file can't be loaded.
-func LoadFixture(t *testing.T, filename string) string {
+func LoadFixture(t testing.TB, filename string) string {
+	t.Helper()
+
+	_, testFilename, _, ok := runtime.Caller(1)
+	if !ok {
+		t.Fatal()
+	}
+
+	return loadFixture(t, filepath.Dir(testFilename), filename)
+}
+
+// RenderFixture loads the test fixture template from the "test-fixtures" dir in the plugin directory and renders
+// it with the specified data, using the text/template syntax. This can be used in combination with ExampleItemFields
+// to create config files containing generated example secrets, instead of hand-crafting them. The `field` function
+// can be used to get a value from item fields. For example, with "config.yml.tmpl" containing `token: {{ field . "Token" }}`:
+//
+//	fields := plugintest.ExampleItemFields(APIToken())
+//	contents := plugintest.RenderFixture(t, "config.yml.tmpl", fields)
+//
+// It fails the test if the template can't be loaded or rendered.
+func RenderFixture(t testing.TB, filename string, data any) string {
 	t.Helper()
 
 	_, testFilename, _, ok := runtime.Caller(1)
@@ -17,7 +41,30 @@ func LoadFixture(t *testing.T, filename string) string {
 		t.Fatal()
 	}
 
-	fixturePath := filepath.Join(filepath.Dir(testFilename), "test-fixtures", filename)
+	tmpl, err := template.New(filename).Option("missingkey=error").Funcs(fixtureFuncs).Parse(loadFixture(t, filepath.Dir(testFilename), filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+	err = tmpl.Execute(&result, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result.String()
+}
+
+var fixtureFuncs = template.FuncMap{
+	"field": func(fields map[sdk.FieldName]string, name string) string {
+		return fields[sdk.FieldName(name)]
+	},
+}
+
+func loadFixture(t testing.TB, testDir string, filename string) string {
+	t.Helper()
+
+	fixturePath := filepath.Join(testDir, "test-fixtures", filename)
 	contents, err := os.ReadFile(fixturePath)
 	if err != nil {
 		t.Fatal(err)
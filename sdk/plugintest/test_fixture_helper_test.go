@@ -0,0 +1,31 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderFixture(t *testing.T) {
+	credential := schema.CredentialType{
+		Fields: []schema.CredentialField{
+			{
+				Name:        "Token",
+				Composition: &schema.ValueComposition{Length: 20, Prefix: "tkn_", Charset: schema.Charset{Digits: true}},
+			},
+		},
+	}
+
+	defaultFields := ExampleItemFields(credential)
+	workFields := map[sdk.FieldName]string{"Token": "tkn_work"}
+
+	contents := RenderFixture(t, "profiles.ini.tmpl", map[string]map[sdk.FieldName]string{
+		"Default": defaultFields,
+		"Work":    workFields,
+	})
+
+	expected := "[default]\ntoken = " + defaultFields["Token"] + "\n\n[work]\ntoken = tkn_work\n"
+	assert.Equal(t, expected, contents)
+}
@@ -0,0 +1,70 @@
+package plugintest
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// SimulatedWindowsUserProfile is the Windows path that the home dir "~" maps to in the simulated Windows filesystem.
+const SimulatedWindowsUserProfile = `C:\Users\plugintest`
+
+// WindowsPath converts a path in the simulated filesystem to the equivalent Windows path. For example,
+// "~/AppData/Roaming/example/config.json" becomes `C:\Users\plugintest\AppData\Roaming\example\config.json`.
+// This can be used to construct expected values for config files that contain absolute Windows paths.
+func WindowsPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		path = SimulatedWindowsUserProfile + strings.TrimPrefix(path, "~")
+	} else if strings.HasPrefix(path, "/") {
+		path = "C:" + path
+	}
+	return strings.ReplaceAll(path, "/", `\`)
+}
+
+var windowsEnvVarReference = regexp.MustCompile(`%([A-Za-z_][A-Za-z0-9_]*)%`)
+
+// fromWindowsPath converts a Windows path to a path in the simulated filesystem, expanding references to the
+// environment variables that Windows sets by default, like %APPDATA% and %USERPROFILE%. Paths that are not
+// Windows paths are returned as is.
+func fromWindowsPath(path string) string {
+	environment := simulatedOSEnvironment("windows")
+	path = windowsEnvVarReference.ReplaceAllStringFunc(path, func(reference string) string {
+		name := strings.ToUpper(strings.Trim(reference, "%"))
+		if value, ok := environment[name]; ok {
+			return value
+		}
+		return reference
+	})
+
+	if strings.HasPrefix(strings.ToLower(path), strings.ToLower(SimulatedWindowsUserProfile)) {
+		path = "~" + path[len(SimulatedWindowsUserProfile):]
+	} else if len(path) > 1 && path[1] == ':' {
+		path = path[2:]
+	}
+	return strings.ReplaceAll(path, `\`, "/")
+}
+
+// assertWindowsACLs asserts that all the provisioned files are inside the user profile, which is where Windows
+// restricts access to the current user by default. Files outside of it, like in C:\ProgramData, could be readable
+// by other users on the machine.
+func assertWindowsACLs(t *testing.T, in sdk.ProvisionInput, out sdk.ProvisionOutput) {
+	t.Helper()
+
+	for path := range out.Files {
+		if !isInDir(path, in.HomeDir) && !isInDir(path, in.TempDir) {
+			t.Errorf("file %s is provisioned outside of the user profile, so on Windows it may be readable by other users", WindowsPath(path))
+		}
+	}
+}
+
+func isInDir(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
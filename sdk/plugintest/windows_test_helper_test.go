@@ -0,0 +1,54 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowsPath(t *testing.T) {
+	assert.Equal(t, `C:\Users\plugintest\AppData\Roaming\example\config.json`, WindowsPath("~/AppData/Roaming/example/config.json"))
+	assert.Equal(t, `C:\ProgramData\example`, WindowsPath("/ProgramData/example"))
+}
+
+func TestFromWindowsPath(t *testing.T) {
+	for windowsPath, expected := range map[string]string{
+		`%APPDATA%\example\config.json`:      "~/AppData/Roaming/example/config.json",
+		`%LocalAppData%\example`:             "~/AppData/Local/example",
+		`C:\Users\plugintest\.example\token`: "~/.example/token",
+		`C:\ProgramData\example`:             "/ProgramData/example",
+		`%UNKNOWN%\example`:                  "%UNKNOWN%/example",
+		"~/.example/token":                   "~/.example/token",
+	} {
+		assert.Equal(t, expected, fromWindowsPath(windowsPath), windowsPath)
+	}
+}
+
+func TestProvisionerOnWindows(t *testing.T) {
+	TestProvisioner(t, provision.TempFile(provision.FieldAsFile("Token"), provision.Filename("token")), map[string]ProvisionCase{
+		"temp file": {
+			OS:         "windows",
+			ItemFields: map[sdk.FieldName]string{"Token": "tkn_example"},
+			ExpectedOutput: sdk.ProvisionOutput{
+				Files: map[string]sdk.OutputFile{
+					"/tmp/token": {Contents: []byte("tkn_example")},
+				},
+			},
+		},
+	})
+}
+
+func TestWindowsACLs(t *testing.T) {
+	in := sdk.ProvisionInput{HomeDir: "~", TempDir: "/tmp"}
+	out := sdk.ProvisionOutput{
+		Files: map[string]sdk.OutputFile{
+			"/ProgramData/example/token": {Contents: []byte("tkn_example")},
+		},
+	}
+
+	inner := &testing.T{}
+	assertWindowsACLs(inner, in, out)
+	assert.True(t, inner.Failed(), "expected file outside of the user profile to be reported")
+}
@@ -0,0 +1,50 @@
+package sdk
+
+// Prompt describes input that a provisioner needs from the user at exec time, such as an MFA code, the passphrase
+// of a key, or which profile to use. Prompts are shown by the 1Password CLI.
+type Prompt struct {
+	// ID identifies the prompt, so the response can be looked up in PromptResponses.
+	ID string
+
+	// Message is shown to the user, e.g. "Enter the MFA code for arn:aws:iam::123456789012:mfa/user".
+	Message string
+
+	// Sensitive indicates that the response should not be echoed while the user types it.
+	Sensitive bool
+
+	// (Optional) Options lists the valid responses. If set, the user picks one of them.
+	Options []string
+
+	// (Optional) Default is used as the response when not running interactively. If not set, provisioning fails
+	// when not running interactively.
+	Default string
+}
+
+// Prompt returns the user's response to the specified prompt. If the response is known, ok is true.
+//
+// Since provisioners run in a separate process, prompting works in rounds: if the response is not known yet,
+// the prompt is added to the output and ok is false. The provisioner should then return without provisioning
+// anything. The 1Password CLI shows the prompts to the user and calls Provision again, with the responses set
+// in PromptResponses. When not running interactively, the default of the prompt is used instead, or an error is
+// added to the output if the prompt has no default.
+func (in *ProvisionInput) Prompt(out *ProvisionOutput, prompt Prompt) (response string, ok bool) {
+	if response, ok := in.PromptResponses[prompt.ID]; ok {
+		return response, true
+	}
+
+	if !in.Interactive {
+		if prompt.Default != "" {
+			return prompt.Default, true
+		}
+		out.AddError(NeedsInteractiveError{Input: prompt.Message})
+		return "", false
+	}
+
+	out.Prompts = append(out.Prompts, prompt)
+	return "", false
+}
+
+// HasPendingPrompts returns whether the provisioner is waiting for the user to respond to one or more prompts.
+func (out *ProvisionOutput) HasPendingPrompts() bool {
+	return len(out.Prompts) > 0
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt(t *testing.T) {
+	mfaCode := Prompt{ID: "mfa-code", Message: "Enter MFA code", Sensitive: true}
+	profile := Prompt{ID: "profile", Message: "Select profile", Options: []string{"dev", "prod"}, Default: "dev"}
+
+	t.Run("interactive without response", func(t *testing.T) {
+		in := ProvisionInput{Interactive: true}
+		out := ProvisionOutput{}
+
+		_, ok := in.Prompt(&out, mfaCode)
+		assert.False(t, ok)
+		assert.True(t, out.HasPendingPrompts())
+		assert.Equal(t, []Prompt{mfaCode}, out.Prompts)
+	})
+
+	t.Run("interactive with response", func(t *testing.T) {
+		in := ProvisionInput{Interactive: true, PromptResponses: map[string]string{"mfa-code": "123456"}}
+		out := ProvisionOutput{}
+
+		response, ok := in.Prompt(&out, mfaCode)
+		assert.True(t, ok)
+		assert.Equal(t, "123456", response)
+		assert.False(t, out.HasPendingPrompts())
+	})
+
+	t.Run("non-interactive with default", func(t *testing.T) {
+		in := ProvisionInput{}
+		out := ProvisionOutput{}
+
+		response, ok := in.Prompt(&out, profile)
+		assert.True(t, ok)
+		assert.Equal(t, "dev", response)
+	})
+
+	t.Run("non-interactive without default", func(t *testing.T) {
+		in := ProvisionInput{}
+		out := ProvisionOutput{}
+
+		_, ok := in.Prompt(&out, mfaCode)
+		assert.False(t, ok)
+		assert.False(t, out.HasPendingPrompts())
+		assert.Equal(t, []Error{{Message: "Enter MFA code: input is required, but the command is not running interactively", Code: ErrorCodeNeedsInteractive}}, out.Diagnostics.Errors)
+	})
+}
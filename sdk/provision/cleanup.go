@@ -0,0 +1,54 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// CleanupStep cleans up a single thing that was set up during provisioning, like stopping an agent or removing
+// a key from a keychain.
+type CleanupStep func(ctx context.Context) error
+
+// Cleanup runs cleanup steps on a best-effort basis, which can be used to implement Deprovision for provisioners
+// that set up more than files and environment variables. Steps run in the reverse order in which they were added,
+// like deferred functions, so that things are torn down in the opposite order of setting them up.
+type Cleanup struct {
+	steps []namedCleanupStep
+}
+
+type namedCleanupStep struct {
+	description string
+	step        CleanupStep
+}
+
+// Add adds a cleanup step, described by the specified description, e.g. "stopping SSH agent".
+func (c *Cleanup) Add(description string, step CleanupStep) {
+	c.steps = append(c.steps, namedCleanupStep{description: description, step: step})
+}
+
+// Run runs all cleanup steps. A failing step does not prevent the remaining steps from running, but is reported
+// as an error in the output. Once the context is done, for example because DeprovisionTimeout passed, the
+// remaining steps are skipped, and reported as such.
+func (c *Cleanup) Run(ctx context.Context, out *sdk.DeprovisionOutput) {
+	for i := len(c.steps) - 1; i >= 0; i-- {
+		step := c.steps[i]
+		if ctx.Err() != nil {
+			out.AddError(fmt.Errorf("%s: skipped, since deprovisioning %s", step.description, contextErrorDescription(ctx)))
+			continue
+		}
+
+		if err := step.step(ctx); err != nil {
+			out.AddError(fmt.Errorf("%s: %w", step.description, err))
+		}
+	}
+	c.steps = nil
+}
+
+func contextErrorDescription(ctx context.Context) string {
+	if ctx.Err() == context.DeadlineExceeded {
+		return "timed out"
+	}
+	return "was canceled"
+}
@@ -0,0 +1,45 @@
+package provision
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanupRunsStepsInReverseOrder(t *testing.T) {
+	var ran []string
+	cleanup := Cleanup{}
+	cleanup.Add("removing socket", func(ctx context.Context) error {
+		ran = append(ran, "socket")
+		return nil
+	})
+	cleanup.Add("stopping agent", func(ctx context.Context) error {
+		ran = append(ran, "agent")
+		return errors.New("agent not responding")
+	})
+
+	out := sdk.DeprovisionOutput{}
+	cleanup.Run(context.Background(), &out)
+
+	assert.Equal(t, []string{"agent", "socket"}, ran, "a failing step should not prevent the remaining steps from running")
+	assert.Equal(t, []sdk.Error{{Message: "stopping agent: agent not responding"}}, out.Diagnostics.Errors)
+}
+
+func TestCleanupSkipsStepsAfterTimeout(t *testing.T) {
+	cleanup := Cleanup{}
+	cleanup.Add("stopping agent", func(ctx context.Context) error {
+		t.Fatal("step should have been skipped")
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	out := sdk.DeprovisionOutput{}
+	cleanup.Run(ctx, &out)
+
+	assert.Equal(t, []sdk.Error{{Message: "stopping agent: skipped, since deprovisioning timed out"}}, out.Diagnostics.Errors)
+}
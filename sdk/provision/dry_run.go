@@ -0,0 +1,117 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// masked is what values of item fields get replaced with in a dry-run report.
+const masked = "****"
+
+// DryRunReport describes what a provisioner would provision, with the values of all item fields masked.
+type DryRunReport struct {
+	// Environment contains the environment variables that would be set, using the format: name -> masked value.
+	Environment map[string]string
+
+	// Files contains the files that would be written, using the format: path -> size in bytes.
+	Files map[string]int
+
+	// CommandLine is the command line that would be executed, with masked args.
+	CommandLine []string
+
+	// Errors contains the errors that provisioning would report.
+	Errors []string
+}
+
+// DryRun runs the provisioner in dry-run mode and reports what it would provision, masking the values of the item
+// fields wherever they appear. Non-sensitive values that the provisioner adds itself, such as the paths of
+// provisioned files, are left as is, so that the report can be used to debug misconfigured plugins.
+func DryRun(ctx context.Context, provisioner sdk.Provisioner, in sdk.ProvisionInput, commandLine []string) DryRunReport {
+	in.DryRun = true
+	out := sdk.ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]sdk.OutputFile),
+		CommandLine: append([]string{}, commandLine...),
+	}
+	provisioner.Provision(ctx, in, &out)
+
+	var secrets []string
+	for _, value := range in.ItemFields {
+		if value != "" {
+			secrets = append(secrets, value)
+		}
+	}
+	// Mask longer values first, so that a value containing another value gets masked as a whole.
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	mask := func(value string) string {
+		for _, secret := range secrets {
+			value = strings.ReplaceAll(value, secret, masked)
+		}
+		return value
+	}
+
+	report := DryRunReport{
+		Environment: make(map[string]string),
+		Files:       make(map[string]int),
+	}
+	for name, value := range out.Environment {
+		report.Environment[name] = mask(value)
+	}
+	for path, file := range out.Files {
+		report.Files[mask(path)] = len(file.Contents)
+	}
+	for _, arg := range out.CommandLine {
+		report.CommandLine = append(report.CommandLine, mask(arg))
+	}
+	for _, err := range out.Diagnostics.Errors {
+		report.Errors = append(report.Errors, mask(err.Message))
+	}
+	return report
+}
+
+// String renders the report in a human-readable format.
+func (r DryRunReport) String() string {
+	var b strings.Builder
+
+	if len(r.Environment) > 0 {
+		b.WriteString("Environment variables:\n")
+		for _, name := range sortedKeys(r.Environment) {
+			fmt.Fprintf(&b, "  %s=%s\n", name, r.Environment[name])
+		}
+	}
+
+	if len(r.Files) > 0 {
+		b.WriteString("Files:\n")
+		for _, path := range sortedKeys(r.Files) {
+			fmt.Fprintf(&b, "  %s (%d bytes)\n", path, r.Files[path])
+		}
+	}
+
+	if len(r.CommandLine) > 0 {
+		fmt.Fprintf(&b, "Command line:\n  %s\n", strings.Join(r.CommandLine, " "))
+	}
+
+	if len(r.Errors) > 0 {
+		b.WriteString("Errors:\n")
+		for _, err := range r.Errors {
+			fmt.Fprintf(&b, "  %s\n", err)
+		}
+	}
+
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
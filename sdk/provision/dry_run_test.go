@@ -0,0 +1,31 @@
+package provision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	provisioner := TempFile(FieldAsFile("Token"),
+		Filename("token"),
+		SetPathAsEnvVar("EXAMPLE_TOKEN_FILE"),
+		AddArgs("--token-file", "{{ .Path }}"),
+	)
+	in := sdk.ProvisionInput{
+		TempDir:    "/tmp",
+		ItemFields: map[sdk.FieldName]string{"Token": "tkn_example"},
+	}
+
+	report := DryRun(context.Background(), provisioner, in, []string{"example", "deploy"})
+	assert.Equal(t, DryRunReport{
+		Environment: map[string]string{"EXAMPLE_TOKEN_FILE": "/tmp/token"},
+		Files:       map[string]int{"/tmp/token": len("tkn_example")},
+		CommandLine: []string{"example", "deploy", "--token-file", "/tmp/token"},
+	}, report)
+
+	envReport := DryRun(context.Background(), EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}), in, nil)
+	assert.Equal(t, "Environment variables:\n  EXAMPLE_TOKEN=****\n", envReport.String())
+}
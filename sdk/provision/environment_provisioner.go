@@ -0,0 +1,77 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// EnvironmentProvisioner delegates provisioning to a different provisioner depending on the environment
+// that was selected for the run.
+type EnvironmentProvisioner struct {
+	sdk.Provisioner
+
+	Provisioners map[sdk.Environment]sdk.Provisioner
+}
+
+// PerEnvironment creates an EnvironmentProvisioner that uses the provisioner mapped to the selected environment,
+// e.g. to provision a live key in production and a test key in development. If any of the provisioners has exec
+// hooks, those get called for the selected environment as well.
+func PerEnvironment(provisioners map[sdk.Environment]sdk.Provisioner) sdk.Provisioner {
+	p := EnvironmentProvisioner{
+		Provisioners: provisioners,
+	}
+	for _, provisioner := range provisioners {
+		_, hasPreExec := provisioner.(sdk.PreExecHook)
+		_, hasPostExec := provisioner.(sdk.PostExecHook)
+		if hasPreExec || hasPostExec {
+			return sdk.WithExecHooksOf(p, environmentExecHooks{p})
+		}
+	}
+	return p
+}
+
+func (p EnvironmentProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, out *sdk.ProvisionOutput) {
+	provisioner, ok := p.Provisioners[in.Environment]
+	if !ok {
+		out.AddError(fmt.Errorf("no provisioner defined for environment '%s'", in.Environment))
+		return
+	}
+	provisioner.Provision(ctx, in, out)
+}
+
+func (p EnvironmentProvisioner) Deprovision(ctx context.Context, in sdk.DeprovisionInput, out *sdk.DeprovisionOutput) {
+	if provisioner, ok := p.Provisioners[in.Environment]; ok {
+		provisioner.Deprovision(ctx, in, out)
+	}
+}
+
+func (p EnvironmentProvisioner) Description() string {
+	var envs []string
+	for env := range p.Provisioners {
+		envs = append(envs, env.String())
+	}
+	sort.Strings(envs)
+
+	return fmt.Sprintf("Provision per environment: %s", strings.Join(envs, ", "))
+}
+
+// environmentExecHooks forwards the exec hooks to the provisioner of the environment selected for the run.
+type environmentExecHooks struct {
+	EnvironmentProvisioner
+}
+
+func (h environmentExecHooks) PreExec(ctx context.Context, in sdk.PreExecInput, out *sdk.PreExecOutput) {
+	if hook, ok := h.Provisioners[in.Environment].(sdk.PreExecHook); ok {
+		hook.PreExec(ctx, in, out)
+	}
+}
+
+func (h environmentExecHooks) PostExec(ctx context.Context, in sdk.PostExecInput, out *sdk.PostExecOutput) {
+	if hook, ok := h.Provisioners[in.Environment].(sdk.PostExecHook); ok {
+		hook.PostExec(ctx, in, out)
+	}
+}
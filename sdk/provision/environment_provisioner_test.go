@@ -0,0 +1,57 @@
+package provision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerEnvironmentKeepsExecHooks(t *testing.T) {
+	var preExecs, postExecs []sdk.Environment
+	hooked := func(env sdk.Environment) sdk.Provisioner {
+		return WithHooks(EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}),
+			func(ctx context.Context, in sdk.PreExecInput) error {
+				preExecs = append(preExecs, env)
+				return nil
+			},
+			func(ctx context.Context, in sdk.PostExecInput) error {
+				postExecs = append(postExecs, env)
+				return nil
+			},
+		)
+	}
+	provisioner := PerEnvironment(map[sdk.Environment]sdk.Provisioner{
+		sdk.EnvironmentProduction:  hooked(sdk.EnvironmentProduction),
+		sdk.EnvironmentDevelopment: hooked(sdk.EnvironmentDevelopment),
+	})
+
+	preExec, ok := provisioner.(sdk.PreExecHook)
+	assert.True(t, ok, "expected provisioner to implement the pre-exec hook")
+	postExec, ok := provisioner.(sdk.PostExecHook)
+	assert.True(t, ok, "expected provisioner to implement the post-exec hook")
+
+	preExec.PreExec(context.Background(), sdk.PreExecInput{Environment: sdk.EnvironmentDevelopment}, &sdk.PreExecOutput{})
+	postExec.PostExec(context.Background(), sdk.PostExecInput{Environment: sdk.EnvironmentDevelopment}, &sdk.PostExecOutput{})
+	assert.Equal(t, []sdk.Environment{sdk.EnvironmentDevelopment}, preExecs)
+	assert.Equal(t, []sdk.Environment{sdk.EnvironmentDevelopment}, postExecs)
+
+	out := sdk.ProvisionOutput{Environment: make(map[string]string)}
+	provisioner.Provision(context.Background(), sdk.ProvisionInput{
+		Environment: sdk.EnvironmentProduction,
+		ItemFields:  map[sdk.FieldName]string{"Token": "tkn_example"},
+	}, &out)
+	assert.Equal(t, map[string]string{"EXAMPLE_TOKEN": "tkn_example"}, out.Environment)
+}
+
+func TestPerEnvironmentWithoutExecHooks(t *testing.T) {
+	provisioner := PerEnvironment(map[sdk.Environment]sdk.Provisioner{
+		sdk.EnvironmentProduction: EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}),
+	})
+
+	_, ok := provisioner.(sdk.PreExecHook)
+	assert.False(t, ok)
+	_, ok = provisioner.(sdk.PostExecHook)
+	assert.False(t, ok)
+}
@@ -32,7 +32,7 @@ func FieldAsFile(fieldName sdk.FieldName) ItemToFileContents {
 		if value, ok := in.ItemFields[fieldName]; ok {
 			return []byte(value), nil
 		} else {
-			return nil, fmt.Errorf("no value present in the item for field '%s'", fieldName)
+			return nil, sdk.FieldMissingError{Field: fieldName}
 		}
 	})
 }
@@ -130,7 +130,7 @@ func (p FileProvisioner) Provision(ctx context.Context, in sdk.ProvisionInput, o
 	if p.outdirEnvVar != "" {
 		// Populate the specified environment variable with the output dir.
 		dir := filepath.Dir(outpath)
-		out.AddEnvVar(p.outpathEnvVar, dir)
+		out.AddEnvVar(p.outdirEnvVar, dir)
 	}
 
 	// Add args to specify the output path.
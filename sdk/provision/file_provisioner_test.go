@@ -0,0 +1,31 @@
+package provision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTempFileSetsOutputDirAsEnvVar(t *testing.T) {
+	provisioner := TempFile(FieldAsFile("Config"),
+		Filename("config.json"),
+		SetPathAsEnvVar("EXAMPLE_CONFIG_FILE"),
+		SetOutputDirAsEnvVar("EXAMPLE_CONFIG_DIR"),
+	)
+
+	out := sdk.ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]sdk.OutputFile),
+	}
+	provisioner.Provision(context.Background(), sdk.ProvisionInput{
+		TempDir:    "/tmp",
+		ItemFields: map[sdk.FieldName]string{"Config": `{"token":"tkn_example"}`},
+	}, &out)
+
+	assert.Equal(t, map[string]string{
+		"EXAMPLE_CONFIG_FILE": "/tmp/config.json",
+		"EXAMPLE_CONFIG_DIR":  "/tmp",
+	}, out.Environment)
+}
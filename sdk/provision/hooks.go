@@ -0,0 +1,49 @@
+package provision
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// PreExecFunc runs right before the executable starts. It can return an error to prevent the executable from running.
+type PreExecFunc func(ctx context.Context, in sdk.PreExecInput) error
+
+// PostExecFunc runs right after the executable exits.
+type PostExecFunc func(ctx context.Context, in sdk.PostExecInput) error
+
+// WithHooks returns a provisioner that provisions using the specified provisioner, and that additionally runs the
+// specified pre-exec and post-exec hooks. Either hook can be nil.
+func WithHooks(provisioner sdk.Provisioner, preExec PreExecFunc, postExec PostExecFunc) sdk.Provisioner {
+	return HookedProvisioner{
+		Provisioner: provisioner,
+		preExec:     preExec,
+		postExec:    postExec,
+	}
+}
+
+// HookedProvisioner is a provisioner with pre-exec and post-exec hooks.
+type HookedProvisioner struct {
+	sdk.Provisioner
+
+	preExec  PreExecFunc
+	postExec PostExecFunc
+}
+
+func (p HookedProvisioner) PreExec(ctx context.Context, in sdk.PreExecInput, out *sdk.PreExecOutput) {
+	if p.preExec == nil {
+		return
+	}
+	if err := p.preExec(ctx, in); err != nil {
+		out.AddError(err)
+	}
+}
+
+func (p HookedProvisioner) PostExec(ctx context.Context, in sdk.PostExecInput, out *sdk.PostExecOutput) {
+	if p.postExec == nil {
+		return
+	}
+	if err := p.postExec(ctx, in); err != nil {
+		out.AddError(err)
+	}
+}
@@ -0,0 +1,38 @@
+package provision
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHooks(t *testing.T) {
+	var revoked bool
+	provisioner := WithHooks(EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"}),
+		func(ctx context.Context, in sdk.PreExecInput) error {
+			if len(in.CommandLine) > 1 && in.CommandLine[1] == "forbidden" {
+				return errors.New("command is not allowed")
+			}
+			return nil
+		},
+		func(ctx context.Context, in sdk.PostExecInput) error {
+			revoked = in.ExitCode == 0
+			return nil
+		},
+	)
+
+	preExec, ok := provisioner.(sdk.PreExecHook)
+	assert.True(t, ok, "expected provisioner to implement the pre-exec hook")
+	postExec, ok := provisioner.(sdk.PostExecHook)
+	assert.True(t, ok, "expected provisioner to implement the post-exec hook")
+
+	preOut := sdk.PreExecOutput{}
+	preExec.PreExec(context.Background(), sdk.PreExecInput{CommandLine: []string{"example", "forbidden"}}, &preOut)
+	assert.Equal(t, []sdk.Error{{Message: "command is not allowed"}}, preOut.Diagnostics.Errors)
+
+	postExec.PostExec(context.Background(), sdk.PostExecInput{ExitCode: 0}, &sdk.PostExecOutput{})
+	assert.True(t, revoked)
+}
@@ -19,9 +19,37 @@ type Provisioner interface {
 
 	// Deprovision gets called after the plugin's executable exits, so that the plugin can clean up and
 	// wipe any sensitive material created in the provision phase.
+	//
+	// Deprovision is called whenever Provision was called, also when provisioning failed, was canceled, or when
+	// the executable got killed. DeprovisionInput.Reason tells which of these happened. It always gets a fresh
+	// context, which is canceled after DeprovisionTimeout. When the context is done, Deprovision should stop
+	// waiting on anything, e.g. for an agent to shut down gracefully, and return as soon as possible. Files in
+	// ProvisionOutput.Files are deleted by the 1Password CLI regardless.
 	Deprovision(ctx context.Context, input DeprovisionInput, output *DeprovisionOutput)
 }
 
+// DeprovisionTimeout is how long Deprovision gets to clean up before its context is canceled.
+const DeprovisionTimeout = 10 * time.Second
+
+// DeprovisionReason describes why Deprovision is called.
+type DeprovisionReason string
+
+const (
+	// DeprovisionReasonExited means that the executable ran and exited by itself, with any exit code.
+	DeprovisionReasonExited DeprovisionReason = "exited"
+
+	// DeprovisionReasonProvisionFailed means that provisioning reported errors, so the executable never ran.
+	DeprovisionReasonProvisionFailed DeprovisionReason = "provision failed"
+
+	// DeprovisionReasonInterrupted means that the run was canceled, e.g. by Ctrl+C, either while provisioning
+	// or while the executable was running.
+	DeprovisionReasonInterrupted DeprovisionReason = "interrupted"
+
+	// DeprovisionReasonKilled means that the executable was killed, e.g. by SIGKILL, so it had no chance to clean
+	// up after itself.
+	DeprovisionReasonKilled DeprovisionReason = "killed"
+)
+
 // ProvisionInput contains info that provisioners can use to provision credentials.
 type ProvisionInput struct {
 	// HomeDir is the path to current user's home directory.
@@ -31,7 +59,9 @@ type ProvisionInput struct {
 	// This directory will automatically be deleted after the executable exits.
 	TempDir string
 
-	// DryRun can be used to opt out
+	// DryRun indicates that the run only reports what would be provisioned, e.g. to show users what a plugin
+	// injects. Provisioners should skip side effects that go beyond the provision output when it's set, such as
+	// starting agents or exchanging tokens, and provision placeholder values instead.
 	DryRun bool
 
 	// Cache can contain data that got added in the provision step from previous runs for this credential.
@@ -39,13 +69,43 @@ type ProvisionInput struct {
 
 	// ItemFields contains the field names and their corresponding (sensitive) values.
 	ItemFields map[FieldName]string
+
+	// Environment is the environment selected for this run, if the credential type supports multiple environments.
+	Environment Environment
+
+	// Settings contains the user preferences of the plugin, with defaults applied for settings that the user
+	// didn't configure.
+	Settings Settings
+
+	// Profile is the profile, account, or context that the invocation targets, as extracted using the ProfileHint
+	// of the executable. Empty if the executable has no profile hint, or if no profile was selected.
+	Profile string
+
+	// Clock can be used to override the current time, which is mostly useful in tests. Provisioners should use
+	// Now() instead of time.Now(), so that they respect it.
+	Clock Clock
+
+	// Interactive indicates whether the user can be prompted for input, i.e. whether a terminal is attached.
+	Interactive bool
+
+	// PromptResponses contains the responses of the user to the prompts from a previous round of provisioning,
+	// using the format: prompt ID -> response. See Prompt for more info.
+	PromptResponses map[string]string
+
+	// HostCapabilities lists the capabilities that the 1Password CLI hosting the plugin supports.
+	HostCapabilities Capabilities
 }
 
 // DeprovisionInput contains info that provisioners can use to deprovision credentials.
 type DeprovisionInput struct {
-	HomeDir string
-	TempDir string
-	DryRun  bool
+	HomeDir     string
+	TempDir     string
+	DryRun      bool
+	Environment Environment
+	Profile     string
+
+	// Reason describes why Deprovision is called.
+	Reason DeprovisionReason
 }
 
 // ProvisionOutput contains the sensitive values that the Provisioner outputs.
@@ -67,6 +127,16 @@ type ProvisionOutput struct {
 	// data from previous runs, use Cache on ProvisionInput.
 	Cache CacheOperations
 
+	// Redactions contains the values that should be masked in the output of the executable and in errors. The
+	// values of the item fields marked as secret in the credential schema are registered before Provision is called.
+	// Provisioners that derive other sensitive values from them, such as exchanged session tokens, have to
+	// register those with AddRedaction.
+	Redactions Redactions
+
+	// Prompts contains the prompts that the user has to respond to before provisioning can complete.
+	// Use Prompt on ProvisionInput to add prompts.
+	Prompts []Prompt
+
 	// Diagnostics can be used to report errors.
 	Diagnostics Diagnostics
 }
@@ -114,6 +184,11 @@ func (out *ProvisionOutput) AddSecretFile(path string, contents []byte) {
 	})
 }
 
+// AddRedaction registers values that should be masked in the output of the executable and in errors.
+func (out *ProvisionOutput) AddRedaction(values ...string) {
+	out.Redactions.Add(values...)
+}
+
 // AddNonSecretFile can be used to add a file that does not contain secrets to the provision output.
 func (out *ProvisionOutput) AddNonSecretFile(path string, contents []byte) {
 	out.AddFile(path, OutputFile{
@@ -127,9 +202,28 @@ func (out *ProvisionOutput) AddFile(path string, file OutputFile) {
 }
 
 // AddError can be used to report an error to the provision output. If the provision output contains one
-// or more errors, provisioning is considered failed.
+// or more errors, provisioning is considered failed. Registered redactions are masked in the error message.
 func (out *ProvisionOutput) AddError(err error) {
-	out.Diagnostics.Errors = append(out.Diagnostics.Errors, Error{err.Error()})
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, out.redactedError(err))
+}
+
+func (out *ProvisionOutput) redactedError(err error) Error {
+	e := newError(err)
+	e.Message = out.Redactions.Redact(e.Message)
+	return e
+}
+
+// AddError can be used to report an error to the deprovision output.
+func (out *DeprovisionOutput) AddError(err error) {
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, newError(err))
+}
+
+// Now returns the current time, according to the Clock if one is set, or the wall clock otherwise.
+func (in *ProvisionInput) Now() time.Time {
+	if in.Clock != nil {
+		return in.Clock.Now()
+	}
+	return time.Now()
 }
 
 // FromHomeDir returns a path with the user's home directory prepended.
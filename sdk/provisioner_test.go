@@ -122,3 +122,12 @@ func TestCacheOperationsPutStruct(t *testing.T) {
 
 	assert.Equal(t, structData, structResult)
 }
+
+func TestProvisionInputNow(t *testing.T) {
+	in := ProvisionInput{}
+	assert.WithinDuration(t, time.Now(), in.Now(), time.Minute)
+
+	fixed := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	in.Clock = FixedClock(fixed)
+	assert.Equal(t, fixed, in.Now())
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+)
+
+// MinRedactionLength is the minimum length of values that get registered for redaction. Shorter values, like
+// "1" or "eu", would mask too much unrelated output to be useful.
+const MinRedactionLength = 4
+
+// redacted is what registered values get replaced with.
+const redacted = "[REDACTED]"
+
+// Redactions is the set of values that should be masked wherever they show up, such as in the output of the
+// executable, in errors, and in log entries.
+type Redactions []string
+
+// Add registers the specified values for redaction. Empty values and values shorter than MinRedactionLength
+// are ignored, as are values that are already registered.
+func (r *Redactions) Add(values ...string) {
+	for _, value := range values {
+		if len(value) < MinRedactionLength || r.contains(value) {
+			continue
+		}
+		*r = append(*r, value)
+	}
+}
+
+// Redact replaces all occurrences of the registered values in the specified text. Longer values are replaced
+// first, so that a value that contains another value gets redacted as a whole.
+func (r Redactions) Redact(text string) string {
+	sorted := append(Redactions{}, r...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	for _, value := range sorted {
+		text = strings.ReplaceAll(text, value, redacted)
+	}
+	return text
+}
+
+func (r Redactions) contains(value string) bool {
+	for _, existing := range r {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionOutputRegistersExplicitRedactionsOnly(t *testing.T) {
+	out := ProvisionOutput{
+		Environment: make(map[string]string),
+		Files:       make(map[string]OutputFile),
+	}
+
+	out.AddEnvVar("EXAMPLE_HOST", "api.example.com")
+	out.AddArgs("--region", "eu-west-1", "deploy")
+	out.AddSecretFile("/tmp/credentials", []byte("token = tkn_example"))
+	out.AddRedaction("tkn_example", "session_example")
+
+	assert.Equal(t, Redactions{"tkn_example", "session_example"}, out.Redactions)
+
+	out.AddError(errors.New("request to api.example.com with tkn_example failed"))
+	assert.Equal(t, []Error{{Message: "request to api.example.com with [REDACTED] failed"}}, out.Diagnostics.Errors)
+}
+
+func TestRedactionsRedactLongestFirst(t *testing.T) {
+	var redactions Redactions
+	redactions.Add("abcd", "abcdefgh")
+
+	assert.Equal(t, "token [REDACTED], prefix [REDACTED]", redactions.Redact("token abcdefgh, prefix abcd"))
+}
@@ -0,0 +1,38 @@
+package rotation
+
+import (
+	"context"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// MintFunc creates a new credential at the provider and returns the fields that changed.
+type MintFunc func(ctx context.Context, in sdk.RotationInput) (map[sdk.FieldName]string, error)
+
+// RevokeFunc revokes the credential with the specified fields at the provider.
+type RevokeFunc func(ctx context.Context, in sdk.RevocationInput) error
+
+// Funcs returns a rotator that mints and revokes credentials using the specified functions.
+func Funcs(mint MintFunc, revoke RevokeFunc) sdk.Rotator {
+	return funcRotator{mint: mint, revoke: revoke}
+}
+
+type funcRotator struct {
+	mint   MintFunc
+	revoke RevokeFunc
+}
+
+func (r funcRotator) Mint(ctx context.Context, in sdk.RotationInput, out *sdk.RotationOutput) {
+	fields, err := r.mint(ctx, in)
+	if err != nil {
+		out.AddError(err)
+		return
+	}
+	out.ItemFields = fields
+}
+
+func (r funcRotator) Revoke(ctx context.Context, in sdk.RevocationInput, out *sdk.RevocationOutput) {
+	if err := r.revoke(ctx, in); err != nil {
+		out.AddError(err)
+	}
+}
@@ -0,0 +1,78 @@
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// ItemUpdater stores the fields of the new credential in the 1Password item.
+type ItemUpdater func(ctx context.Context, fields map[sdk.FieldName]string) error
+
+// Rotate rotates the credential using the specified rotator, in the order that keeps the item working at any time:
+// it mints a new credential, stores it using the specified updater, and then revokes the old credential. If storing
+// the new credential fails, the new credential is revoked again and the item keeps the old one. It returns the
+// fields of the new credential, merged with the fields that did not change.
+//
+// If revoking the old credential fails, the new fields are still returned along with the error, since the item
+// has already been updated at that point.
+func Rotate(ctx context.Context, rotator sdk.Rotator, in sdk.RotationInput, update ItemUpdater) (map[sdk.FieldName]string, error) {
+	mintOut := sdk.RotationOutput{}
+	rotator.Mint(ctx, in, &mintOut)
+	if err := diagnosticsError(mintOut.Diagnostics); err != nil {
+		return nil, fmt.Errorf("minting new credential: %w", err)
+	}
+	if len(mintOut.ItemFields) == 0 {
+		return nil, errors.New("minting new credential: no fields were returned")
+	}
+
+	newFields := make(map[sdk.FieldName]string)
+	for fieldName, value := range in.ItemFields {
+		newFields[fieldName] = value
+	}
+	for fieldName, value := range mintOut.ItemFields {
+		newFields[fieldName] = value
+	}
+
+	if err := update(ctx, newFields); err != nil {
+		revokeOut := sdk.RevocationOutput{}
+		rotator.Revoke(ctx, sdk.RevocationInput{
+			HomeDir:        in.HomeDir,
+			TempDir:        in.TempDir,
+			ItemFields:     newFields,
+			AuthItemFields: in.ItemFields,
+		}, &revokeOut)
+		if revokeErr := diagnosticsError(revokeOut.Diagnostics); revokeErr != nil {
+			return nil, fmt.Errorf("storing new credential: %s (revoking the new credential also failed: %s)", err, revokeErr)
+		}
+		return nil, fmt.Errorf("storing new credential: %w", err)
+	}
+
+	revokeOut := sdk.RevocationOutput{}
+	rotator.Revoke(ctx, sdk.RevocationInput{
+		HomeDir:        in.HomeDir,
+		TempDir:        in.TempDir,
+		ItemFields:     in.ItemFields,
+		AuthItemFields: newFields,
+	}, &revokeOut)
+	if err := diagnosticsError(revokeOut.Diagnostics); err != nil {
+		return newFields, fmt.Errorf("revoking old credential: %w", err)
+	}
+
+	return newFields, nil
+}
+
+func diagnosticsError(diagnostics sdk.Diagnostics) error {
+	if len(diagnostics.Errors) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, err := range diagnostics.Errors {
+		messages = append(messages, err.Message)
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
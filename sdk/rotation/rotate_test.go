@@ -0,0 +1,65 @@
+package rotation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotate(t *testing.T) {
+	var steps []string
+	rotator := Funcs(
+		func(ctx context.Context, in sdk.RotationInput) (map[sdk.FieldName]string, error) {
+			steps = append(steps, "mint with "+in.ItemFields["Token"])
+			return map[sdk.FieldName]string{"Token": "tkn_new"}, nil
+		},
+		func(ctx context.Context, in sdk.RevocationInput) error {
+			steps = append(steps, "revoke "+in.ItemFields["Token"]+" with "+in.AuthItemFields["Token"])
+			return nil
+		},
+	)
+	in := sdk.RotationInput{ItemFields: map[sdk.FieldName]string{"Token": "tkn_old", "Host": "example.com"}}
+
+	t.Run("update then revoke", func(t *testing.T) {
+		steps = nil
+		fields, err := Rotate(context.Background(), rotator, in, func(ctx context.Context, fields map[sdk.FieldName]string) error {
+			steps = append(steps, "update to "+fields["Token"])
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[sdk.FieldName]string{"Token": "tkn_new", "Host": "example.com"}, fields)
+		assert.Equal(t, []string{"mint with tkn_old", "update to tkn_new", "revoke tkn_old with tkn_new"}, steps)
+	})
+
+	t.Run("revoke new credential if update fails", func(t *testing.T) {
+		steps = nil
+		_, err := Rotate(context.Background(), rotator, in, func(ctx context.Context, fields map[sdk.FieldName]string) error {
+			return errors.New("item is read-only")
+		})
+
+		assert.EqualError(t, err, "storing new credential: item is read-only")
+		assert.Equal(t, []string{"mint with tkn_old", "revoke tkn_new with tkn_old"}, steps)
+	})
+
+	t.Run("do not update if minting fails", func(t *testing.T) {
+		failing := Funcs(
+			func(ctx context.Context, in sdk.RotationInput) (map[sdk.FieldName]string, error) {
+				return nil, errors.New("quota exceeded")
+			},
+			func(ctx context.Context, in sdk.RevocationInput) error {
+				t.Fatal("nothing should be revoked")
+				return nil
+			},
+		)
+
+		_, err := Rotate(context.Background(), failing, in, func(ctx context.Context, fields map[sdk.FieldName]string) error {
+			t.Fatal("the item should not be updated")
+			return nil
+		})
+		assert.EqualError(t, err, "minting new credential: quota exceeded")
+	})
+}
@@ -0,0 +1,66 @@
+package sdk
+
+import "context"
+
+// Rotator can optionally be implemented for a credential type to rotate credentials at the provider side, e.g. by
+// minting a new API key and revoking the old one. Rotation always happens in this order: Mint creates the new
+// credential, the 1Password CLI stores it in the item, and only then Revoke revokes the old credential, so that the
+// item never contains a credential that no longer works. Use rotation.Rotate to run these steps in order.
+type Rotator interface {
+	// Mint creates a new credential at the provider, authenticating with the current item fields, and outputs
+	// the fields of the new credential.
+	Mint(ctx context.Context, input RotationInput, output *RotationOutput)
+
+	// Revoke revokes a credential at the provider. It's called for the old credential after the new credential
+	// has been stored, or for the new credential if storing it failed.
+	Revoke(ctx context.Context, input RevocationInput, output *RevocationOutput)
+}
+
+// RotationInput contains info that rotators can use to mint a new credential.
+type RotationInput struct {
+	HomeDir string
+	TempDir string
+
+	// ItemFields contains the field names and values of the current credential.
+	ItemFields map[FieldName]string
+}
+
+// RotationOutput contains the fields of the newly minted credential.
+type RotationOutput struct {
+	// ItemFields contains the fields that changed in the new credential. Fields that are not set keep their
+	// current value.
+	ItemFields map[FieldName]string
+
+	// Diagnostics can be used to report errors.
+	Diagnostics Diagnostics
+}
+
+// RevocationInput contains info that rotators can use to revoke a credential.
+type RevocationInput struct {
+	HomeDir string
+	TempDir string
+
+	// ItemFields contains the fields of the credential to revoke.
+	ItemFields map[FieldName]string
+
+	// AuthItemFields contains the fields of the credential to authenticate the revocation with.
+	AuthItemFields map[FieldName]string
+}
+
+// RevocationOutput contains the diagnostics of the revocation.
+type RevocationOutput struct {
+	// Diagnostics can be used to report errors.
+	Diagnostics Diagnostics
+}
+
+// AddError can be used to report an error to the rotation output. If the output contains one or more errors,
+// minting is considered failed.
+func (out *RotationOutput) AddError(err error) {
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, newError(err))
+}
+
+// AddError can be used to report an error to the revocation output. If the output contains one or more errors,
+// revocation is considered failed.
+func (out *RevocationOutput) AddError(err error) {
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, newError(err))
+}
@@ -67,6 +67,27 @@ type GetPluginResponse struct {
 	// CredentialUsageHasProvisioner contains a true value for all CredentialUsage objects that have their Provisioner
 	// field set.
 	CredentialUsageHasProvisioner map[CredentialUsageID]bool
+	// ProvisionerHasPreExecHook contains a true value for all provisioners that implement sdk.PreExecHook.
+	ProvisionerHasPreExecHook map[ProvisionerID]bool
+	// ProvisionerHasPostExecHook contains a true value for all provisioners that implement sdk.PostExecHook.
+	ProvisionerHasPostExecHook map[ProvisionerID]bool
+	// CredentialHasRotator contains a true value for all credentials that have their Rotator field set.
+	CredentialHasRotator map[CredentialID]bool
+	// CredentialHasVerifier contains a true value for all credentials that have their Verifier field set.
+	CredentialHasVerifier map[CredentialID]bool
+}
+
+// NegotiateCapabilitiesRequest contains the capabilities that the host supports.
+type NegotiateCapabilitiesRequest struct {
+	HostCapabilities sdk.Capabilities
+}
+
+// NegotiateCapabilitiesResponse contains the capabilities that the SDK the plugin is built with supports, the
+// capabilities that the plugin requires, and which of those the host is missing.
+type NegotiateCapabilitiesResponse struct {
+	SDKCapabilities      sdk.Capabilities
+	RequiredCapabilities sdk.Capabilities
+	MissingCapabilities  sdk.Capabilities
 }
 
 // ImportCredentialRequest augments sdk.ImportInput with a CredentialID so Import() can be called over RPC.
@@ -90,6 +111,41 @@ type DeprovisionCredentialRequest struct {
 	sdk.DeprovisionOutput
 }
 
+// VerifyCredentialRequest augments sdk.VerifyInput with a CredentialID so Verifier can be called over RPC.
+type VerifyCredentialRequest struct {
+	CredentialID
+	sdk.VerifyInput
+	sdk.VerifyOutput
+}
+
+// MintCredentialRequest augments sdk.RotationInput with a CredentialID so Mint() can be called over RPC.
+type MintCredentialRequest struct {
+	CredentialID
+	sdk.RotationInput
+	sdk.RotationOutput
+}
+
+// RevokeCredentialRequest augments sdk.RevocationInput with a CredentialID so Revoke() can be called over RPC.
+type RevokeCredentialRequest struct {
+	CredentialID
+	sdk.RevocationInput
+	sdk.RevocationOutput
+}
+
+// PreExecRequest augments sdk.PreExecInput with a ProvisionerID so PreExec() can be called over RPC.
+type PreExecRequest struct {
+	ProvisionerID
+	sdk.PreExecInput
+	sdk.PreExecOutput
+}
+
+// PostExecRequest augments sdk.PostExecInput with a ProvisionerID so PostExec() can be called over RPC.
+type PostExecRequest struct {
+	ProvisionerID
+	sdk.PostExecInput
+	sdk.PostExecOutput
+}
+
 // ExecutableNeedsAuthRequest augments sdk.NeedsAuthenticationInput with the ID of an executable so NeedsAuth() can be
 // called over RPC. ExecutableID resembles the slice index of the executable in schema.Plugin.
 type ExecutableNeedsAuthRequest struct {
@@ -31,6 +31,14 @@ type RPCServer struct {
 	importers    map[proto.CredentialID]sdk.Importer
 	provisioners map[proto.ProvisionerID]sdk.Provisioner
 	needsAuth    map[proto.ExecutableID]sdk.NeedsAuthentication
+	rotators     map[proto.CredentialID]sdk.Rotator
+	verifiers    map[proto.CredentialID]sdk.Verifier
+
+	// provisionedCredentials holds the credential type that each provisioner provisions, if it's defined in this
+	// plugin, so that the values of its secret fields can be registered for redaction.
+	provisionedCredentials map[proto.ProvisionerID]*schema.CredentialType
+
+	hostCapabilities sdk.Capabilities
 }
 
 func newServer(p schema.Plugin) *RPCServer {
@@ -38,6 +46,10 @@ func newServer(p schema.Plugin) *RPCServer {
 		importers:    map[proto.CredentialID]sdk.Importer{},
 		provisioners: map[proto.ProvisionerID]sdk.Provisioner{},
 		needsAuth:    map[proto.ExecutableID]sdk.NeedsAuthentication{},
+		rotators:     map[proto.CredentialID]sdk.Rotator{},
+		verifiers:    map[proto.CredentialID]sdk.Verifier{},
+
+		provisionedCredentials: map[proto.ProvisionerID]*schema.CredentialType{},
 	}
 
 	// Remove all functions and interfaces from schema.Plugin and store them in the respective maps.
@@ -50,13 +62,17 @@ func newServer(p schema.Plugin) *RPCServer {
 		p.Executables[i].NeedsAuth = nil
 		for usageID, credentialUse := range p.Executables[i].Uses {
 			executableID := proto.ExecutableID(i)
-			s.provisioners[proto.ProvisionerID{
+			provisionerID := proto.ProvisionerID{
 				IsDefaultProvisioner: false,
 				CredentialUsage: proto.CredentialUsageID{
 					Executable: executableID,
 					Usage:      usageID,
 				},
-			}] = credentialUse.Provisioner
+			}
+			s.provisioners[provisionerID] = credentialUse.Provisioner
+			if credential, _ := p.CredentialForUsage(credentialUse); credential != nil {
+				s.provisionedCredentials[provisionerID] = credential
+			}
 			p.Executables[i].Uses[usageID].Provisioner = nil
 		}
 	}
@@ -65,11 +81,19 @@ func newServer(p schema.Plugin) *RPCServer {
 		s.importers[id] = c.Importer
 		c.Importer = nil
 
-		s.provisioners[proto.ProvisionerID{
+		provisionerID := proto.ProvisionerID{
 			IsDefaultProvisioner: true,
 			Credential:           id,
-		}] = c.DefaultProvisioner
+		}
+		s.provisioners[provisionerID] = c.DefaultProvisioner
+		s.provisionedCredentials[provisionerID] = c
 		c.DefaultProvisioner = nil
+
+		s.rotators[id] = c.Rotator
+		c.Rotator = nil
+
+		s.verifiers[id] = c.Verifier
+		c.Verifier = nil
 	}
 
 	s.p = p
@@ -84,6 +108,10 @@ func (t *RPCServer) GetPlugin(_ int, resp *proto.GetPluginResponse) error {
 		CredentialHasImporter:         map[proto.CredentialID]bool{},
 		ExecutableHasNeedAuth:         map[proto.ExecutableID]bool{},
 		CredentialUsageHasProvisioner: map[proto.CredentialUsageID]bool{},
+		ProvisionerHasPreExecHook:     map[proto.ProvisionerID]bool{},
+		ProvisionerHasPostExecHook:    map[proto.ProvisionerID]bool{},
+		CredentialHasRotator:          map[proto.CredentialID]bool{},
+		CredentialHasVerifier:         map[proto.CredentialID]bool{},
 		Plugin:                        t.p,
 	}
 	for executableID, needsAuth := range t.needsAuth {
@@ -92,7 +120,18 @@ func (t *RPCServer) GetPlugin(_ int, resp *proto.GetPluginResponse) error {
 	for credentialID, importer := range t.importers {
 		resp.CredentialHasImporter[credentialID] = importer != nil
 	}
+	for credentialID, verifier := range t.verifiers {
+		resp.CredentialHasVerifier[credentialID] = verifier != nil
+	}
+	for credentialID, rotator := range t.rotators {
+		resp.CredentialHasRotator[credentialID] = rotator != nil
+	}
 	for provisionerID, provisioner := range t.provisioners {
+		_, hasPreExecHook := provisioner.(sdk.PreExecHook)
+		resp.ProvisionerHasPreExecHook[provisionerID] = hasPreExecHook
+		_, hasPostExecHook := provisioner.(sdk.PostExecHook)
+		resp.ProvisionerHasPostExecHook[provisionerID] = hasPostExecHook
+
 		if !provisionerID.IsDefaultProvisioner {
 			resp.CredentialUsageHasProvisioner[provisionerID.CredentialUsage] = provisioner != nil
 		}
@@ -101,6 +140,19 @@ func (t *RPCServer) GetPlugin(_ int, resp *proto.GetPluginResponse) error {
 	return nil
 }
 
+// NegotiateCapabilities lets the host find out which capabilities the plugin and SDK support and require. This
+// should be called before any other method, so the host can refuse to load plugins that require capabilities it
+// doesn't support. The host capabilities are passed on to provisioners in sdk.ProvisionInput.HostCapabilities.
+func (t *RPCServer) NegotiateCapabilities(req proto.NegotiateCapabilitiesRequest, resp *proto.NegotiateCapabilitiesResponse) error {
+	t.hostCapabilities = req.HostCapabilities
+	*resp = proto.NegotiateCapabilitiesResponse{
+		SDKCapabilities:      sdk.SupportedCapabilities,
+		RequiredCapabilities: t.p.RequiredCapabilities,
+		MissingCapabilities:  req.HostCapabilities.Missing(t.p.RequiredCapabilities...),
+	}
+	return nil
+}
+
 // ExecutableNeedsAuth is a remote version of the NeedsAuth function in schema.Executable.
 // The call is forwarded to Executables[req.ExecutableID].NeedsAuth of the original plugin.
 func (t *RPCServer) ExecutableNeedsAuth(req proto.ExecutableNeedsAuthRequest, resp *bool) error {
@@ -143,6 +195,78 @@ func (t *RPCServer) CredentialImport(req proto.ImportCredentialRequest, resp *sd
 	return nil
 }
 
+// CredentialProvisionerPreExec is a remote version of the PreExec() method of the sdk.PreExecHook interface. The
+// call is forwarded to the PreExec() function of the Provisioner identified by req.ProvisionerID.
+func (t *RPCServer) CredentialProvisionerPreExec(req proto.PreExecRequest, resp *sdk.PreExecOutput) error {
+	defer func() {
+		if err := recover(); err != nil {
+			diagnostics := getPanicDiagnostics(err)
+			resp.Diagnostics = diagnostics
+		}
+	}()
+	provisioner, err := t.getProvisioner(req.ProvisionerID)
+	if err != nil {
+		return err
+	}
+	hook, ok := provisioner.(sdk.PreExecHook)
+	if !ok {
+		return &errFunctionFieldNotSet{
+			objName:  req.ProvisionerID.String(),
+			funcName: "PreExec",
+		}
+	}
+	*resp = req.PreExecOutput
+	hook.PreExec(context.Background(), req.PreExecInput, resp)
+	return nil
+}
+
+// CredentialProvisionerPostExec is a remote version of the PostExec() method of the sdk.PostExecHook interface. The
+// call is forwarded to the PostExec() function of the Provisioner identified by req.ProvisionerID.
+func (t *RPCServer) CredentialProvisionerPostExec(req proto.PostExecRequest, resp *sdk.PostExecOutput) error {
+	defer func() {
+		if err := recover(); err != nil {
+			diagnostics := getPanicDiagnostics(err)
+			resp.Diagnostics = diagnostics
+		}
+	}()
+	provisioner, err := t.getProvisioner(req.ProvisionerID)
+	if err != nil {
+		return err
+	}
+	hook, ok := provisioner.(sdk.PostExecHook)
+	if !ok {
+		return &errFunctionFieldNotSet{
+			objName:  req.ProvisionerID.String(),
+			funcName: "PostExec",
+		}
+	}
+	*resp = req.PostExecOutput
+	hook.PostExec(context.Background(), req.PostExecInput, resp)
+	return nil
+}
+
+// CredentialVerify is a remote version of the Verifier function in schema.CredentialType.
+// The call is forwarded to the Verifier function of the credential identified by req.CredentialID.
+func (t *RPCServer) CredentialVerify(req proto.VerifyCredentialRequest, resp *sdk.VerifyOutput) error {
+	defer func() {
+		if err := recover(); err != nil {
+			diagnostics := getPanicDiagnostics(err)
+			resp.Diagnostics = diagnostics
+		}
+	}()
+
+	verifier, ok := t.verifiers[req.CredentialID]
+	if !ok || verifier == nil {
+		return &errFunctionFieldNotSet{
+			objName:  req.CredentialID.String(),
+			funcName: "Verifier",
+		}
+	}
+	*resp = req.VerifyOutput
+	verifier(context.Background(), req.VerifyInput, resp)
+	return nil
+}
+
 // CredentialProvisionerDescription is a remote version of the the Description() method of the sdk.Provisioner
 // interface. The call is forwarded to the Description() function of the Provisioner of the credential identified by
 // req.CredentialID.
@@ -170,6 +294,14 @@ func (t *RPCServer) CredentialProvisionerProvision(req proto.ProvisionCredential
 		return err
 	}
 	*resp = req.ProvisionOutput
+	if req.ProvisionInput.HostCapabilities == nil {
+		req.ProvisionInput.HostCapabilities = t.hostCapabilities
+	}
+	// Credentials of other plugins are unknown here, so the 1Password CLI registers the values of their secret
+	// fields itself.
+	if credential, ok := t.provisionedCredentials[req.ProvisionerID]; ok {
+		resp.AddRedaction(credential.SecretValues(req.ProvisionInput.ItemFields)...)
+	}
 	provisioner.Provision(context.Background(), req.ProvisionInput, resp)
 	return nil
 }
@@ -189,10 +321,59 @@ func (t *RPCServer) CredentialProvisionerDeprovision(req proto.DeprovisionCreden
 		return err
 	}
 	*resp = req.DeprovisionOutput
-	provisioner.Deprovision(context.Background(), req.DeprovisionInput, resp)
+	ctx, cancel := context.WithTimeout(context.Background(), sdk.DeprovisionTimeout)
+	defer cancel()
+	provisioner.Deprovision(ctx, req.DeprovisionInput, resp)
+	return nil
+}
+
+// CredentialRotatorMint is a remote version of the Mint() method of the sdk.Rotator interface. The call is forwarded
+// to the Mint() function of the Rotator of the credential identified by req.CredentialID.
+func (t *RPCServer) CredentialRotatorMint(req proto.MintCredentialRequest, resp *sdk.RotationOutput) error {
+	defer func() {
+		if err := recover(); err != nil {
+			diagnostics := getPanicDiagnostics(err)
+			resp.Diagnostics = diagnostics
+		}
+	}()
+	rotator, err := t.getRotator(req.CredentialID)
+	if err != nil {
+		return err
+	}
+	*resp = req.RotationOutput
+	rotator.Mint(context.Background(), req.RotationInput, resp)
 	return nil
 }
 
+// CredentialRotatorRevoke is a remote version of the Revoke() method of the sdk.Rotator interface. The call is
+// forwarded to the Revoke() function of the Rotator of the credential identified by req.CredentialID.
+func (t *RPCServer) CredentialRotatorRevoke(req proto.RevokeCredentialRequest, resp *sdk.RevocationOutput) error {
+	defer func() {
+		if err := recover(); err != nil {
+			diagnostics := getPanicDiagnostics(err)
+			resp.Diagnostics = diagnostics
+		}
+	}()
+	rotator, err := t.getRotator(req.CredentialID)
+	if err != nil {
+		return err
+	}
+	*resp = req.RevocationOutput
+	rotator.Revoke(context.Background(), req.RevocationInput, resp)
+	return nil
+}
+
+func (t *RPCServer) getRotator(credentialID proto.CredentialID) (sdk.Rotator, error) {
+	rotator, ok := t.rotators[credentialID]
+	if !ok || rotator == nil {
+		return nil, &errFunctionFieldNotSet{
+			objName:  credentialID.String(),
+			funcName: "Rotator",
+		}
+	}
+	return rotator, nil
+}
+
 func (t *RPCServer) getProvisioner(provisionerID proto.ProvisionerID) (sdk.Provisioner, error) {
 	provisioner, ok := t.provisioners[provisionerID]
 	if !ok || provisioner == nil {
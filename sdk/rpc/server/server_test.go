@@ -0,0 +1,43 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/rpc/proto"
+	"github.com/1Password/shell-plugins/sdk/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateCapabilities(t *testing.T) {
+	s := newServer(schema.Plugin{
+		Name:                 "example",
+		RequiredCapabilities: []sdk.Capability{sdk.CapabilityPrompts},
+	})
+
+	var resp proto.NegotiateCapabilitiesResponse
+	err := s.NegotiateCapabilities(proto.NegotiateCapabilitiesRequest{
+		HostCapabilities: sdk.Capabilities{sdk.CapabilityPrompts, sdk.CapabilityRotation},
+	}, &resp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, sdk.SupportedCapabilities, resp.SDKCapabilities)
+	assert.Equal(t, sdk.Capabilities{sdk.CapabilityPrompts}, resp.RequiredCapabilities)
+	assert.Empty(t, resp.MissingCapabilities)
+	assert.Equal(t, sdk.Capabilities{sdk.CapabilityPrompts, sdk.CapabilityRotation}, s.hostCapabilities)
+}
+
+func TestNegotiateCapabilitiesMissingRequiredCapability(t *testing.T) {
+	s := newServer(schema.Plugin{
+		Name:                 "example",
+		RequiredCapabilities: []sdk.Capability{sdk.CapabilityPrompts, sdk.CapabilityVerification},
+	})
+
+	var resp proto.NegotiateCapabilitiesResponse
+	err := s.NegotiateCapabilities(proto.NegotiateCapabilitiesRequest{
+		HostCapabilities: sdk.Capabilities{sdk.CapabilityPrompts},
+	}, &resp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, sdk.Capabilities{sdk.CapabilityVerification}, resp.MissingCapabilities)
+}
@@ -3,6 +3,8 @@ package schema
 import (
 	"fmt"
 	"net/url"
+	"strings"
+	"unicode"
 
 	"github.com/1Password/shell-plugins/sdk"
 )
@@ -26,6 +28,98 @@ type CredentialType struct {
 
 	// The default provisioner to use for this credential if the executable doesn't override it.
 	DefaultProvisioner sdk.Provisioner
+
+	// (Optional) A function to confirm that a credential works, using a cheap read-only call against the platform.
+	Verifier sdk.Verifier
+
+	// (Optional) Rotates the credential at the provider side, so that the 1Password CLI can offer to rotate it.
+	Rotator sdk.Rotator
+
+	// (Optional) The environments this credential type can be used in, e.g. live and test keys, and how the
+	// environment gets selected at runtime.
+	Environments *EnvironmentSelection
+
+	// (Optional) Additional questions to ask when setting up this credential type with `op plugin init`.
+	// The answers populate non-secret fields of the credential, such as the host or the region.
+	InitQuestions []InitQuestion
+}
+
+// InitQuestion describes a question that is asked during `op plugin init` to populate a non-secret field.
+type InitQuestion struct {
+	// The name of the field that the answer populates. Must be a non-secret field on the credential type.
+	Field sdk.FieldName
+
+	// The question to ask, e.g. "Which GitLab host do you want to use?".
+	Message string
+
+	// (Optional) The answer to suggest by default, e.g. "gitlab.com".
+	Default string
+
+	// (Optional) The answers to choose from, e.g. a list of regions. If set, any other answer is rejected.
+	Options []string
+}
+
+// EnvironmentSelection describes which environments a credential type supports and how the environment
+// for a single run gets selected.
+type EnvironmentSelection struct {
+	// The environments the credential type supports. The first environment is used as the default.
+	Supported []sdk.Environment
+
+	// (Optional) A command-line flag that selects the environment, e.g. "--env" for `--env staging`
+	// and `--env=staging`. Takes precedence over `EnvVar`.
+	Flag string
+
+	// (Optional) An environment variable that selects the environment, e.g. "STRIPE_ENV".
+	EnvVar string
+}
+
+// Select returns the environment that was selected for the specified command-line args, falling
+// back to the environment variable in the specified environment and the default environment respectively.
+// Selecting an environment that is not supported returns an error, instead of silently using the credentials
+// of another environment.
+func (s EnvironmentSelection) Select(args []string, environment map[string]string) (sdk.Environment, error) {
+	if s.Flag != "" {
+		for i, arg := range args {
+			if arg == s.Flag && i+1 < len(args) {
+				return s.supported(sdk.Environment(args[i+1]))
+			}
+			if strings.HasPrefix(arg, s.Flag+"=") {
+				return s.supported(sdk.Environment(strings.TrimPrefix(arg, s.Flag+"=")))
+			}
+		}
+	}
+
+	if s.EnvVar != "" {
+		if value, ok := environment[s.EnvVar]; ok {
+			return s.supported(sdk.Environment(value))
+		}
+	}
+
+	if len(s.Supported) > 0 {
+		return s.Supported[0], nil
+	}
+	return "", nil
+}
+
+func (s EnvironmentSelection) supported(env sdk.Environment) (sdk.Environment, error) {
+	if !s.IsSupported(env) {
+		supported := make([]string, len(s.Supported))
+		for i, env := range s.Supported {
+			supported[i] = string(env)
+		}
+		return "", fmt.Errorf("environment %q is not supported, expected one of: %s", env, strings.Join(supported, ", "))
+	}
+	return env, nil
+}
+
+// IsSupported returns whether the specified environment is one of the supported environments.
+func (s EnvironmentSelection) IsSupported(env sdk.Environment) bool {
+	for _, supported := range s.Supported {
+		if supported == env {
+			return true
+		}
+	}
+	return false
 }
 
 // CredentialField provides the schema of a single field on a credential type.
@@ -59,6 +153,18 @@ func (c CredentialType) Field(name string) *CredentialField {
 	return nil
 }
 
+// SecretValues returns the values of the specified item fields that are marked as secret in this credential type.
+// These are the values that get registered for redaction before provisioning.
+func (c CredentialType) SecretValues(itemFields map[sdk.FieldName]string) []string {
+	var values []string
+	for _, field := range c.Fields {
+		if value, ok := itemFields[field.Name]; ok && field.Secret {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // ValueComposition describes what a value for a certain field looks like. This gets used for various purposes,
 // including but not limited to the Save in 1Password functionality and secrets scanning functionality.
 type ValueComposition struct {
@@ -80,6 +186,50 @@ type Charset struct {
 	Specific  []rune
 }
 
+// Matches returns whether the specified value could have been created according to this value composition,
+// i.e. it has the right prefix and length, and only consists of characters from the charset after the prefix.
+func (v ValueComposition) Matches(value string) bool {
+	if !strings.HasPrefix(value, v.Prefix) {
+		return false
+	}
+
+	if v.Length > 0 && len(value) != v.Length {
+		return false
+	}
+
+	for _, r := range strings.TrimPrefix(value, v.Prefix) {
+		if !v.Charset.Contains(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Contains returns whether the specified character is part of the charset.
+func (c Charset) Contains(r rune) bool {
+	for _, specific := range c.Specific {
+		if r == specific {
+			return true
+		}
+	}
+
+	switch {
+	case r > unicode.MaxASCII:
+		return false
+	case unicode.IsUpper(r):
+		return c.Uppercase
+	case unicode.IsLower(r):
+		return c.Lowercase
+	case unicode.IsDigit(r):
+		return c.Digits
+	case unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return c.Symbols
+	}
+
+	return false
+}
+
 func (c CredentialType) Validate() (bool, ValidationReport) {
 	report := ValidationReport{
 		Heading: fmt.Sprintf("Credential: %s", c.Name),
@@ -137,6 +287,9 @@ func (c CredentialType) Validate() (bool, ValidationReport) {
 			if !cs.Lowercase && !cs.Uppercase && !cs.Digits && !cs.Symbols && len(cs.Specific) == 0 {
 				allCompositionsValid = false
 			}
+			if comp.Length > 0 && comp.Length <= len(comp.Prefix) {
+				allCompositionsValid = false
+			}
 		}
 		if f.Secret {
 			hasSecretField = true
@@ -186,6 +339,18 @@ func (c CredentialType) Validate() (bool, ValidationReport) {
 		Severity:    ValidationSeverityError,
 	})
 
+	report.AddCheck(ValidationCheck{
+		Description: "If defined, the environment selection has at least 1 environment and no duplicates",
+		Assertion:   c.hasValidEnvironments(),
+		Severity:    ValidationSeverityError,
+	})
+
+	report.AddCheck(ValidationCheck{
+		Description: "Init questions have a message and each populate a different non-secret field",
+		Assertion:   c.hasValidInitQuestions(),
+		Severity:    ValidationSeverityError,
+	})
+
 	report.AddCheck(ValidationCheck{
 		Description: "Has an importer set",
 		Assertion:   c.Importer != nil,
@@ -208,3 +373,47 @@ func (c CredentialType) hasNoDuplicateFieldNames() bool {
 	}
 	return true
 }
+
+func (c CredentialType) hasValidEnvironments() bool {
+	if c.Environments == nil {
+		return true
+	}
+
+	var envs []string
+	for _, env := range c.Environments.Supported {
+		if env == "" {
+			return false
+		}
+		envs = append(envs, env.String())
+	}
+
+	return len(envs) > 0 && IsStringSliceASet(envs)
+}
+
+func (c CredentialType) hasValidInitQuestions() bool {
+	var fields []string
+	for _, q := range c.InitQuestions {
+		field := c.Field(q.Field.String())
+		if field == nil || field.Secret || q.Message == "" {
+			return false
+		}
+
+		if len(q.Options) > 0 && q.Default != "" && !q.HasOption(q.Default) {
+			return false
+		}
+
+		fields = append(fields, q.Field.String())
+	}
+
+	return IsStringSliceASet(fields)
+}
+
+// HasOption returns whether the specified answer is one of the options of the question.
+func (q InitQuestion) HasOption(answer string) bool {
+	for _, option := range q.Options {
+		if option == answer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentSelectionSelect(t *testing.T) {
+	selection := EnvironmentSelection{
+		Supported: []sdk.Environment{sdk.EnvironmentProduction, sdk.EnvironmentDevelopment},
+		Flag:      "--env",
+		EnvVar:    "EXAMPLE_ENV",
+	}
+
+	cases := map[string]struct {
+		args          []string
+		envVar        string
+		expected      sdk.Environment
+		expectedError string
+	}{
+		"default environment without flag or env var": {
+			args:     []string{"deploy"},
+			expected: sdk.EnvironmentProduction,
+		},
+		"flag with separate value": {
+			args:     []string{"deploy", "--env", "development"},
+			expected: sdk.EnvironmentDevelopment,
+		},
+		"flag with inline value": {
+			args:     []string{"deploy", "--env=development"},
+			expected: sdk.EnvironmentDevelopment,
+		},
+		"env var": {
+			args:     []string{"deploy"},
+			envVar:   "development",
+			expected: sdk.EnvironmentDevelopment,
+		},
+		"flag takes precedence over env var": {
+			args:     []string{"deploy", "--env", "production"},
+			envVar:   "development",
+			expected: sdk.EnvironmentProduction,
+		},
+		"unsupported environment in flag fails": {
+			args:          []string{"deploy", "--env", "staging"},
+			expectedError: `environment "staging" is not supported, expected one of: production, development`,
+		},
+		"unsupported environment in inline flag fails": {
+			args:          []string{"deploy", "--env=staging"},
+			envVar:        "development",
+			expectedError: `environment "staging" is not supported, expected one of: production, development`,
+		},
+		"unsupported environment in env var fails": {
+			args:          []string{"deploy"},
+			envVar:        "staging",
+			expectedError: `environment "staging" is not supported, expected one of: production, development`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			environment := map[string]string{}
+			if tc.envVar != "" {
+				environment["EXAMPLE_ENV"] = tc.envVar
+			}
+			env, err := selection.Select(tc.args, environment)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, env)
+		})
+	}
+}
+
+func TestEnvironmentSelectionSelectIgnoresProcessEnvironment(t *testing.T) {
+	t.Setenv("EXAMPLE_ENV", "development")
+	selection := EnvironmentSelection{
+		Supported: []sdk.Environment{sdk.EnvironmentProduction, sdk.EnvironmentDevelopment},
+		EnvVar:    "EXAMPLE_ENV",
+	}
+
+	env, err := selection.Select([]string{"deploy"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sdk.EnvironmentProduction, env)
+}
+
+func TestValueCompositionMatches(t *testing.T) {
+	composition := ValueComposition{
+		Length:  10,
+		Prefix:  "sk_",
+		Charset: Charset{Lowercase: true, Digits: true},
+	}
+
+	cases := map[string]struct {
+		value    string
+		expected bool
+	}{
+		"when value matches":           {value: "sk_abc1234", expected: true},
+		"when prefix is missing":       {value: "abcdef1234", expected: false},
+		"when length is different":     {value: "sk_abc123", expected: false},
+		"when charset is not followed": {value: "sk_ABC1234", expected: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, composition.Matches(tc.value))
+		})
+	}
+}
+
+func TestCredentialTypeInitQuestions(t *testing.T) {
+	fields := []CredentialField{
+		{Name: "Token", Secret: true},
+		{Name: "Host"},
+		{Name: "Region"},
+	}
+
+	cases := map[string]struct {
+		questions []InitQuestion
+		expected  bool
+	}{
+		"when no questions are defined": {
+			expected: true,
+		},
+		"when questions populate non-secret fields": {
+			questions: []InitQuestion{
+				{Field: "Host", Message: "Which host?", Default: "gitlab.com"},
+				{Field: "Region", Message: "Which region?", Default: "eu-west-1", Options: []string{"eu-west-1", "us-east-1"}},
+			},
+			expected: true,
+		},
+		"when question populates a secret field": {
+			questions: []InitQuestion{{Field: "Token", Message: "Which token?"}},
+			expected:  false,
+		},
+		"when question populates an unknown field": {
+			questions: []InitQuestion{{Field: "Username", Message: "Which user?"}},
+			expected:  false,
+		},
+		"when question has no message": {
+			questions: []InitQuestion{{Field: "Host"}},
+			expected:  false,
+		},
+		"when default is not one of the options": {
+			questions: []InitQuestion{{Field: "Region", Message: "Which region?", Default: "mars", Options: []string{"eu-west-1"}}},
+			expected:  false,
+		},
+		"when multiple questions populate the same field": {
+			questions: []InitQuestion{
+				{Field: "Host", Message: "Which host?"},
+				{Field: "Host", Message: "Which other host?"},
+			},
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := CredentialType{Fields: fields, InitQuestions: tc.questions}
+			assert.Equal(t, tc.expected, c.hasValidInitQuestions())
+		})
+	}
+}
+
+func TestCredentialTypeSecretValues(t *testing.T) {
+	credential := CredentialType{
+		Fields: []CredentialField{
+			{Name: "Host"},
+			{Name: "Token", Secret: true},
+			{Name: "Passphrase", Secret: true, Optional: true},
+		},
+	}
+
+	values := credential.SecretValues(map[sdk.FieldName]string{
+		"Host":  "api.example.com",
+		"Token": "tkn_example",
+	})
+
+	assert.Equal(t, []string{"tkn_example"}, values)
+}
@@ -4,6 +4,7 @@ import "github.com/1Password/shell-plugins/sdk"
 
 // Credential type names.
 const (
+	ACLToken             = sdk.CredentialName("ACL Token")
 	APIClientCredentials = sdk.CredentialName("API Client Credentials")
 	APIKey               = sdk.CredentialName("API Key")
 	APIToken             = sdk.CredentialName("API Token")
@@ -13,6 +14,7 @@ const (
 	AppToken             = sdk.CredentialName("App Token")
 	AuthToken            = sdk.CredentialName("Auth Token")
 	CLIToken             = sdk.CredentialName("CLI Token")
+	ClusterCredentials   = sdk.CredentialName("Cluster Credentials")
 	Credential           = sdk.CredentialName("Credential")
 	Credentials          = sdk.CredentialName("Credentials")
 	DatabaseCredentials  = sdk.CredentialName("Database Credentials")
@@ -22,11 +24,14 @@ const (
 	PersonalAccessToken  = sdk.CredentialName("Personal Access Token")
 	RegistryCredentials  = sdk.CredentialName("Registry Credentials")
 	SecretKey            = sdk.CredentialName("Secret Key")
+	ServiceAccountKey    = sdk.CredentialName("Service Account Key")
+	ServicePrincipal     = sdk.CredentialName("Service Principal")
 	UserLogin            = sdk.CredentialName("User Login")
 )
 
 func ListAll() []sdk.CredentialName {
 	return []sdk.CredentialName{
+		ACLToken,
 		APIClientCredentials,
 		APIKey,
 		APIToken,
@@ -36,6 +41,7 @@ func ListAll() []sdk.CredentialName {
 		AppToken,
 		AuthToken,
 		CLIToken,
+		ClusterCredentials,
 		Credential,
 		Credentials,
 		DatabaseCredentials,
@@ -45,6 +51,8 @@ func ListAll() []sdk.CredentialName {
 		PersonalAccessToken,
 		RegistryCredentials,
 		SecretKey,
+		ServiceAccountKey,
+		ServicePrincipal,
 		UserLogin,
 	}
 }
@@ -3,7 +3,9 @@ package schema
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/1Password/shell-plugins/sdk"
 )
@@ -12,6 +14,11 @@ type Executable struct {
 	// The entrypoint of the command that should be executed, e.g. ["aws"] or ["stripe"].
 	Runs []string
 
+	// (Optional) Alternative binary names that run the same executable, e.g. ["tofu"] for ["terraform"] or
+	// ["podman"] for ["docker"]. Each alias replaces the first element of `Runs`, and shares the credential
+	// usages and needs-auth rules defined on this executable.
+	Aliases []string
+
 	// The display name of the executable, e.g. "AWS CLI".
 	Name string
 
@@ -23,6 +30,40 @@ type Executable struct {
 
 	// (Optional) Whether the executable needs authentication for certain args.
 	NeedsAuth sdk.NeedsAuthentication
+
+	// (Optional) Run the executable without provisioning credentials first, and only provision credentials and
+	// retry if that run fails with an authentication error. Useful for executables of which most invocations
+	// don't need authentication, such as git or npm.
+	AuthOnFailure *AuthOnFailure
+
+	// (Optional) How to tell which profile, account, or context an invocation of the executable targets, such as
+	// `--profile prod`. The selected profile is passed to provisioners in ProvisionInput.
+	ProfileHint *ProfileHint
+}
+
+// ProfileHint describes how the profile, account, or context that an invocation targets can be extracted from it.
+// Plugins for multi-account platforms can use this to pick the right fields or item per invocation.
+type ProfileHint struct {
+	// The command-line flags that select the profile, e.g. ["--profile", "-p"]. Both `--profile prod` and
+	// `--profile=prod` are recognized. Takes precedence over `EnvVars`.
+	Flags []string
+
+	// (Optional) The environment variables that select the profile, e.g. ["AWS_PROFILE"]. The first one that
+	// is set is used.
+	EnvVars []string
+
+	// (Optional) The profile to use if none is selected, e.g. "default".
+	Default string
+}
+
+// AuthOnFailure describes how to recognize that a run of an executable failed because authentication was missing.
+type AuthOnFailure struct {
+	// (Optional) The exit codes that indicate an authentication error. Defaults to any non-zero exit code.
+	ExitCodes []int
+
+	// (Optional) Regular expressions of which at least one has to match the stderr output of the failed run,
+	// e.g. `(?i)authentication required`.
+	StderrPatterns []string
 }
 
 type CredentialUsage struct {
@@ -92,6 +133,24 @@ func (e Executable) Validate() (bool, ValidationReport) {
 		Severity:    ValidationSeverityError,
 	})
 
+	report.AddCheck(ValidationCheck{
+		Description: "Aliases are set and do not collide with each other or the executable command",
+		Assertion:   AreExecutableAliasesValid(e),
+		Severity:    ValidationSeverityError,
+	})
+
+	report.AddCheck(ValidationCheck{
+		Description: "If defined, the auth-on-failure stderr patterns are valid regular expressions",
+		Assertion:   e.AuthOnFailure == nil || e.AuthOnFailure.hasValidPatterns(),
+		Severity:    ValidationSeverityError,
+	})
+
+	report.AddCheck(ValidationCheck{
+		Description: "If defined, the profile hint has at least 1 flag or environment variable, and all flags start with a dash",
+		Assertion:   e.ProfileHint == nil || e.ProfileHint.isValid(),
+		Severity:    ValidationSeverityError,
+	})
+
 	report.AddCheck(ValidationCheck{
 		Description: "Has a credential type defined",
 		Assertion:   len(e.Uses) > 0,
@@ -111,6 +170,133 @@ func (e Executable) Command() string {
 	return strings.Join(e.Runs, " ")
 }
 
+// Entrypoints returns the entrypoint of the executable followed by the entrypoint of each of its aliases.
+func (e Executable) Entrypoints() [][]string {
+	entrypoints := [][]string{e.Runs}
+	if len(e.Runs) == 0 {
+		return entrypoints
+	}
+
+	for _, alias := range e.Aliases {
+		entrypoint := append([]string{alias}, e.Runs[1:]...)
+		entrypoints = append(entrypoints, entrypoint)
+	}
+	return entrypoints
+}
+
+// Commands returns the command of the executable followed by the command of each of its aliases.
+func (e Executable) Commands() []string {
+	var commands []string
+	for _, entrypoint := range e.Entrypoints() {
+		commands = append(commands, strings.Join(entrypoint, " "))
+	}
+	return commands
+}
+
+// RunsCommand returns whether the specified command matches the command of the executable or one of its aliases.
+func (e Executable) RunsCommand(command string) bool {
+	for _, c := range e.Commands() {
+		if strings.EqualFold(command, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the profile that was selected for the specified command-line args, falling back to the
+// environment variables in the specified environment and the default profile respectively.
+func (h ProfileHint) Select(args []string, environment map[string]string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		for _, flag := range h.Flags {
+			if arg == flag && i+1 < len(args) {
+				return args[i+1]
+			}
+			if strings.HasPrefix(arg, flag+"=") {
+				return strings.TrimPrefix(arg, flag+"=")
+			}
+		}
+	}
+
+	for _, envVar := range h.EnvVars {
+		if value := environment[envVar]; value != "" {
+			return value
+		}
+	}
+
+	return h.Default
+}
+
+func (h ProfileHint) isValid() bool {
+	for _, flag := range h.Flags {
+		if !strings.HasPrefix(flag, "-") || strings.ContainsAny(flag, " =") {
+			return false
+		}
+	}
+	return len(h.Flags) > 0 || len(h.EnvVars) > 0
+}
+
+// ShouldRetry returns whether a run that exited with the specified exit code and stderr output failed because of
+// missing authentication, and should be retried with credentials provisioned.
+func (a AuthOnFailure) ShouldRetry(exitCode int, stderr []byte) bool {
+	if exitCode == 0 {
+		return false
+	}
+
+	if len(a.ExitCodes) > 0 {
+		found := false
+		for _, code := range a.ExitCodes {
+			if code == exitCode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(a.StderrPatterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range a.StderrPatterns {
+		re, err := compileStderrPattern(pattern)
+		if err == nil && re.Match(stderr) {
+			return true
+		}
+	}
+	return false
+}
+
+// compiledStderrPatterns caches the compiled StderrPatterns, so that they only get compiled once instead of on
+// every failed run. Using the pattern as the key keeps AuthOnFailure a plain struct that can be copied freely.
+var compiledStderrPatterns sync.Map
+
+func compileStderrPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiledStderrPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	compiledStderrPatterns.Store(pattern, re)
+	return re, nil
+}
+
+func (a AuthOnFailure) hasValidPatterns() bool {
+	for _, pattern := range a.StderrPatterns {
+		if _, err := compileStderrPattern(pattern); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func (c CredentialUsage) Validate() (bool, ValidationReport) {
 	report := ValidationReport{
 		Heading: fmt.Sprintf("Credential usage %s", c.ID()),
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthOnFailureShouldRetry(t *testing.T) {
+	cases := map[string]struct {
+		authOnFailure AuthOnFailure
+		exitCode      int
+		stderr        string
+		expected      bool
+	}{
+		"no for successful run": {
+			authOnFailure: AuthOnFailure{},
+			exitCode:      0,
+			expected:      false,
+		},
+		"yes for any failure by default": {
+			authOnFailure: AuthOnFailure{},
+			exitCode:      1,
+			expected:      true,
+		},
+		"yes for matching exit code": {
+			authOnFailure: AuthOnFailure{ExitCodes: []int{128}},
+			exitCode:      128,
+			expected:      true,
+		},
+		"no for other exit code": {
+			authOnFailure: AuthOnFailure{ExitCodes: []int{128}},
+			exitCode:      1,
+			expected:      false,
+		},
+		"yes for matching stderr": {
+			authOnFailure: AuthOnFailure{StderrPatterns: []string{`(?i)authentication failed`}},
+			exitCode:      128,
+			stderr:        "fatal: Authentication failed for 'https://example.com/repo.git'",
+			expected:      true,
+		},
+		"no for other stderr": {
+			authOnFailure: AuthOnFailure{StderrPatterns: []string{`(?i)authentication failed`}},
+			exitCode:      128,
+			stderr:        "fatal: repository not found",
+			expected:      false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.authOnFailure.ShouldRetry(tc.exitCode, []byte(tc.stderr)))
+		})
+	}
+}
+
+func TestProfileHintSelect(t *testing.T) {
+	hint := ProfileHint{
+		Flags:   []string{"--profile", "-p"},
+		EnvVars: []string{"EXAMPLE_PROFILE"},
+		Default: "default",
+	}
+
+	for description, scenario := range map[string]struct {
+		args     []string
+		envVar   string
+		expected string
+	}{
+		"flag with separate value":    {args: []string{"deploy", "--profile", "prod"}, expected: "prod"},
+		"flag with inline value":      {args: []string{"deploy", "--profile=staging"}, expected: "staging"},
+		"short flag":                  {args: []string{"-p", "prod", "deploy"}, expected: "prod"},
+		"flag takes precedence":       {args: []string{"--profile", "prod"}, envVar: "staging", expected: "prod"},
+		"env var":                     {args: []string{"deploy"}, envVar: "staging", expected: "staging"},
+		"default":                     {args: []string{"deploy"}, expected: "default"},
+		"flag after argument divider": {args: []string{"exec", "--", "--profile", "prod"}, expected: "default"},
+	} {
+		t.Run(description, func(t *testing.T) {
+			environment := map[string]string{"EXAMPLE_PROFILE": scenario.envVar}
+			assert.Equal(t, scenario.expected, hint.Select(scenario.args, environment))
+		})
+	}
+}
+
+func TestProfileHintSelectIgnoresProcessEnvironment(t *testing.T) {
+	t.Setenv("EXAMPLE_PROFILE", "staging")
+	hint := ProfileHint{EnvVars: []string{"EXAMPLE_PROFILE"}, Default: "default"}
+
+	assert.Equal(t, "default", hint.Select([]string{"deploy"}, nil))
+	assert.Equal(t, "prod", hint.Select([]string{"deploy"}, map[string]string{"EXAMPLE_PROFILE": "prod"}))
+}
+
+func TestProfileHintIsValid(t *testing.T) {
+	assert.True(t, ProfileHint{Flags: []string{"--profile"}}.isValid())
+	assert.True(t, ProfileHint{EnvVars: []string{"AWS_PROFILE"}}.isValid())
+	assert.False(t, ProfileHint{}.isValid())
+	assert.False(t, ProfileHint{Flags: []string{"profile"}}.isValid())
+}
@@ -4,63 +4,96 @@ import "github.com/1Password/shell-plugins/sdk"
 
 // Credential field names.
 const (
-	APIHost         = sdk.FieldName("API Host")
-	APIUrl          = sdk.FieldName("API URL")
-	APIKey          = sdk.FieldName("API Key")
-	APIKeyID        = sdk.FieldName("API Key ID")
-	APISecret       = sdk.FieldName("API Secret")
-	AccessKeyID     = sdk.FieldName("Access Key ID")
-	AccessToken     = sdk.FieldName("Access Token")
-	Account         = sdk.FieldName("Account")
-	AccountID       = sdk.FieldName("Account ID")
-	AccountSID      = sdk.FieldName("Account SID")
-	Address         = sdk.FieldName("Address")
-	AppKey          = sdk.FieldName("App Key")
-	AppSecret       = sdk.FieldName("App Secret")
-	AppToken        = sdk.FieldName("App Token")
-	AuthToken       = sdk.FieldName("Auth Token")
-	Authtoken       = sdk.FieldName("Authtoken")
-	Cert            = sdk.FieldName("Cert")
-	Certificate     = sdk.FieldName("Certificate")
-	ClientSecret    = sdk.FieldName("Client Secret")
-	ClientToken     = sdk.FieldName("Client Token")
-	Credential      = sdk.FieldName("Credential")
-	Credentials     = sdk.FieldName("Credentials")
-	Database        = sdk.FieldName("Database")
-	DefaultRegion   = sdk.FieldName("Default Region")
-	Deployment      = sdk.FieldName("Deployment")
-	Email           = sdk.FieldName("Email")
-	Endpoint        = sdk.FieldName("Endpoint")
-	Host            = sdk.FieldName("Host")
-	HostAddress     = sdk.FieldName("Host Address")
-	Key             = sdk.FieldName("Key")
-	MFASerial       = sdk.FieldName("MFA Serial")
-	Mode            = sdk.FieldName("Mode")
-	Namespace       = sdk.FieldName("Namespace")
-	OneTimePassword = sdk.FieldName("One-Time Password")
-	OrgID           = sdk.FieldName("Org ID")
-	OrgURL          = sdk.FieldName("Org URL")
-	Organization    = sdk.FieldName("Organization")
-	Password        = sdk.FieldName("Password")
-	Port            = sdk.FieldName("Port")
-	PublicKey       = sdk.FieldName("Public Key")
-	PrivateKey      = sdk.FieldName("Private Key")
-	ProjectID       = sdk.FieldName("Project ID")
-	Project         = sdk.FieldName("Project")
-	Region          = sdk.FieldName("Region")
-	Secret          = sdk.FieldName("Secret")
-	SecretAccessKey = sdk.FieldName("Secret Access Key")
-	Subdomain       = sdk.FieldName("Subdomain")
-	Token           = sdk.FieldName("Token")
-	URL             = sdk.FieldName("URL")
-	User            = sdk.FieldName("User")
-	UserAccessToken = sdk.FieldName("User Access Token")
-	Username        = sdk.FieldName("Username")
-	Website         = sdk.FieldName("Website")
+	AccessKeySecret   = sdk.FieldName("Access Key Secret")
+	AccountKey        = sdk.FieldName("Account Key")
+	APIHost           = sdk.FieldName("API Host")
+	APIUrl            = sdk.FieldName("API URL")
+	APIKey            = sdk.FieldName("API Key")
+	APIKeyID          = sdk.FieldName("API Key ID")
+	APISecret         = sdk.FieldName("API Secret")
+	AccessKeyID       = sdk.FieldName("Access Key ID")
+	AccessToken       = sdk.FieldName("Access Token")
+	Account           = sdk.FieldName("Account")
+	AccountID         = sdk.FieldName("Account ID")
+	AccountSID        = sdk.FieldName("Account SID")
+	Address           = sdk.FieldName("Address")
+	AppKey            = sdk.FieldName("App Key")
+	AppSecret         = sdk.FieldName("App Secret")
+	AppToken          = sdk.FieldName("App Token")
+	AuthMethodID      = sdk.FieldName("Auth Method ID")
+	AuthToken         = sdk.FieldName("Auth Token")
+	Authtoken         = sdk.FieldName("Authtoken")
+	CACertificate     = sdk.FieldName("CA Certificate")
+	Cert              = sdk.FieldName("Cert")
+	Certificate       = sdk.FieldName("Certificate")
+	ClientCertificate = sdk.FieldName("Client Certificate")
+	ClientID          = sdk.FieldName("Client ID")
+	ClientKey         = sdk.FieldName("Client Key")
+	ClientSecret      = sdk.FieldName("Client Secret")
+	ClientToken       = sdk.FieldName("Client Token")
+	Context           = sdk.FieldName("Context")
+	Credential        = sdk.FieldName("Credential")
+	Credentials       = sdk.FieldName("Credentials")
+	Database          = sdk.FieldName("Database")
+	DefaultRegion     = sdk.FieldName("Default Region")
+	Deployment        = sdk.FieldName("Deployment")
+	Email             = sdk.FieldName("Email")
+	Endpoint          = sdk.FieldName("Endpoint")
+	Fingerprint       = sdk.FieldName("Fingerprint")
+	Host              = sdk.FieldName("Host")
+	HostAddress       = sdk.FieldName("Host Address")
+	IdentityFile      = sdk.FieldName("Identity File")
+	Key               = sdk.FieldName("Key")
+	MFASerial         = sdk.FieldName("MFA Serial")
+	Mode              = sdk.FieldName("Mode")
+	Namespace         = sdk.FieldName("Namespace")
+	OneTimePassword   = sdk.FieldName("One-Time Password")
+	OrgID             = sdk.FieldName("Org ID")
+	OrgURL            = sdk.FieldName("Org URL")
+	Organization      = sdk.FieldName("Organization")
+	Passphrase        = sdk.FieldName("Passphrase")
+	Password          = sdk.FieldName("Password")
+	Port              = sdk.FieldName("Port")
+	Proxy             = sdk.FieldName("Proxy")
+	PublicKey         = sdk.FieldName("Public Key")
+	PrivateKey        = sdk.FieldName("Private Key")
+	ProjectID         = sdk.FieldName("Project ID")
+	Project           = sdk.FieldName("Project")
+	PushToken         = sdk.FieldName("Push Token")
+	Region            = sdk.FieldName("Region")
+	Registry          = sdk.FieldName("Registry")
+	Repository        = sdk.FieldName("Repository")
+	RoleARN           = sdk.FieldName("Role ARN")
+	RoleID            = sdk.FieldName("Role ID")
+	RoleName          = sdk.FieldName("Role Name")
+	SecretID          = sdk.FieldName("Secret ID")
+	SecretKey         = sdk.FieldName("Secret Key")
+	Server            = sdk.FieldName("Server")
+	ServerID          = sdk.FieldName("Server ID")
+	SigningKey        = sdk.FieldName("Signing Key")
+	SigningKeyID      = sdk.FieldName("Signing Key ID")
+	SSORegion         = sdk.FieldName("SSO Region")
+	Secret            = sdk.FieldName("Secret")
+	SecretAccessKey   = sdk.FieldName("Secret Access Key")
+	StartURL          = sdk.FieldName("Start URL")
+	Subdomain         = sdk.FieldName("Subdomain")
+	SubscriptionID    = sdk.FieldName("Subscription ID")
+	TenancyOCID       = sdk.FieldName("Tenancy OCID")
+	TenantID          = sdk.FieldName("Tenant ID")
+	Token             = sdk.FieldName("Token")
+	URL               = sdk.FieldName("URL")
+	User              = sdk.FieldName("User")
+	UserAccessToken   = sdk.FieldName("User Access Token")
+	Username          = sdk.FieldName("Username")
+	UserOCID          = sdk.FieldName("User OCID")
+	Website           = sdk.FieldName("Website")
+	Zone              = sdk.FieldName("Zone")
 )
 
 func ListAll() []sdk.FieldName {
 	return []sdk.FieldName{
+		AccessKeySecret,
+		AccountKey,
 		APIHost,
 		APIKey,
 		APIKeyID,
@@ -74,20 +107,28 @@ func ListAll() []sdk.FieldName {
 		AppKey,
 		AppSecret,
 		AppToken,
+		AuthMethodID,
 		AuthToken,
 		Authtoken,
+		CACertificate,
 		Cert,
 		Certificate,
+		ClientCertificate,
+		ClientID,
+		ClientKey,
 		ClientSecret,
 		ClientToken,
+		Context,
 		Credential,
 		Credentials,
 		Database,
 		DefaultRegion,
 		Deployment,
 		Endpoint,
+		Fingerprint,
 		Host,
 		HostAddress,
+		IdentityFile,
 		Key,
 		MFASerial,
 		Mode,
@@ -96,19 +137,40 @@ func ListAll() []sdk.FieldName {
 		OrgID,
 		OrgURL,
 		Organization,
+		Passphrase,
 		Password,
 		Port,
+		Proxy,
 		PublicKey,
 		PrivateKey,
 		ProjectID,
 		Project,
+		PushToken,
 		Region,
+		Registry,
+		Repository,
+		RoleARN,
+		RoleID,
+		RoleName,
+		SecretID,
+		SecretKey,
+		Server,
+		ServerID,
+		SigningKey,
+		SigningKeyID,
+		SSORegion,
 		Secret,
 		SecretAccessKey,
+		StartURL,
+		SubscriptionID,
+		TenancyOCID,
+		TenantID,
 		Token,
 		URL,
 		User,
 		Username,
+		UserOCID,
 		Website,
+		Zone,
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+
+	"github.com/1Password/shell-plugins/sdk"
 )
 
 // Plugin provides the schema for a single shell plugin. A plugin focuses on a single platform
@@ -20,6 +22,13 @@ type Plugin struct {
 
 	// One or more specifications for the executables the plugin offers.
 	Executables []Executable
+
+	// (Optional) The non-secret user preferences the plugin supports, such as the default region.
+	Settings []Setting
+
+	// (Optional) The capabilities the plugin requires from the 1Password CLI, e.g. sdk.CapabilityPrompts. This is
+	// best-effort: only versions of the 1Password CLI that negotiate capabilities refuse to load the plugin.
+	RequiredCapabilities []sdk.Capability
 }
 
 // PlatformInfo provides information on the platform of the shell plugin.
@@ -67,6 +76,18 @@ func (p Plugin) Validate() (bool, ValidationReport) {
 		Severity:    ValidationSeverityError,
 	})
 
+	report.AddCheck(ValidationCheck{
+		Description: "Only requires capabilities supported by the SDK",
+		Assertion:   len(sdk.SupportedCapabilities.Missing(p.RequiredCapabilities...)) == 0,
+		Severity:    ValidationSeverityError,
+	})
+
+	report.AddCheck(ValidationCheck{
+		Description: "Settings have a unique, lowercase name and a description, and a default that is one of the options",
+		Assertion:   hasValidSettings(p.Settings),
+		Severity:    ValidationSeverityError,
+	})
+
 	report.AddCheck(ValidationCheck{
 		Description: "Has a credential type or executable defined",
 		Assertion:   len(p.Credentials) > 0 || len(p.Executables) > 0,
@@ -119,6 +140,24 @@ func (p Plugin) DeepValidate() []ValidationReport {
 	return reports
 }
 
+// CredentialForUsage returns the credential type of the plugin that the usage refers to, and the provisioner to use
+// for it: the provisioner of the usage if it has one, or the default provisioner of the credential type otherwise.
+// It returns nil if the usage refers to a credential type of another plugin, or one that the plugin doesn't define.
+func (p Plugin) CredentialForUsage(usage CredentialUsage) (*CredentialType, sdk.Provisioner) {
+	if usage.Plugin != "" && usage.Plugin != p.Name {
+		return nil, nil
+	}
+	for i := range p.Credentials {
+		if p.Credentials[i].Name == usage.Name {
+			if usage.Provisioner != nil {
+				return &p.Credentials[i], usage.Provisioner
+			}
+			return &p.Credentials[i], p.Credentials[i].DefaultProvisioner
+		}
+	}
+	return nil, nil
+}
+
 func (p Plugin) MarshalJSON() ([]byte, error) {
 	if len(p.Credentials) == 0 {
 		return nil, nil
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/1Password/shell-plugins/sdk/provision"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginCredentialForUsage(t *testing.T) {
+	defaultProvisioner := provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_TOKEN": "Token"})
+	usageProvisioner := provision.EnvVars(map[string]sdk.FieldName{"EXAMPLE_API_TOKEN": "Token"})
+	plugin := Plugin{
+		Name: "example",
+		Credentials: []CredentialType{
+			{Name: "API Token", DefaultProvisioner: defaultProvisioner},
+		},
+	}
+
+	credential, provisioner := plugin.CredentialForUsage(CredentialUsage{Name: "API Token"})
+	assert.Equal(t, &plugin.Credentials[0], credential)
+	assert.Equal(t, defaultProvisioner, provisioner)
+
+	credential, provisioner = plugin.CredentialForUsage(CredentialUsage{Name: "API Token", Plugin: "example", Provisioner: usageProvisioner})
+	assert.Equal(t, &plugin.Credentials[0], credential)
+	assert.Equal(t, usageProvisioner, provisioner)
+
+	credential, provisioner = plugin.CredentialForUsage(CredentialUsage{Name: "API Token", Plugin: "other"})
+	assert.Nil(t, credential, "credential types of other plugins are not defined in this plugin")
+	assert.Nil(t, provisioner)
+
+	credential, provisioner = plugin.CredentialForUsage(CredentialUsage{Name: "Personal Access Token"})
+	assert.Nil(t, credential)
+	assert.Nil(t, provisioner)
+}
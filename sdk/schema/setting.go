@@ -0,0 +1,70 @@
+package schema
+
+import "github.com/1Password/shell-plugins/sdk"
+
+// Setting describes a non-secret user preference of a plugin, such as the default region or the preferred way to
+// provision credentials. Settings are stored by the 1Password CLI and passed to provisioners in ProvisionInput, so
+// that plugins don't have to add credential fields for configuration.
+type Setting struct {
+	// The name of the setting, e.g. "default-region". Must only contain lowercase letters, digits, and dashes.
+	Name string
+
+	// A description of the setting, shown to the user when configuring the plugin.
+	Description string
+
+	// (Optional) The value to use if the user didn't configure the setting.
+	Default string
+
+	// (Optional) The valid values of the setting. If set, any other value is rejected.
+	Options []string
+}
+
+// IsValidValue returns whether the specified value is valid for the setting.
+func (s Setting) IsValidValue(value string) bool {
+	if len(s.Options) == 0 {
+		return true
+	}
+	for _, option := range s.Options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSettings returns the value of each of the specified settings, using the format: name -> value. Configured
+// values that are invalid are ignored, and settings that are not configured fall back to their default value.
+func ResolveSettings(settings []Setting, configured map[string]string) sdk.Settings {
+	resolved := make(sdk.Settings)
+	for _, setting := range settings {
+		if value, ok := configured[setting.Name]; ok && setting.IsValidValue(value) {
+			resolved[setting.Name] = value
+		} else if setting.Default != "" {
+			resolved[setting.Name] = setting.Default
+		}
+	}
+	return resolved
+}
+
+func hasValidSettings(settings []Setting) bool {
+	var names []string
+	for _, setting := range settings {
+		if setting.Name == "" || !isSettingName(setting.Name) || setting.Description == "" {
+			return false
+		}
+		if setting.Default != "" && !setting.IsValidValue(setting.Default) {
+			return false
+		}
+		names = append(names, setting.Name)
+	}
+	return IsStringSliceASet(names)
+}
+
+func isSettingName(name string) bool {
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSettings(t *testing.T) {
+	settings := []Setting{
+		{Name: "default-region", Description: "The region to use by default", Default: "eu-west-1"},
+		{Name: "provisioning", Description: "How to provision credentials", Options: []string{"env", "file"}, Default: "env"},
+		{Name: "org", Description: "The organization to use"},
+	}
+
+	assert.Equal(t, sdk.Settings{
+		"default-region": "us-east-1",
+		"provisioning":   "env",
+	}, ResolveSettings(settings, map[string]string{
+		"default-region": "us-east-1",
+		"provisioning":   "keychain",
+		"unknown":        "value",
+	}))
+}
+
+func TestHasValidSettings(t *testing.T) {
+	assert.True(t, hasValidSettings(nil))
+	assert.True(t, hasValidSettings([]Setting{{Name: "default-region", Description: "Region"}}))
+	assert.False(t, hasValidSettings([]Setting{{Name: "Default Region", Description: "Region"}}))
+	assert.False(t, hasValidSettings([]Setting{{Name: "region", Description: "Region", Options: []string{"eu"}, Default: "us"}}))
+	assert.False(t, hasValidSettings([]Setting{{Name: "region", Description: "Region"}, {Name: "region", Description: "Region"}}))
+}
@@ -95,9 +95,14 @@ func ContainsLowercaseLettersOrDigits(str string) bool {
 	return matched
 }
 
+// CredentialReferencesInCredentialList checks that the credentials used by the executables are defined in the plugin,
+// except for credentials that get referenced from other plugins.
 func CredentialReferencesInCredentialList(plugin Plugin) bool {
 	for _, executable := range plugin.Executables {
 		for _, execCredential := range executable.Uses {
+			if execCredential.Plugin != "" && execCredential.Plugin != plugin.Name {
+				continue
+			}
 			if execCredential.Name != "" {
 				found := false
 				for _, credential := range plugin.Credentials {
@@ -133,6 +138,16 @@ func AreCredentialUsagesUniquelyIdentifiable(executable Executable) bool {
 	return IsStringSliceASet(usageIds)
 }
 
+func AreExecutableAliasesValid(executable Executable) bool {
+	for _, alias := range executable.Aliases {
+		if alias == "" || strings.Contains(alias, " ") {
+			return false
+		}
+	}
+
+	return IsStringSliceASet(executable.Commands())
+}
+
 func IsStringSliceASet(slice []string) bool {
 	for i, s := range slice {
 		if i == len(slice)-1 {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/1Password/shell-plugins/sdk"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -136,6 +137,34 @@ func TestPluginValidateEachReportFieldHasError(t *testing.T) {
 	assert.False(t, c.Assertion, fmt.Sprintf("\"%s\" validation is erroneous", c.Description))
 }
 
+func TestCredentialReferencesInCredentialList(t *testing.T) {
+	credential := CredentialType{Name: sdk.CredentialName("API Token")}
+	executable := func(uses ...CredentialUsage) Executable {
+		return Executable{Uses: uses}
+	}
+
+	assert.True(t, CredentialReferencesInCredentialList(Plugin{
+		Name:        "test",
+		Credentials: []CredentialType{credential},
+		Executables: []Executable{executable(CredentialUsage{Name: credential.Name})},
+	}), "credential defined in the plugin")
+
+	assert.False(t, CredentialReferencesInCredentialList(Plugin{
+		Name:        "test",
+		Executables: []Executable{executable(CredentialUsage{Name: credential.Name})},
+	}), "credential not defined in the plugin")
+
+	assert.False(t, CredentialReferencesInCredentialList(Plugin{
+		Name:        "test",
+		Executables: []Executable{executable(CredentialUsage{Name: credential.Name, Plugin: "test"})},
+	}), "credential referenced from the plugin itself but not defined in it")
+
+	assert.True(t, CredentialReferencesInCredentialList(Plugin{
+		Name:        "test",
+		Executables: []Executable{executable(CredentialUsage{Name: credential.Name, Plugin: "other"})},
+	}), "credential referenced from another plugin")
+}
+
 func TestIsStringSliceASet(t *testing.T) {
 	testCases := []struct {
 		slice     []string
@@ -165,3 +194,51 @@ func TestIsStringSliceASet(t *testing.T) {
 		assert.Equal(t, tc.assertion, IsStringSliceASet(tc.slice))
 	}
 }
+
+func TestAreExecutableAliasesValid(t *testing.T) {
+	cases := map[string]struct {
+		executable Executable
+		expected   bool
+	}{
+		"when no aliases are set": {
+			executable: Executable{Runs: []string{"terraform"}},
+			expected:   true,
+		},
+		"when aliases are unique": {
+			executable: Executable{Runs: []string{"fd"}, Aliases: []string{"fdfind"}},
+			expected:   true,
+		},
+		"when alias equals the executable command": {
+			executable: Executable{Runs: []string{"docker"}, Aliases: []string{"docker"}},
+			expected:   false,
+		},
+		"when aliases are duplicated": {
+			executable: Executable{Runs: []string{"terraform"}, Aliases: []string{"tofu", "tofu"}},
+			expected:   false,
+		},
+		"when alias is empty": {
+			executable: Executable{Runs: []string{"terraform"}, Aliases: []string{""}},
+			expected:   false,
+		},
+		"when alias contains a space": {
+			executable: Executable{Runs: []string{"terraform"}, Aliases: []string{"open tofu"}},
+			expected:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			isValid := AreExecutableAliasesValid(tc.executable)
+			assert.Equal(t, tc.expected, isValid, fmt.Sprintf("should return %t", tc.expected))
+		})
+	}
+}
+
+func TestExecutableRunsCommand(t *testing.T) {
+	e := Executable{Runs: []string{"terraform"}, Aliases: []string{"tofu"}}
+
+	assert.Equal(t, []string{"terraform", "tofu"}, e.Commands())
+	assert.True(t, e.RunsCommand("terraform"))
+	assert.True(t, e.RunsCommand("tofu"))
+	assert.False(t, e.RunsCommand("terragrunt"))
+}
@@ -0,0 +1,9 @@
+package sdk
+
+// Settings contains the non-secret user preferences of a plugin, using the format: setting name -> value.
+type Settings map[string]string
+
+// Get returns the value of the specified setting, or an empty string if it's not set.
+func (s Settings) Get(name string) string {
+	return s[name]
+}
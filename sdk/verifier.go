@@ -0,0 +1,63 @@
+package sdk
+
+import "context"
+
+// Verifier performs a cheap, read-only call against the platform to confirm that a credential works, so that users
+// get a clear message when setting up a credential that is invalid or expired, rather than an error from the
+// executable later on.
+type Verifier func(ctx context.Context, in VerifyInput, out *VerifyOutput)
+
+// VerifyInput contains info that verifiers can use to verify a credential.
+type VerifyInput struct {
+	HomeDir string
+	TempDir string
+
+	// ItemFields contains the field names and their corresponding (sensitive) values.
+	ItemFields map[FieldName]string
+}
+
+// VerificationStatus is the outcome of verifying a credential.
+type VerificationStatus string
+
+const (
+	VerificationStatusUnknown VerificationStatus = ""
+	VerificationStatusValid   VerificationStatus = "valid"
+	VerificationStatusInvalid VerificationStatus = "invalid"
+	VerificationStatusExpired VerificationStatus = "expired"
+)
+
+// VerifyOutput contains the outcome of verifying a credential.
+type VerifyOutput struct {
+	// Status is the outcome of the verification. Remains unknown if the credential could not be verified,
+	// for example because the platform could not be reached.
+	Status VerificationStatus
+
+	// Message describes the outcome to the user, e.g. "Authenticated as octocat" or "The token has been revoked".
+	Message string
+
+	// Diagnostics can be used to report errors that prevented the verification.
+	Diagnostics Diagnostics
+}
+
+// Valid marks the credential as valid, with the specified message for the user.
+func (out *VerifyOutput) Valid(message string) {
+	out.Status = VerificationStatusValid
+	out.Message = message
+}
+
+// Invalid marks the credential as invalid, with the specified reason for the user.
+func (out *VerifyOutput) Invalid(reason string) {
+	out.Status = VerificationStatusInvalid
+	out.Message = reason
+}
+
+// Expired marks the credential as expired, with the specified reason for the user.
+func (out *VerifyOutput) Expired(reason string) {
+	out.Status = VerificationStatusExpired
+	out.Message = reason
+}
+
+// AddError can be used to report an error that prevented the verification.
+func (out *VerifyOutput) AddError(err error) {
+	out.Diagnostics.Errors = append(out.Diagnostics.Errors, newError(err))
+}
@@ -0,0 +1,79 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/1Password/shell-plugins/sdk"
+)
+
+// RequestBuilder builds the HTTP request to verify the credential with, e.g. by setting the credential
+// as a bearer token.
+type RequestBuilder func(ctx context.Context, in sdk.VerifyInput) (*http.Request, error)
+
+// HTTPOption can be used to influence the behavior of the HTTP verifier.
+type HTTPOption func(*httpVerifier)
+
+// WithClient can be used to set the HTTP client that sends the verification request.
+func WithClient(client *http.Client) HTTPOption {
+	return func(v *httpVerifier) {
+		v.client = client
+	}
+}
+
+// HTTP returns a verifier that sends the request built by the specified builder, and considers the credential valid
+// if the response has a 2xx status code, and invalid if it has a 401 or 403 status code. Any other status code is
+// reported as an error, since it says nothing about the credential.
+func HTTP(build RequestBuilder, opts ...HTTPOption) sdk.Verifier {
+	v := httpVerifier{
+		build:  build,
+		client: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v.verify
+}
+
+// GetWithBearerToken returns a verifier that sends a GET request to the specified URL, with the value of the
+// specified field as bearer token.
+func GetWithBearerToken(url string, fieldName sdk.FieldName, opts ...HTTPOption) sdk.Verifier {
+	return HTTP(func(ctx context.Context, in sdk.VerifyInput) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+in.ItemFields[fieldName])
+		return req, nil
+	}, opts...)
+}
+
+type httpVerifier struct {
+	build  RequestBuilder
+	client *http.Client
+}
+
+func (v httpVerifier) verify(ctx context.Context, in sdk.VerifyInput, out *sdk.VerifyOutput) {
+	req, err := v.build(ctx, in)
+	if err != nil {
+		out.AddError(err)
+		return
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		out.AddError(fmt.Errorf("sending verification request: %w", err))
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		out.Valid("The credential is valid")
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		out.Invalid(fmt.Sprintf("The credential was rejected by %s (%s)", req.URL.Host, resp.Status))
+	default:
+		out.AddError(fmt.Errorf("unexpected response from %s: %s", req.URL.Host, resp.Status))
+	}
+}
@@ -0,0 +1,46 @@
+package verify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/1Password/shell-plugins/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWithBearerToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer tkn_valid":
+			w.WriteHeader(http.StatusOK)
+		case "Bearer tkn_unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	verifier := GetWithBearerToken(server.URL+"/user", "Token", WithClient(server.Client()))
+	verifyToken := func(token string) sdk.VerifyOutput {
+		out := sdk.VerifyOutput{}
+		verifier(context.Background(), sdk.VerifyInput{ItemFields: map[sdk.FieldName]string{"Token": token}}, &out)
+		return out
+	}
+
+	valid := verifyToken("tkn_valid")
+	assert.Equal(t, sdk.VerificationStatusValid, valid.Status)
+
+	invalid := verifyToken("tkn_revoked")
+	assert.Equal(t, sdk.VerificationStatusInvalid, invalid.Status)
+	assert.Contains(t, invalid.Message, "401 Unauthorized")
+
+	unavailable := verifyToken("tkn_unavailable")
+	assert.Equal(t, sdk.VerificationStatusUnknown, unavailable.Status)
+	if assert.Len(t, unavailable.Diagnostics.Errors, 1) {
+		assert.True(t, strings.HasSuffix(unavailable.Diagnostics.Errors[0].Message, "503 Service Unavailable"))
+	}
+}